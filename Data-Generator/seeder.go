@@ -1,33 +1,66 @@
 // Autor: Diego Fernando Alba Novoa
 // Descripción: Este programa genera cuentas bancarias y transacciones aleatorias
-//              y las inserta en una base de datos MySQL. Cada cuenta tiene un número
+//              y las inserta en una base de datos MySQL o SQLite. Cada cuenta tiene un número
 //              único y un balance inicial aleatorio, seguido de un conjunto de transacciones
 //              (depósitos y retiros) que se generan aleatoriamente. Es útil para simular
 //              escenarios de carga en un sistema bancario.
 // Fecha: 10/09/2024
+//
+// Uso:
+//
+//	go run ./Data-Generator                                  # MySQL del contenedor de Docker
+//	go run ./Data-Generator -driver sqlite -dsn bank.db      # Archivo SQLite local
+//
+// El esquema debe existir antes de sembrar: con SQLite basta con arrancar el servicio una vez
+// o ejecutar "BANK_DB_DRIVER=sqlite bankservice migrate up -dsn bank.db" sobre el mismo archivo.
 
 package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql" // Importamos el driver MySQL
+	_ "modernc.org/sqlite"             // Importamos el driver SQLite (Go puro, sin cgo)
 )
 
 // Constantes de configuración
 const (
-	dsn             = "bankuser:bankpassword@tcp(127.0.0.1:3306)/bankdb" // DSN (Data Source Name) para la conexión a MySQL
+	defaultDSN      = "bankuser:bankpassword@tcp(127.0.0.1:3306)/bankdb" // DSN (Data Source Name) por defecto para la conexión a MySQL
 	numAccounts     = 100                                                // Número de cuentas bancarias a generar
 	numTransactions = 500                                                // Número de transacciones a generar por cada cuenta
 )
 
+// sqliteParams son las opciones de conexión de SQLite: esperar a que se libere el archivo si el servicio
+// lo está usando y guardar las fechas con el mismo formato que el servicio
+const sqliteParams = "_pragma=busy_timeout(5000)&_time_format=sqlite"
+
 func main() {
+	// Leer el motor y la DSN desde la línea de comandos
+	driver := flag.String("driver", "mysql", "Motor de la base de datos: mysql o sqlite")
+	dsn := flag.String("dsn", defaultDSN, "DSN de MySQL o ruta del archivo SQLite")
+	flag.Parse()
+
+	dataSource := *dsn
+	switch *driver {
+	case "mysql":
+	case "sqlite":
+		// Con SQLite la DSN es la ruta del archivo; se agregan las opciones de conexión
+		if dataSource == defaultDSN {
+			log.Fatal("Indique la ruta del archivo SQLite con -dsn")
+		}
+		dataSource += "?" + sqliteParams
+	default:
+		log.Fatalf("Motor de base de datos no soportado: %q", *driver)
+	}
+
 	// Conectar a la base de datos usando la DSN proporcionada
-	db, err := sql.Open("mysql", dsn)
+	db, err := sql.Open(*driver, dataSource)
 	if err != nil {
 		// Si hay un error al conectar, se imprime el error y se detiene el programa
 		log.Fatal("Error al conectar a la base de datos:", err)
@@ -51,7 +84,8 @@ func main() {
 		accountNumber := fmt.Sprintf("ACC%04d", i+1)
 
 		// Generar un balance inicial aleatorio entre 0 y 10,000 para la cuenta
-		initialBalance := rand.Float64() * 10000
+		// Los montos se redondean a centavos para que ambos motores guarden el mismo valor
+		initialBalance := roundCents(rand.Float64() * 10000)
 
 		// Insertar la cuenta bancaria con su número y balance en la base de datos
 		_, err := db.Exec("INSERT INTO accounts (account_number, balance) VALUES (?, ?)", accountNumber, initialBalance)
@@ -71,7 +105,7 @@ func main() {
 		// Bucle para generar transacciones para la cuenta
 		for j := 0; j < numTransactions; j++ {
 			// Generar un monto aleatorio para la transacción entre 0 y 1,000
-			amount := rand.Float64() * 1000
+			amount := roundCents(rand.Float64() * 1000)
 
 			// Determinar aleatoriamente si la transacción es un depósito o un retiro
 			transactionType := "deposit"
@@ -94,3 +128,8 @@ func main() {
 	// Al final del proceso, imprimir que se ha completado la generación de datos
 	log.Println("Proceso de generación de datos completado.")
 }
+
+// roundCents redondea un monto a dos decimales.
+func roundCents(x float64) float64 {
+	return math.Round(x*100) / 100
+}
@@ -1,15 +0,0 @@
-CREATE TABLE IF NOT EXISTS accounts (
-                                        id INT AUTO_INCREMENT PRIMARY KEY,
-                                        account_number VARCHAR(20) NOT NULL,
-    balance DECIMAL(15, 2) NOT NULL,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-    );
-
-CREATE TABLE IF NOT EXISTS transactions (
-                                            id INT AUTO_INCREMENT PRIMARY KEY,
-                                            account_id INT NOT NULL,
-                                            amount DECIMAL(15, 2) NOT NULL,
-    transaction_type ENUM('deposit', 'withdrawal') NOT NULL,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    FOREIGN KEY (account_id) REFERENCES accounts(id)
-    );
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"Transaction-System/internal/application" // Servicio de cambio de divisas
+	"Transaction-System/internal/config"      // Configuración del cambio de divisas
+	"Transaction-System/internal/domain/fx"   // Tabla estática de tipos de cambio
+	"fmt"                                     // Paquete para formatear errores
+	"log/slog"                                // Paquete para el logging estructurado
+	"time"                                    // Fecha de carga de la tabla estática
+)
+
+// newFXService crea el servicio de cambio de divisas.
+// La tabla estática se carga de fx.rates_file; si el archivo es el de la configuración por defecto y no existe,
+// el servicio arranca sin tabla estática y sólo cotiza los pares fijados manualmente.
+func newFXService(cfg config.FXConfig, store *storage) (*application.FXService, error) {
+	var static fx.Provider
+	if cfg.RatesFile != "" {
+		table, err := config.LoadRates(cfg.RatesFile, cfg.RatesFile != config.Default().FX.RatesFile)
+		if err != nil {
+			return nil, err
+		}
+		provider, err := fx.NewStaticProvider(table, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("tabla de tipos de cambio inválida en %s: %w", cfg.RatesFile, err)
+		}
+		static = provider
+		slog.Info("tabla de tipos de cambio cargada", "file", cfg.RatesFile, "pairs", len(table))
+	}
+	return application.NewFXService(static, store.rates, store.quotes, application.FXPolicy{
+		SpreadBPS: cfg.SpreadBPS,
+		QuoteTTL:  cfg.QuoteTTL,
+	}), nil
+}
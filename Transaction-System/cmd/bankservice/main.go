@@ -10,109 +10,390 @@ package main
 import (
 	_ "Transaction-System/internal/infrastructure/http-conection"              // Importación implícita para inicializar la conexión HTTP
 	http_conection "Transaction-System/internal/infrastructure/http-conection" // Alias explícito para manejar HTTP
+	"context"                                                                  // Paquete para cancelar el servicio al recibir una señal
 	"database/sql"                                                             // Paquete para trabajar con bases de datos SQL
-	"fmt"                                                                      // Paquete para formatear cadenas
-	"log"                                                                      // Paquete para loguear mensajes de información o errores
+	"fmt"                                                                      // Paquete para formatear errores
+	"log/slog"                                                                 // Paquete para el logging estructurado
 	"net/http"                                                                 // Paquete para manejar solicitudes HTTP
 	"os"                                                                       // Paquete para interactuar con el sistema operativo, en este caso para crear archivos
+	"os/signal"                                                                // Paquete para capturar las señales de apagado
 	"runtime/trace"                                                            // Paquete para habilitar tracing y análisis de rendimiento
+	"sync"                                                                     // Paquete para esperar las tareas en segundo plano
+	"syscall"                                                                  // Paquete que define SIGTERM
 	"time"                                                                     // Paquete para trabajar con fechas y tiempos
 
 	_ "net/http/pprof" // Paquete para habilitar el perfilado de pprof en el servidor
 
 	"Transaction-System/internal/application"             // Módulo de aplicación para manejar la lógica de negocio
+	"Transaction-System/internal/config"                  // Carga de la configuración del servicio
 	_ "Transaction-System/internal/domain/account"        // Módulo de dominio para gestionar cuentas
 	_ "Transaction-System/internal/domain/transaction"    // Módulo de dominio para gestionar transacciones
-	"Transaction-System/internal/infrastructure/database" // Módulo de infraestructura para interactuar con la base de datos
+	"Transaction-System/internal/infrastructure/database" // Conexión a la base de datos (MySQL, PostgreSQL o SQLite)
+	"Transaction-System/internal/infrastructure/health"   // Comprobaciones de estado del servicio
+	"Transaction-System/internal/infrastructure/logging"  // Logging estructurado con identificador de solicitud
+	"Transaction-System/internal/infrastructure/metrics"  // Métricas en formato Prometheus
 	_ "github.com/go-sql-driver/mysql"                    // Driver MySQL para Go
 )
 
-// Middleware para registrar las solicitudes HTTP entrantes
-// Este middleware registra el método, URI y la IP de origen de cada solicitud, así como el tiempo de ejecución.
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now() // Captura el tiempo de inicio de la solicitud
-
-		// Registra la solicitud entrante
-		log.Printf("Solicitud entrante: %s %s desde %s", r.Method, r.RequestURI, r.RemoteAddr)
-
-		// Pasa la solicitud al siguiente manejador en la cadena
-		next.ServeHTTP(w, r)
+func main() {
+	// El subcomando migrate administra el esquema de la base de datos sin iniciar el servicio
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			slog.Error("error al ejecutar las migraciones", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-		// Calcula y registra el tiempo que tomó procesar la solicitud
-		log.Printf("Solicitud completada en %v", time.Since(start))
-	})
+	// run concentra todo el ciclo de vida del servicio; al retornar ya se ejecutaron sus defer
+	// (cierre de la base de datos y del trace), por lo que aquí sólo queda informar el resultado
+	if err := run(); err != nil {
+		slog.Error("el servicio terminó con error", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("servicio detenido correctamente")
 }
 
-func main() {
-	// Crear un archivo de trace que almacenará el rastro de ejecución del sistema
-	traceFile, err := os.Create("trace.out")
+// run inicia el servicio y lo mantiene en ejecución hasta recibir SIGINT o SIGTERM.
+// Al apagar se terminan las solicitudes en curso, se detienen los servidores HTTP y pprof
+// y, en ese orden, se cierran la base de datos y el trace.
+func run() error {
+	// Cargar la configuración: valores por defecto, configs/config.yaml, variables de entorno y banderas
+	// Una configuración inválida detiene el programa antes de abrir cualquier recurso
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
 	if err != nil {
-		// Si no se puede crear el archivo de trace, se registra un error y se detiene el programa
-		log.Fatalf("No se puede crear el archivo de trace: %v", err)
+		return fmt.Errorf("error al cargar la configuración: %w", err)
 	}
-	defer traceFile.Close() // Asegurarse de cerrar el archivo de trace al finalizar el programa
 
-	// Iniciar el trace para comenzar a capturar eventos de ejecución
-	if err := trace.Start(traceFile); err != nil {
-		log.Fatalf("No se puede iniciar el trace: %v", err)
+	// Configurar el logging estructurado
+	if err := setupLogging(cfg.Logging); err != nil {
+		return err
 	}
-	defer trace.Stop() // Detener el trace cuando el programa finalice
 
-	// Configurar la conexión a la base de datos MySQL usando el DSN (Data Source Name)
-	// El DSN incluye las credenciales y la dirección del servidor MySQL
-	dsn := "bankuser:bankpassword@tcp(127.0.0.1:3306)/bankdb"
-	db, err := sql.Open("mysql", dsn) // Abre una conexión a la base de datos
+	// Cancelar ctx al recibir SIGINT (Ctrl+C) o SIGTERM (por ejemplo, docker stop)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.Trace.Enabled {
+		// Crear un archivo de trace que almacenará el rastro de ejecución del sistema
+		traceFile, err := os.Create(cfg.Trace.File)
+		if err != nil {
+			// Si no se puede crear el archivo de trace, se detiene el programa
+			return fmt.Errorf("no se puede crear el archivo de trace: %w", err)
+		}
+		defer traceFile.Close() // Asegurarse de cerrar el archivo de trace al finalizar el programa
+
+		// Iniciar el trace para comenzar a capturar eventos de ejecución
+		if err := trace.Start(traceFile); err != nil {
+			return fmt.Errorf("no se puede iniciar el trace: %w", err)
+		}
+		defer trace.Stop() // Detener el trace (y volcarlo al archivo) cuando el programa finalice
+	}
+
+	// Preparar el backend de almacenamiento (MySQL o en memoria) y su unidad de trabajo
+	store, err := openStorage(ctx, cfg)
 	if err != nil {
-		// Si hay un error al conectar a la base de datos, se registra y se detiene el programa
-		log.Fatalf("Error al conectar a la base de datos: %v", err)
+		return err
 	}
-	defer db.Close() // Cerrar la conexión a la base de datos al finalizar el programa
+	defer store.Close() // Cerrar la conexión a la base de datos al finalizar el programa
+	unitOfWork := store.unitOfWork
 
-	// Verificar la conexión a la base de datos con un "ping"
-	if err := db.Ping(); err != nil {
-		// Si no se puede establecer una conexión estable, se registra el error y se detiene el programa
-		log.Fatalf("No se puede conectar a la base de datos: %v", err)
+	// Crear las métricas del servicio, incluidas las estadísticas del pool de conexiones a MySQL si se usa
+	var telemetry *metrics.Metrics
+	serviceOptions := []application.Option{application.WithRetryPolicy(application.RetryPolicy{
+		MaxAttempts: cfg.Retry.MaxAttempts,
+		BaseDelay:   cfg.Retry.BaseDelay,
+		MaxDelay:    cfg.Retry.MaxDelay,
+	})}
+	if cfg.Metrics.Enabled {
+		telemetry = metrics.New()
+		if store.db != nil {
+			telemetry.RegisterDB("bankdb", store.db)
+		}
+		// Contar los depósitos, retiros y transferencias por resultado
+		serviceOptions = append(serviceOptions, application.WithObserver(telemetry))
 	}
 
-	// Inicializar los repositorios de cuentas y transacciones, que interactúan con la base de datos
-	accountRepo := database.NewAccountRepository(db)
-	transactionRepo := database.NewTransactionRepository(db)
+	// Crear el servicio de cambio de divisas con la tabla estática de tipos de cambio, si la hay,
+	// y habilitar las transferencias entre monedas liquidadas con sus cotizaciones
+	fxService, err := newFXService(cfg.FX, store)
+	if err != nil {
+		return err
+	}
+	serviceOptions = append(serviceOptions, application.WithQuotes(store.quotes))
 
 	// Crear el servicio de transacciones, que contiene la lógica para manejar las transacciones de cuentas
-	transactionService := application.NewTransactionService(accountRepo, transactionRepo)
+	transactionService := application.NewTransactionService(unitOfWork, serviceOptions...)
+
+	// Crear el servicio de cuentas, que gestiona la apertura, consulta y cambios de estado de las cuentas
+	accountService := application.NewAccountService(unitOfWork)
+
+	// Crear el servicio del libro mayor, que deriva y verifica balances a partir de los asientos contables
+	ledgerService := application.NewLedgerService(unitOfWork)
+
+	// Crear el servicio de intereses, que cobra diariamente los intereses y la comisión sobre el sobregiro en uso;
+	// con los intereses deshabilitados la tasa y la comisión quedan en cero y no se cobra nada
+	interestPolicy := application.InterestPolicy{DaysPerYear: cfg.Overdraft.DaysPerYear}
+	if cfg.Overdraft.InterestEnabled {
+		if interestPolicy.AnnualRate, err = cfg.Overdraft.AnnualRate(); err != nil {
+			return err
+		}
+		if interestPolicy.DailyFee, err = cfg.Overdraft.Fee(); err != nil {
+			return err
+		}
+	}
+	interestService := application.NewInterestService(unitOfWork, interestPolicy)
+
+	// Crear el servicio de retenciones, que autoriza, captura y libera retenciones de fondos
+	holdService := application.NewHoldService(unitOfWork, application.HoldPolicy{
+		DefaultTTL: cfg.Holds.DefaultTTL,
+		MaxTTL:     cfg.Holds.MaxTTL,
+	})
 
 	// Crear los controladores HTTP para manejar las solicitudes de depósito y retiro
 	accountHandler := http_conection.NewAccountHandler(transactionService)
+	// Crear el controlador HTTP para el ciclo de vida de las cuentas
+	accountLifecycleHandler := http_conection.NewAccountLifecycleHandler(accountService)
+	// Crear el controlador HTTP para consultar el libro mayor
+	ledgerHandler := http_conection.NewLedgerHandler(ledgerService)
+	// Crear el controlador HTTP de cotizaciones y tipos de cambio
+	fxHandler := http_conection.NewFXHandler(fxService)
+	// Crear el controlador HTTP de administración de los intereses de sobregiro
+	interestHandler := http_conection.NewInterestHandler(interestService)
+	// Crear el controlador HTTP de retenciones de fondos
+	holdHandler := http_conection.NewHoldHandler(holdService)
+
+	// Registrar las comprobaciones de disponibilidad que consulta /readyz
+	// Otras dependencias pueden agregar las suyas con readiness.Register
+	shutdownState := &health.Shutdown{}
+	readiness := health.NewRegistry(cfg.Health.CheckTimeout)
+	for _, c := range store.checks {
+		readiness.Register(c.name, c.checker)
+	}
+	readiness.Register("shutdown", shutdownState)
+	// Al recibir la señal de apagado, /readyz deja de responder 200 de inmediato
+	context.AfterFunc(ctx, shutdownState.Begin)
+	// Crear el controlador HTTP de estado del servicio
+	healthHandler := http_conection.NewHealthHandler(readiness)
+
+	// Las tareas en segundo plano se detienen y se esperan antes de cerrar la base de datos
+	var background sync.WaitGroup
+	defer func() {
+		stop()
+		background.Wait()
+	}()
+
+	// Las rutas que mueven dinero se envuelven con la deduplicación por Idempotency-Key si está habilitada
+	withIdempotency := func(h http.HandlerFunc) http.HandlerFunc { return h }
+	if cfg.Idempotency.Enabled {
+		// Crear el componente de idempotencia, que deduplica los reintentos mediante la cabecera Idempotency-Key
+		idempotencyKeys := http_conection.NewIdempotency(store.idempotency, cfg.Idempotency.Retention)
+		withIdempotency = idempotencyKeys.Wrap
+
+		// Eliminar periódicamente las claves de idempotencia cuyo periodo de retención terminó,
+		// hasta que comience el apagado
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			ticker := time.NewTicker(cfg.Idempotency.PurgeInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				if deleted, err := idempotencyKeys.PurgeExpired(ctx); err != nil {
+					slog.Error("no se pudieron eliminar las claves de idempotencia expiradas", "error", err)
+				} else if deleted > 0 {
+					slog.Info("claves de idempotencia expiradas eliminadas", "deleted", deleted)
+				}
+			}
+		}()
+	}
+
+	if cfg.Overdraft.InterestEnabled {
+		// Cobrar periódicamente los intereses de sobregiro del día anterior (UTC), hasta que comience
+		// el apagado; cada cuenta se cobra una sola vez por día aunque el cálculo se repita
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			ticker := time.NewTicker(cfg.Overdraft.AccrualInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				report, err := interestService.AccrueOverdraftInterest(ctx, time.Now().UTC().AddDate(0, 0, -1))
+				if err != nil {
+					slog.Error("no se pudieron cobrar los intereses de sobregiro", "error", err)
+				}
+				if report != nil && len(report.Accruals) > 0 {
+					slog.Info("intereses de sobregiro cobrados", "day", report.Day.Format(time.DateOnly), "accounts", len(report.Accruals))
+				}
+			}
+		}()
+	}
+
+	// Liberar periódicamente las retenciones vencidas sin capturar, hasta que comience el apagado
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		ticker := time.NewTicker(cfg.Holds.ExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			expired, err := holdService.ExpireHolds(ctx, time.Now())
+			if err != nil {
+				slog.Error("no se pudieron liberar las retenciones vencidas", "error", err)
+			}
+			if expired > 0 {
+				slog.Info("retenciones vencidas liberadas", "expired", expired)
+			}
+		}
+	}()
 
 	// Crear un nuevo "mux" que se encargará de enrutar las solicitudes HTTP
 	mux := http.NewServeMux()
 
+	// handle registra una ruta; con las métricas habilitadas, sus solicitudes se cuentan y se miden
+	// con el patrón de la ruta como etiqueta
+	handle := func(pattern string, handler http.HandlerFunc) {
+		if telemetry == nil {
+			mux.HandleFunc(pattern, handler)
+			return
+		}
+		mux.Handle(pattern, telemetry.InstrumentRoute(pattern, handler))
+	}
+
+	// admin registra una ruta de administración, que exige el token de admin.token; sin token configurado
+	// las rutas de administración no se registran y responden 404
+	admin := func(pattern string, handler http.HandlerFunc) {
+		if cfg.Admin.Token != "" {
+			handle(pattern, http_conection.RequireAdminToken(cfg.Admin.Token, handler))
+		}
+	}
+	if cfg.Admin.Token == "" {
+		slog.Warn("rutas de administración deshabilitadas: admin.token está vacío")
+	}
+
 	// Definir las rutas HTTP y asociarlas con los manejadores correspondientes
+	// Las rutas que mueven dinero aceptan la cabecera Idempotency-Key para deduplicar reintentos
 	// La ruta "/deposit" manejará las solicitudes POST para depósitos en cuentas
-	mux.HandleFunc("/deposit", accountHandler.DepositHandler)
+	handle("/deposit", withIdempotency(accountHandler.DepositHandler))
 	// La ruta "/withdraw" manejará las solicitudes POST para retiros de cuentas
-	mux.HandleFunc("/withdraw", accountHandler.WithdrawHandler)
+	handle("/withdraw", withIdempotency(accountHandler.WithdrawHandler))
+	// La ruta "/transfers" manejará las solicitudes POST para transferencias entre cuentas
+	handle("POST /transfers", withIdempotency(accountHandler.TransferHandler))
+	// La ruta "/transactions/{id}/reverse" revierte un movimiento con una transacción compensatoria
+	handle("POST /transactions/{id}/reverse", withIdempotency(accountHandler.ReverseHandler))
+	// Las rutas "/holds/..." autorizan retenciones de fondos y luego las capturan o liberan
+	handle("POST /holds", withIdempotency(holdHandler.AuthorizeHandler))
+	handle("GET /holds/{id}", holdHandler.GetHandler)
+	handle("POST /holds/{id}/capture", withIdempotency(holdHandler.CaptureHandler))
+	handle("POST /holds/{id}/release", holdHandler.ReleaseHandler)
+	// Las rutas "/accounts/..." permiten abrir, consultar, listar y cambiar el estado de las cuentas
+	handle("POST /accounts", accountLifecycleHandler.OpenHandler)
+	handle("GET /accounts", accountLifecycleHandler.ListHandler)
+	handle("GET /accounts/{id}", accountLifecycleHandler.GetHandler)
+	handle("PATCH /accounts/{id}/status", accountLifecycleHandler.ChangeStatusHandler)
+	handle("PUT /accounts/{id}/overdraft", accountLifecycleHandler.SetOverdraftHandler)
+	// Ruta para consultar el historial de transacciones de una cuenta
+	handle("GET /accounts/{id}/transactions", accountHandler.HistoryHandler)
+	// Las rutas "/ledger/..." permiten verificar los balances contra el libro mayor
+	handle("GET /ledger/accounts/{id}", ledgerHandler.VerifyAccountHandler)
+	handle("GET /ledger/trial-balance", ledgerHandler.TrialBalanceHandler)
+	// Las rutas "/fx/..." emiten cotizaciones de cambio y publican los tipos vigentes;
+	// las rutas "/admin/fx/..." fijan o eliminan tipos de cambio manuales y exigen el token de administración
+	handle("POST /fx/quotes", fxHandler.QuoteHandler)
+	handle("GET /fx/quotes/{id}", fxHandler.GetQuoteHandler)
+	handle("GET /fx/rates", fxHandler.ListRatesHandler)
+	admin("PUT /admin/fx/rates/{base}/{quote}", fxHandler.SetRateHandler)
+	admin("DELETE /admin/fx/rates/{base}/{quote}", fxHandler.DeleteRateHandler)
+	// Ruta para cobrar manualmente los intereses y la comisión de sobregiro de un día; exige el token de administración
+	admin("POST /admin/overdraft/interest", interestHandler.AccrueHandler)
+	// Rutas de estado para los orquestadores: proceso vivo y disponibilidad de las dependencias
+	mux.HandleFunc("GET /healthz", healthHandler.LivenessHandler)
+	mux.HandleFunc("GET /readyz", healthHandler.ReadinessHandler)
+	// Ruta de métricas para Prometheus
+	if telemetry != nil {
+		mux.Handle("GET "+cfg.Metrics.Path, telemetry.Handler())
+	}
 
-	// Habilitar pprof en un puerto separado (6060) para permitir el monitoreo de rendimiento
-	go func() {
-		log.Println("Iniciando el servidor de pprof en :6060")
-		// Iniciar el servidor pprof
-		log.Println(http.ListenAndServe("localhost:6060", nil))
-	}()
+	// Aplicar los middlewares de logging al mux: primero se asigna el identificador de la solicitud
+	// y luego se registra una línea estructurada por cada solicitud atendida
+	loggingHandler := http_conection.RequestIDMiddleware(http_conection.AccessLogMiddleware(mux))
+
+	// Crear el servidor HTTP principal con los tiempos límite configurados, para que un cliente lento
+	// no retenga conexiones indefinidamente
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           loggingHandler, // El servidor usará el manejador con logging aplicado
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
 
-	// Aplicar el middleware de logging al mux, para que todas las solicitudes pasen por el logger
-	loggingHandler := loggingMiddleware(mux)
+	servers := []*http.Server{server}
+	slog.Info("iniciando el servidor HTTP", "addr", cfg.Server.Addr)
 
-	// Determinar el puerto en el que el servidor HTTP principal escuchará solicitudes
-	// Si no se define un puerto en las variables de entorno, usar el puerto por defecto (8080)
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Habilitar pprof en una dirección separada para permitir el monitoreo de rendimiento
+	// Sin WriteTimeout, porque los perfiles de CPU y los trace pueden tardar lo que indique la solicitud
+	if cfg.Pprof.Enabled {
+		servers = append(servers, &http.Server{
+			Addr:              cfg.Pprof.Addr,
+			Handler:           http.DefaultServeMux, // net/http/pprof registra sus rutas en el mux por defecto
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		})
+		slog.Info("iniciando el servidor de pprof", "addr", cfg.Pprof.Addr)
 	}
 
-	// Iniciar el servidor HTTP en el puerto especificado
-	log.Printf("Iniciando el servidor HTTP en el puerto %s\n", port)
-	// El servidor usará el manejador con logging aplicado
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), loggingHandler))
+	// Atender solicitudes hasta recibir una señal de apagado y luego drenar las solicitudes en curso
+	return serve(ctx, cfg.Server.ShutdownTimeout, servers...)
+}
+
+// setupLogging configura el logger por defecto con el nivel y el formato indicados.
+// Los registros incluyen el request_id de la solicitud en curso y el paquete log estándar
+// también escribe a través de este logger.
+func setupLogging(cfg config.LoggingConfig) error {
+	level, err := logging.ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	logger, err := logging.NewLogger(os.Stdout, cfg.Format, level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// openDatabase abre la conexión a la base de datos usando el DSN (Data Source Name), que con MySQL y PostgreSQL
+// incluye las credenciales y la dirección del servidor y con SQLite es la ruta del archivo, dimensiona el pool
+// de conexiones y verifica la conexión con un "ping".
+func openDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := database.Open(database.Driver(cfg.Driver), cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar a la base de datos: %w", err)
+	}
+
+	// Dimensionar el pool de conexiones según la configuración
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	// Si no se puede establecer una conexión estable, se detiene el programa
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("no se puede conectar a la base de datos: %w", err)
+	}
+	return db, nil
 }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"Transaction-System/internal/config"                  // Carga de la configuración del servicio
+	"Transaction-System/internal/infrastructure/database" // Migraciones del esquema de cada motor
+	"Transaction-System/internal/infrastructure/migrate"  // Ejecución de las migraciones
+	"context"                                             // Cancelación al recibir una señal
+	"fmt"                                                 // Paquete para formatear la salida
+	"os"                                                  // Argumentos, salida estándar y señales
+	"os/signal"                                           // Paquete para capturar las señales de interrupción
+	"strconv"                                             // Conversión de la cantidad de pasos
+	"syscall"                                             // Paquete que define SIGTERM
+	"text/tabwriter"                                      // Salida tabulada del estado
+)
+
+// migrateUsage describe el uso del subcomando migrate.
+const migrateUsage = "uso: bankservice migrate up|down [pasos]|status [banderas de configuración]"
+
+// runMigrate ejecuta el subcomando migrate:
+//   - up aplica todas las migraciones pendientes.
+//   - down [pasos] revierte las últimas migraciones aplicadas (una por defecto).
+//   - status muestra el estado de cada migración.
+//
+// Después de la acción se aceptan las mismas banderas que el servicio (por ejemplo -config o -dsn).
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("falta la acción; %s", migrateUsage)
+	}
+	action, args := args[0], args[1:]
+
+	// Cantidad de migraciones a revertir con down
+	steps := 1
+	if action == "down" && len(args) > 0 && !isFlag(args[0]) {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("cantidad de pasos inválida: %q", args[0])
+		}
+		steps, args = n, args[1:]
+	}
+
+	cfg, err := config.Load(args, os.LookupEnv)
+	if err != nil {
+		return fmt.Errorf("error al cargar la configuración: %w", err)
+	}
+	if err := setupLogging(cfg.Logging); err != nil {
+		return err
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("el driver %s no tiene un esquema que migrar", cfg.Database.Driver)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := openDatabase(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	migrator, err := database.NewMigrator(db, database.Driver(cfg.Database.Driver), cfg.Migrations.LockTimeout)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("aplicada", applied)
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations("revertida", reverted)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSIÓN\tNOMBRE\tESTADO\tAPLICADA")
+		for _, s := range statuses {
+			state := "pendiente"
+			switch {
+			case s.Dirty:
+				state = "incompleta"
+			case s.Applied:
+				state = "aplicada"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, s.AppliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("acción desconocida %q; %s", action, migrateUsage)
+	}
+}
+
+// printMigrations informa en la salida estándar las migraciones procesadas.
+func printMigrations(verb string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Println("no hay migraciones para procesar")
+	}
+	for _, m := range migrations {
+		fmt.Printf("migración %04d_%s %s\n", m.Version, m.Name, verb)
+	}
+}
+
+// isFlag indica si un argumento es una bandera de la línea de comandos.
+func isFlag(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"  // Cancelación al recibir una señal de apagado
+	"errors"   // Paquete para inspeccionar errores
+	"fmt"      // Paquete para formatear errores
+	"log/slog" // Paquete para registrar el ciclo de vida de los servidores
+	"net/http" // Servidores HTTP
+	"time"     // Paquete para manejar duraciones
+)
+
+// serve ejecuta los servidores HTTP hasta que ctx se cancele o alguno de ellos falle,
+// y luego los apaga de forma ordenada.
+// Al apagar, cada servidor deja de aceptar conexiones nuevas y espera a que terminen las solicitudes
+// en curso (por ejemplo, un depósito a medio procesar) hasta shutdownTimeout; pasado ese plazo,
+// las conexiones restantes se cierran.
+// Retorna el error del servidor que falló o el de un apagado que no terminó a tiempo.
+func serve(ctx context.Context, shutdownTimeout time.Duration, servers ...*http.Server) error {
+	failed := make(chan error, len(servers))
+	for _, srv := range servers {
+		go func() {
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				failed <- fmt.Errorf("servidor %s: %w", srv.Addr, err)
+			}
+		}()
+	}
+
+	// Esperar una señal de apagado o la falla de un servidor
+	var err error
+	select {
+	case <-ctx.Done():
+		slog.Info("señal de apagado recibida, terminando las solicitudes en curso")
+	case err = <-failed:
+		slog.Error("error en el servidor, apagando", "error", err)
+	}
+
+	// Apagar todos los servidores con un mismo plazo
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, srv := range servers {
+		if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil {
+			slog.Error("el servidor no terminó a tiempo", "addr", srv.Addr, "error", shutdownErr)
+			srv.Close()
+			err = errors.Join(err, fmt.Errorf("apagado del servidor %s: %w", srv.Addr, shutdownErr))
+		}
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"Transaction-System/internal/application"             // Unidad de trabajo de los servicios
+	"Transaction-System/internal/config"                  // Configuración del backend de almacenamiento
+	"Transaction-System/internal/domain/fx"               // Repositorios de tipos de cambio y cotizaciones
+	"Transaction-System/internal/domain/idempotency"      // Repositorio de claves de idempotencia
+	"Transaction-System/internal/infrastructure/database" // Backends MySQL, PostgreSQL y SQLite
+	"Transaction-System/internal/infrastructure/health"   // Comprobaciones de estado del backend
+	"Transaction-System/internal/infrastructure/memory"   // Backend en memoria
+	"context"                                             // Cancelación de las migraciones al recibir una señal
+	"database/sql"                                        // Conexión a la base de datos
+	"fmt"                                                 // Paquete para formatear errores
+	"log/slog"                                            // Paquete para el logging estructurado
+)
+
+// storage agrupa los componentes que dependen del backend de almacenamiento elegido en database.driver.
+type storage struct {
+	unitOfWork  application.UnitOfWork // Unidad de trabajo de los servicios
+	idempotency idempotency.Repository // Repositorio de las claves de idempotencia
+	rates       fx.RateStore           // Tipos de cambio fijados por un administrador
+	quotes      fx.QuoteRepository     // Cotizaciones de cambio emitidas
+	checks      []namedCheck           // Comprobaciones de disponibilidad propias del backend
+	db          *sql.DB                // Conexión a la base de datos; nil con el backend en memoria
+}
+
+// namedCheck es una comprobación de disponibilidad con el nombre con el que se informa en /readyz.
+type namedCheck struct {
+	name    string
+	checker health.Checker
+}
+
+// Close libera los recursos del backend.
+func (s *storage) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// openStorage prepara el backend de almacenamiento indicado en la configuración.
+// Con MySQL, PostgreSQL o SQLite abre la conexión y aplica (o verifica) las migraciones del esquema; con el backend
+// en memoria el servicio arranca sin base de datos y los datos se pierden al detenerlo.
+func openStorage(ctx context.Context, cfg config.Config) (*storage, error) {
+	if cfg.Database.Driver == config.DriverMemory {
+		slog.Warn("usando el almacenamiento en memoria; los datos se perderán al detener el servicio")
+		return &storage{
+			unitOfWork:  memory.NewUnitOfWork(memory.NewAccountRepository(), memory.NewTransactionRepository()),
+			idempotency: memory.NewIdempotencyRepository(),
+			rates:       memory.NewRateRepository(),
+			quotes:      memory.NewQuoteRepository(),
+		}, nil
+	}
+
+	// Abrir la conexión a la base de datos y verificarla
+	driver := database.Driver(cfg.Database.Driver)
+	db, err := openDatabase(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	// Llevar el esquema a la última versión, o advertir si quedan migraciones pendientes
+	// Las instancias que arrancan a la vez se turnan mediante el bloqueo de migraciones
+	migrator, err := database.NewMigrator(db, driver, cfg.Migrations.LockTimeout)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if cfg.Migrations.Auto {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("no se pudieron aplicar las migraciones: %w", err)
+		}
+		for _, m := range applied {
+			slog.Info("migración aplicada", "version", m.Version, "name", m.Name)
+		}
+	} else if pending, err := migrator.Pending(ctx); err != nil || len(pending) > 0 {
+		slog.Warn("el esquema no está al día; ejecute bankservice migrate up", "pending", len(pending), "error", err)
+	}
+
+	// Inicializar la unidad de trabajo, que entrega repositorios de cuentas y transacciones
+	// ligados a una misma transacción de base de datos, con los plazos configurados
+	timeouts := database.Timeouts{Query: cfg.Database.QueryTimeout, Transaction: cfg.Database.TransactionTimeout}
+	return &storage{
+		unitOfWork:  database.NewUnitOfWork(db, driver, timeouts),
+		idempotency: database.NewIdempotencyRepository(db, driver, timeouts.Query),
+		rates:       database.NewRateRepository(db, driver, timeouts.Query),
+		quotes:      database.NewQuoteRepository(db, driver, timeouts.Query),
+		checks: []namedCheck{
+			{"database", database.PingCheck(db)},
+			{"schema", database.SchemaCheck(migrator)},
+		},
+		db: db,
+	}, nil
+}
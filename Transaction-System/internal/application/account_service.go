@@ -0,0 +1,177 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/account"     // Importación del dominio de cuentas
+	"Transaction-System/internal/domain/money"       // Tipo Money para montos exactos
+	"Transaction-System/internal/domain/transaction" // Importación del dominio de transacciones
+	"context"                                        // Contexto de la solicitud
+	"fmt"                                            // Paquete para formatear errores
+	"log/slog"                                       // Logging de los cambios de sobregiro
+)
+
+// Límites de paginación de los listados (cuentas e historial de transacciones).
+const (
+	DefaultListLimit = 50  // Cantidad de elementos por página si no se indica otra
+	MaxListLimit     = 200 // Cantidad máxima de elementos por página
+)
+
+// AccountPage es una página del listado de cuentas.
+type AccountPage struct {
+	Accounts []*account.Account // Cuentas de la página
+	Total    int                // Total de cuentas que cumplen el filtro (sin paginar)
+	Limit    int                // Límite efectivo aplicado
+	Offset   int                // Desplazamiento efectivo aplicado
+}
+
+// AccountService es el servicio encargado del ciclo de vida de las cuentas:
+// apertura, consulta, listado, cambios de estado (activa, congelada, cerrada) y sobregiro autorizado.
+type AccountService struct {
+	uow       UnitOfWork // Unidad de trabajo que provee los repositorios transaccionales
+	validator *Validator // Validador de las solicitudes de apertura
+}
+
+// NewAccountService crea una instancia del servicio de cuentas.
+// Recibe la unidad de trabajo con la que se accede a cuentas, transacciones y libro mayor.
+func NewAccountService(uow UnitOfWork) *AccountService {
+	return &AccountService{uow: uow, validator: NewValidator(nil)}
+}
+
+// Validator devuelve el validador del servicio, para validar las solicitudes antes de procesarlas.
+func (s *AccountService) Validator() *Validator {
+	return s.validator
+}
+
+// Open abre una cuenta nueva con un número de cuenta generado automáticamente.
+// Parametros:
+//   - ctx: contexto de la solicitud
+//   - initialDeposit: Monto del depósito inicial; puede ser cero
+//
+// Si el depósito inicial es positivo se registra como un depósito normal (transacción y asiento
+// contable) dentro de la misma unidad de trabajo que crea la cuenta.
+// Devuelve la cuenta creada o un error si no pudo abrirse.
+func (s *AccountService) Open(ctx context.Context, initialDeposit money.Money) (*account.Account, error) {
+	if initialDeposit.IsNegative() {
+		return nil, fmt.Errorf("el depósito inicial no puede ser negativo: %w", account.ErrInvalidAmount)
+	}
+
+	// Generar el número de cuenta antes de abrir la unidad de trabajo
+	number, err := account.GenerateNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	var opened *account.Account
+	err = s.uow.Execute(ctx, func(repos Repositories) error {
+		// Guardar la cuenta con balance cero; el repositorio le asigna su ID
+		acc := account.NewAccount(number, money.Zero(initialDeposit.Currency()))
+		if err := repos.Accounts.Save(ctx, acc); err != nil {
+			return err
+		}
+
+		// Registrar el depósito inicial, si lo hay
+		if initialDeposit.IsPositive() {
+			if _, err := applyTransaction(ctx, repos, acc, initialDeposit, transaction.TypeDeposit); err != nil {
+				return err
+			}
+		}
+		opened = acc
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return opened, nil
+}
+
+// Get devuelve la cuenta con el ID indicado.
+func (s *AccountService) Get(ctx context.Context, accountID int) (*account.Account, error) {
+	var found *account.Account
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		var err error
+		found, err = repos.Accounts.FindByID(ctx, accountID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// List devuelve una página de cuentas que cumplen el filtro, junto con el total sin paginar.
+// Si el límite no es válido se usa DefaultListLimit; nunca se devuelven más de MaxListLimit cuentas.
+func (s *AccountService) List(ctx context.Context, filter account.ListFilter) (*AccountPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	page := &AccountPage{Limit: filter.Limit, Offset: filter.Offset}
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		var err error
+		page.Accounts, page.Total, err = repos.Accounts.List(ctx, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// ChangeStatus cambia el estado de una cuenta (activa, congelada o cerrada).
+// Si la cuenta se modifica al mismo tiempo, el cambio se reintenta con DefaultRetryPolicy.
+// Devuelve la cuenta actualizada o un error si la transición no está permitida.
+func (s *AccountService) ChangeStatus(ctx context.Context, accountID int, status account.Status) (*account.Account, error) {
+	var updated *account.Account
+	err := executeWithRetry(ctx, s.uow, DefaultRetryPolicy, func(repos Repositories) error {
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		// Aplicar la transición de estado según las reglas del dominio
+		if err := acc.ChangeStatus(status); err != nil {
+			return err
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+		updated = acc
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// SetOverdraftLimit fija el sobregiro autorizado de una cuenta; un límite cero lo deshabilita.
+// Si la cuenta se modifica al mismo tiempo, el cambio se reintenta con DefaultRetryPolicy.
+// Devuelve la cuenta actualizada, un *account.CurrencyMismatchError si el límite no está en la moneda
+// de la cuenta, o account.ErrOverdraftLimitExceeded si el límite es menor que el sobregiro en uso.
+func (s *AccountService) SetOverdraftLimit(ctx context.Context, accountID int, limit money.Money) (*account.Account, error) {
+	var updated *account.Account
+	err := executeWithRetry(ctx, s.uow, DefaultRetryPolicy, func(repos Repositories) error {
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if err := acc.SetOverdraftLimit(limit); err != nil {
+			return err
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+		updated = acc
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "sobregiro autorizado actualizado", "account_id", accountID, "limit", limit.String())
+	return updated, nil
+}
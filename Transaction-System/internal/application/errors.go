@@ -0,0 +1,12 @@
+package application
+
+import "errors"
+
+// Errores de validación de las solicitudes que reciben los servicios.
+// Los errores propios de una cuenta (fondos insuficientes, cuenta congelada, ...) se definen en el dominio.
+var (
+	// ErrInvalidTransactionType indica que el tipo de transacción no es "deposit" ni "withdrawal".
+	ErrInvalidTransactionType = errors.New("tipo de transacción no válido")
+	// ErrSameAccount indica que una transferencia tiene la misma cuenta como origen y destino.
+	ErrSameAccount = errors.New("la cuenta de origen y la de destino deben ser distintas")
+)
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/fx"    // Dominio de cambio de divisas
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"context"                                  // Contexto de la solicitud
+	"log/slog"                                 // Logging de los cambios de tipos
+	"math/big"                                 // Margen de cambio exacto
+	"time"                                     // Vigencia de las cotizaciones
+)
+
+// DefaultQuoteTTL es el plazo de validez de una cotización si la política no indica otro.
+const DefaultQuoteTTL = 30 * time.Second
+
+// FXPolicy define cómo se cotizan las conversiones de divisas.
+type FXPolicy struct {
+	SpreadBPS int           // Margen del banco sobre el tipo de mercado, en puntos básicos (25 = 0,25 %)
+	QuoteTTL  time.Duration // Plazo de validez de cada cotización
+}
+
+// spread devuelve el margen como fracción del tipo de mercado.
+func (p FXPolicy) spread() *big.Rat {
+	return big.NewRat(int64(p.SpreadBPS), 10000)
+}
+
+// FXService es el servicio de cambio de divisas: emite cotizaciones y administra los tipos de cambio.
+// Los tipos fijados por un administrador tienen prioridad sobre la tabla estática; si ninguno de los
+// dos conoce un par se usa el inverso del par opuesto.
+type FXService struct {
+	rates     fx.Chain           // Proveedores de tipos de cambio por orden de prioridad
+	store     fx.RateStore       // Tipos de cambio fijados por un administrador
+	quotes    fx.QuoteRepository // Cotizaciones emitidas
+	policy    FXPolicy           // Margen y vigencia de las cotizaciones
+	validator *Validator         // Validador de las solicitudes
+}
+
+// NewFXService crea una instancia del servicio de cambio de divisas.
+// Parametros:
+//   - static: tabla estática de tipos de cambio; puede ser nil si no se cargó ninguna
+//   - store: tipos de cambio fijados manualmente, con prioridad sobre la tabla estática
+//   - quotes: repositorio donde se guardan las cotizaciones emitidas
+//   - policy: margen y vigencia de las cotizaciones; una vigencia no positiva usa DefaultQuoteTTL
+func NewFXService(static fx.Provider, store fx.RateStore, quotes fx.QuoteRepository, policy FXPolicy) *FXService {
+	rates := fx.Chain{store}
+	if static != nil {
+		rates = append(rates, static)
+	}
+	if policy.QuoteTTL <= 0 {
+		policy.QuoteTTL = DefaultQuoteTTL
+	}
+	return &FXService{rates: rates, store: store, quotes: quotes, policy: policy, validator: NewValidator(nil)}
+}
+
+// Validator devuelve el validador del servicio, para validar las solicitudes antes de procesarlas.
+func (s *FXService) Validator() *Validator {
+	return s.validator
+}
+
+// Quote emite y guarda una cotización para convertir amount a la moneda cotizada del par.
+// El tipo aplicado es el tipo vigente menos el margen de la política.
+// Retorna fx.ErrRateNotFound si no hay tipo de cambio para el par, fx.ErrAmountTooSmall si el monto
+// convertido es cero y un *ValidationError si el monto no es válido.
+func (s *FXService) Quote(ctx context.Context, amount money.Money, pair fx.Pair) (*fx.Quote, error) {
+	if err := s.validator.CheckAmount("amount", amount); err != nil {
+		return nil, err
+	}
+	rate, err := s.rates.Rate(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := fx.NewQuote(rate, amount, s.policy.spread(), s.policy.QuoteTTL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quotes.Save(ctx, quote); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "cotización emitida", "quote_id", quote.ID, "pair", pair.String(),
+		"amount", amount.String(), "target", quote.Target.String(), "rate", fx.FormatRate(quote.Rate))
+	return quote, nil
+}
+
+// FindQuote devuelve una cotización emitida, vigente o no.
+// Retorna fx.ErrQuoteNotFound si no existe.
+func (s *FXService) FindQuote(ctx context.Context, id string) (*fx.Quote, error) {
+	return s.quotes.FindByID(ctx, id)
+}
+
+// Rates devuelve los tipos de cambio publicados, indicando el origen de cada uno.
+func (s *FXService) Rates(ctx context.Context) ([]fx.Rate, error) {
+	return s.rates.Rates(ctx)
+}
+
+// SetRate fija manualmente el tipo de cambio de un par; reemplaza al de la tabla estática
+// y al fijado anteriormente. Las cotizaciones ya emitidas conservan su tipo.
+func (s *FXService) SetRate(ctx context.Context, rate fx.Rate) error {
+	if err := s.store.Set(ctx, rate); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "tipo de cambio fijado", "pair", rate.Pair.String(), "rate", rate.Decimal())
+	return nil
+}
+
+// DeleteRate elimina el tipo de cambio fijado manualmente para un par; vuelve a regir el de la tabla estática.
+// Retorna fx.ErrRateNotFound si el par no tenía un tipo fijado.
+func (s *FXService) DeleteRate(ctx context.Context, pair fx.Pair) error {
+	if err := s.store.Delete(ctx, pair); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "tipo de cambio eliminado", "pair", pair.String())
+	return nil
+}
@@ -0,0 +1,257 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/hold"        // Dominio de las retenciones de fondos
+	"Transaction-System/internal/domain/money"       // Tipo Money para montos exactos
+	"Transaction-System/internal/domain/transaction" // Importación del dominio de transacciones
+	"context"                                        // Contexto de la solicitud
+	"errors"                                         // Agrupación de los errores por retención
+	"fmt"                                            // Paquete para formatear errores
+	"log/slog"                                       // Logging de las autorizaciones y capturas
+	"time"                                           // Vigencia de las retenciones
+)
+
+// Vigencias por defecto de las retenciones, si la política no indica otras.
+const (
+	DefaultHoldTTL    = 7 * 24 * time.Hour  // Vigencia de una retención si la solicitud no indica otra
+	DefaultHoldMaxTTL = 30 * 24 * time.Hour // Vigencia máxima que puede pedir una solicitud
+)
+
+// HoldPolicy define la vigencia de las retenciones de fondos.
+type HoldPolicy struct {
+	DefaultTTL time.Duration // Vigencia de una retención si la solicitud no indica otra
+	MaxTTL     time.Duration // Vigencia máxima que puede pedir una solicitud
+}
+
+// HoldService es el servicio de retenciones de fondos: autoriza retenciones que reducen el disponible
+// de una cuenta sin registrar un movimiento, y luego las captura (total o parcialmente), las libera o
+// las da por vencidas. Cada operación actualiza la retención y los fondos retenidos de la cuenta en la
+// misma unidad de trabajo, bajo el control de versión de la cuenta.
+type HoldService struct {
+	uow       UnitOfWork  // Unidad de trabajo que provee los repositorios transaccionales
+	policy    HoldPolicy  // Vigencia de las retenciones
+	retry     RetryPolicy // Política de reintentos ante conflictos de concurrencia
+	validator *Validator  // Validador de las solicitudes
+	now       func() time.Time
+}
+
+// NewHoldService crea una instancia del servicio de retenciones.
+// Parametros:
+//   - uow: unidad de trabajo con la que se accede a cuentas, retenciones, transacciones y libro mayor
+//   - policy: vigencias de las retenciones; las no positivas usan DefaultHoldTTL y DefaultHoldMaxTTL
+func NewHoldService(uow UnitOfWork, policy HoldPolicy) *HoldService {
+	if policy.DefaultTTL <= 0 {
+		policy.DefaultTTL = DefaultHoldTTL
+	}
+	if policy.MaxTTL <= 0 {
+		policy.MaxTTL = DefaultHoldMaxTTL
+	}
+	return &HoldService{uow: uow, policy: policy, retry: DefaultRetryPolicy, validator: NewValidator(nil), now: time.Now}
+}
+
+// Validator devuelve el validador del servicio, para validar las solicitudes antes de procesarlas.
+func (s *HoldService) Validator() *Validator {
+	return s.validator
+}
+
+// MaxTTL devuelve la vigencia máxima que puede pedir una solicitud de autorización.
+func (s *HoldService) MaxTTL() time.Duration {
+	return s.policy.MaxTTL
+}
+
+// Authorize retiene amount de la cuenta durante ttl (la vigencia por defecto si es cero).
+// La retención reduce el disponible de la cuenta pero no su balance contable ni genera una transacción.
+// Retorna account.ErrInsufficientFunds o account.ErrOverdraftLimitExceeded si el disponible no alcanza,
+// y los errores de estado y de moneda de la cuenta.
+func (s *HoldService) Authorize(ctx context.Context, accountID int, amount money.Money, reference string, ttl time.Duration) (*hold.Hold, error) {
+	if err := s.validator.CheckAmount("amount", amount); err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = s.policy.DefaultTTL
+	}
+
+	var authorized *hold.Hold
+	err := executeWithRetry(ctx, s.uow, s.retry, func(repos Repositories) error {
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if err := acc.Authorize(amount); err != nil {
+			return err
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+		h, err := hold.New(acc.ID, amount, reference, ttl, s.now())
+		if err != nil {
+			return err
+		}
+		if err := repos.Holds.Save(ctx, h); err != nil {
+			return err
+		}
+		authorized = h
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "retención autorizada", "hold_id", authorized.ID, "account_id", accountID,
+		"amount", amount.String(), "expires_at", authorized.ExpiresAt)
+	return authorized, nil
+}
+
+// Capture captura una retención activa: debita de la cuenta el monto capturado con una transacción
+// "capture" y su asiento contable, y libera el resto del monto retenido. Un monto cero captura todo
+// el monto retenido. Retorna hold.ErrNotFound, hold.ErrNotActive, hold.ErrExpired o
+// hold.ErrCaptureExceedsHold según el estado de la retención, y los errores de estado de la cuenta.
+func (s *HoldService) Capture(ctx context.Context, holdID string, amount money.Money) (*hold.Hold, error) {
+	var captured *hold.Hold
+	err := executeWithRetry(ctx, s.uow, s.retry, func(repos Repositories) error {
+		h, err := repos.Holds.FindByIDForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, h.AccountID)
+		if err != nil {
+			return err
+		}
+		capture := amount
+		if capture.IsZero() {
+			capture = h.Amount
+		}
+		if err := h.Capture(capture, s.now()); err != nil {
+			return err
+		}
+
+		// Registrar en el libro mayor el saldo inicial de la cuenta si aún no tiene movimientos
+		if err := ensureOpeningBalance(ctx, repos, acc); err != nil {
+			return err
+		}
+		if err := acc.CaptureHold(h.Amount, capture); err != nil {
+			return err
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+		tr := transaction.New(acc.ID, capture, transaction.TypeCapture)
+		if err := repos.Transactions.Save(ctx, tr); err != nil {
+			return err
+		}
+		if err := repos.Ledger.Append(ctx, transactionEntry(tr)); err != nil {
+			return err
+		}
+		h.TransactionID = tr.ID
+		if err := repos.Holds.Update(ctx, h); err != nil {
+			return err
+		}
+		captured = h
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "retención capturada", "hold_id", holdID, "account_id", captured.AccountID,
+		"captured", captured.Captured.String(), "released", captured.Remaining().String(), "transaction_id", captured.TransactionID)
+	return captured, nil
+}
+
+// Release libera una retención activa sin capturarla y devuelve sus fondos al disponible de la cuenta.
+// Retorna hold.ErrNotFound o hold.ErrNotActive según el estado de la retención.
+func (s *HoldService) Release(ctx context.Context, holdID string) (*hold.Hold, error) {
+	released, err := s.close(ctx, holdID, func(h *hold.Hold, now time.Time) error { return h.Release(now) })
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "retención liberada", "hold_id", holdID, "account_id", released.AccountID, "amount", released.Amount.String())
+	return released, nil
+}
+
+// Get devuelve una retención, activa o cerrada.
+// Retorna hold.ErrNotFound si no existe.
+func (s *HoldService) Get(ctx context.Context, holdID string) (*hold.Hold, error) {
+	var found *hold.Hold
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		h, err := repos.Holds.FindByID(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		found = h
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// ExpireHolds da por vencidas las retenciones activas cuyo plazo terminó en now y devuelve sus fondos
+// al disponible de cada cuenta. Cada retención se procesa en su propia unidad de trabajo; un error en
+// una no detiene las demás y los errores se devuelven agrupados junto con la cantidad de retenciones vencidas.
+func (s *HoldService) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	var expired int
+	var errs []error
+	for {
+		var batch []*hold.Hold
+		err := s.uow.Execute(ctx, func(repos Repositories) error {
+			var err error
+			batch, err = repos.Holds.FindExpired(ctx, now, MaxListLimit)
+			return err
+		})
+		if err != nil {
+			return expired, errors.Join(append(errs, err)...)
+		}
+
+		processed := 0
+		for _, h := range batch {
+			_, err := s.close(ctx, h.ID, func(h *hold.Hold, _ time.Time) error { return h.Expire(now) })
+			switch {
+			case errors.Is(err, hold.ErrNotActive):
+				// Se capturó o liberó entretanto
+			case err != nil:
+				errs = append(errs, fmt.Errorf("retención %s: %w", h.ID, err))
+				continue
+			default:
+				expired++
+				slog.InfoContext(ctx, "retención vencida", "hold_id", h.ID, "account_id", h.AccountID, "amount", h.Amount.String())
+			}
+			processed++
+		}
+
+		// Las retenciones que fallaron siguen activas y volverían en el próximo lote; se reintentan en la próxima ejecución
+		if len(batch) < MaxListLimit || processed == 0 {
+			return expired, errors.Join(errs...)
+		}
+	}
+}
+
+// close cierra una retención activa con la transición indicada (liberación o vencimiento) y devuelve
+// su monto completo al disponible de la cuenta.
+func (s *HoldService) close(ctx context.Context, holdID string, transition func(h *hold.Hold, now time.Time) error) (*hold.Hold, error) {
+	var closed *hold.Hold
+	err := executeWithRetry(ctx, s.uow, s.retry, func(repos Repositories) error {
+		h, err := repos.Holds.FindByIDForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		if err := transition(h, s.now()); err != nil {
+			return err
+		}
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, h.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := acc.ReleaseHold(h.Amount); err != nil {
+			return err
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+		if err := repos.Holds.Update(ctx, h); err != nil {
+			return err
+		}
+		closed = h
+		return nil
+	})
+	return closed, err
+}
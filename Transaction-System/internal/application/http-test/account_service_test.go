@@ -0,0 +1,106 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newAccountService crea el servicio de cuentas sobre un repositorio vacío
+func newAccountService() (*application.AccountService, *application.TransactionService) {
+	accountRepo := memory.NewAccountRepository()
+	uow := memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository())
+	return application.NewAccountService(uow), application.NewTransactionService(uow)
+}
+
+// Prueba la apertura de una cuenta con depósito inicial
+func TestOpenAccount(t *testing.T) {
+	service, _ := newAccountService()
+
+	acc, err := service.Open(context.Background(), money.MustParse("250.00", money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta: %v", err)
+	}
+
+	// Verificar el número generado, el estado y el balance inicial
+	if !strings.HasPrefix(acc.AccountNumber, "ACC") || len(acc.AccountNumber) != 15 {
+		t.Errorf("Número de cuenta inesperado: %q", acc.AccountNumber)
+	}
+	if acc.Status != account.StatusActive {
+		t.Errorf("Estado incorrecto, esperado active, obtenido %q", acc.Status)
+	}
+	stored, err := service.Get(context.Background(), acc.ID)
+	if err != nil {
+		t.Fatalf("Error al consultar la cuenta: %v", err)
+	}
+	if stored.Balance != money.MustParse("250.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado 250.00, obtenido %v", stored.Balance)
+	}
+}
+
+// Prueba que una cuenta congelada no admite movimientos hasta reactivarse
+func TestFreezeAccount_BlocksTransactions(t *testing.T) {
+	service, transactions := newAccountService()
+	acc, _ := service.Open(context.Background(), money.MustParse("100.00", money.DefaultCurrency))
+
+	if _, err := service.ChangeStatus(context.Background(), acc.ID, account.StatusFrozen); err != nil {
+		t.Fatalf("Error al congelar la cuenta: %v", err)
+	}
+	err := transactions.ProcessTransaction(context.Background(), acc.ID, money.MustParse("10.00", money.DefaultCurrency), "withdrawal")
+	if !errors.Is(err, account.ErrAccountFrozen) {
+		t.Errorf("Se esperaba ErrAccountFrozen, obtenido %v", err)
+	}
+
+	// Al reactivarla vuelve a admitir movimientos
+	if _, err := service.ChangeStatus(context.Background(), acc.ID, account.StatusActive); err != nil {
+		t.Fatalf("Error al reactivar la cuenta: %v", err)
+	}
+	if err := transactions.ProcessTransaction(context.Background(), acc.ID, money.MustParse("10.00", money.DefaultCurrency), "withdrawal"); err != nil {
+		t.Errorf("La cuenta reactivada debería admitir retiros: %v", err)
+	}
+}
+
+// Prueba que sólo pueden cerrarse cuentas con balance cero
+func TestCloseAccount(t *testing.T) {
+	service, transactions := newAccountService()
+	acc, _ := service.Open(context.Background(), money.MustParse("40.00", money.DefaultCurrency))
+
+	if _, err := service.ChangeStatus(context.Background(), acc.ID, account.StatusClosed); !errors.Is(err, account.ErrBalanceNotZero) {
+		t.Fatalf("Se esperaba ErrBalanceNotZero, obtenido %v", err)
+	}
+
+	// Retirar el saldo y cerrar la cuenta
+	if err := transactions.ProcessTransaction(context.Background(), acc.ID, money.MustParse("40.00", money.DefaultCurrency), "withdrawal"); err != nil {
+		t.Fatalf("Error al retirar el saldo: %v", err)
+	}
+	if _, err := service.ChangeStatus(context.Background(), acc.ID, account.StatusClosed); err != nil {
+		t.Fatalf("Error al cerrar la cuenta: %v", err)
+	}
+
+	// Una cuenta cerrada no puede reactivarse
+	if _, err := service.ChangeStatus(context.Background(), acc.ID, account.StatusActive); !errors.Is(err, account.ErrAccountClosed) {
+		t.Errorf("Se esperaba ErrAccountClosed, obtenido %v", err)
+	}
+}
+
+// Prueba el listado paginado con filtro por estado
+func TestListAccounts(t *testing.T) {
+	service, _ := newAccountService()
+	for i := 0; i < 3; i++ {
+		service.Open(context.Background(), money.Zero(money.DefaultCurrency))
+	}
+	service.ChangeStatus(context.Background(), 2, account.StatusFrozen)
+
+	page, err := service.List(context.Background(), account.ListFilter{Status: account.StatusActive, Limit: 1})
+	if err != nil {
+		t.Fatalf("Error al listar las cuentas: %v", err)
+	}
+	if page.Total != 2 || len(page.Accounts) != 1 || page.Accounts[0].ID != 1 {
+		t.Errorf("Página incorrecta: total %d, cuentas %d", page.Total, len(page.Accounts))
+	}
+}
@@ -0,0 +1,85 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Mock de repositorio de cuentas cuyas primeras actualizaciones fallan por un conflicto de versión
+// Simula otra solicitud que modifica la misma cuenta entre la lectura y la escritura.
+type conflictingAccountRepository struct {
+	*memory.AccountRepository
+	conflicts int // Cantidad de actualizaciones que todavía fallarán
+	updates   int // Cantidad de actualizaciones intentadas
+}
+
+// Método mock que rechaza la actualización mientras queden conflictos por simular
+func (m *conflictingAccountRepository) Update(ctx context.Context, a *account.Account) error {
+	m.updates++
+	if m.conflicts > 0 {
+		m.conflicts--
+		return fmt.Errorf("cuenta %d: %w", a.ID, account.ErrVersionConflict)
+	}
+	return m.AccountRepository.Update(ctx, a)
+}
+
+// newConflictingAccountRepository crea el mock con una cuenta de 100.00 y la cantidad de conflictos indicada
+func newConflictingAccountRepository(t testing.TB, conflicts int) *conflictingAccountRepository {
+	return &conflictingAccountRepository{
+		AccountRepository: newAccountRepository(t,
+			&account.Account{ID: 1, AccountNumber: "ACC0001", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+		),
+		conflicts: conflicts,
+	}
+}
+
+// Prueba que un conflicto de versión se reintenta y el depósito se aplica una sola vez
+func TestProcessTransaction_RetriesOnVersionConflict(t *testing.T) {
+	accountRepo := newConflictingAccountRepository(t, 2)
+	transactionRepo := memory.NewTransactionRepository()
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo),
+		application.WithRetryPolicy(application.RetryPolicy{MaxAttempts: 3}))
+
+	if err := service.ProcessTransaction(context.Background(), 1, money.MustParse("25.00", money.DefaultCurrency), "deposit"); err != nil {
+		t.Fatalf("Se esperaba que el reintento tuviera éxito: %v", err)
+	}
+
+	// El balance refleja un único depósito y sólo se guardó una transacción
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if balance := acc.Balance; balance != money.MustParse("125.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado 125.00, obtenido %v", balance)
+	}
+	if n := countTransactions(t, transactionRepo, 1); n != 1 {
+		t.Errorf("Se esperaba 1 transacción guardada, obtenidas %d", n)
+	}
+}
+
+// Prueba que al agotar los reintentos se devuelve un ConflictError y la cuenta no cambia
+func TestProcessTransaction_ConflictErrorWhenRetriesExhausted(t *testing.T) {
+	accountRepo := newConflictingAccountRepository(t, 10)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()),
+		application.WithRetryPolicy(application.RetryPolicy{MaxAttempts: 3}))
+
+	err := service.ProcessTransaction(context.Background(), 1, money.MustParse("25.00", money.DefaultCurrency), "withdrawal")
+
+	var conflict *application.ConflictError
+	if !errors.As(err, &conflict) || conflict.Attempts != 3 {
+		t.Fatalf("Se esperaba un ConflictError tras 3 intentos, obtenido %v", err)
+	}
+	if !errors.Is(err, account.ErrVersionConflict) {
+		t.Errorf("El ConflictError debería envolver account.ErrVersionConflict")
+	}
+	if accountRepo.updates != 3 {
+		t.Errorf("Se esperaban 3 actualizaciones intentadas, obtenidas %d", accountRepo.updates)
+	}
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if balance := acc.Balance; balance != money.MustParse("100.00", money.DefaultCurrency) {
+		t.Errorf("El balance no debería cambiar, obtenido %v", balance)
+	}
+}
@@ -0,0 +1,210 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fxFixture agrupa los servicios de una prueba de transferencias entre monedas.
+type fxFixture struct {
+	accounts     *memory.AccountRepository
+	transactions *memory.TransactionRepository
+	quotes       *memory.QuoteRepository
+	uow          application.UnitOfWork
+	fx           *application.FXService
+	service      *application.TransactionService
+}
+
+// newFXFixture crea una cuenta en USD (1) y otra en EUR (2), una tabla estática con USD/EUR = 0.92
+// y un margen de 25 puntos básicos.
+func newFXFixture(t *testing.T) *fxFixture {
+	t.Helper()
+	static, err := fx.NewStaticProvider(map[string]string{"USD/EUR": "0.92"}, time.Now())
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	f := &fxFixture{
+		accounts: newAccountRepository(t,
+			&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("500.00", "USD")},
+			&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("10.00", "EUR")},
+		),
+		transactions: memory.NewTransactionRepository(),
+		quotes:       memory.NewQuoteRepository(),
+	}
+	f.uow = memory.NewUnitOfWork(f.accounts, f.transactions)
+	f.fx = application.NewFXService(static, memory.NewRateRepository(), f.quotes, application.FXPolicy{SpreadBPS: 25, QuoteTTL: time.Minute})
+	f.service = application.NewTransactionService(f.uow, application.WithQuotes(f.quotes))
+	return f
+}
+
+// Prueba una transferencia entre monedas: el destino recibe el monto cotizado, ambas patas registran
+// la conversión y el libro mayor queda balanceado en cada moneda
+func TestTransferWithQuote(t *testing.T) {
+	f := newFXFixture(t)
+	ctx := context.Background()
+	pair, _ := fx.NewPair("USD", "EUR")
+
+	quote, err := f.fx.Quote(ctx, money.MustParse("100.00", "USD"), pair)
+	if err != nil {
+		t.Fatalf("Error al cotizar: %v", err)
+	}
+	// 0.92 * (1 - 0.0025) = 0.9177; 100 USD -> 91.77 EUR
+	if quote.Target != money.MustParse("91.77", "EUR") || fx.FormatRate(quote.Rate) != "0.9177" {
+		t.Fatalf("Cotización incorrecta: %v a %s", quote.Target, fx.FormatRate(quote.Rate))
+	}
+
+	transferID, err := f.service.TransferWithQuote(ctx, 1, 2, money.MustParse("100.00", "USD"), quote.ID)
+	if err != nil {
+		t.Fatalf("Error al transferir: %v", err)
+	}
+
+	from, _ := f.accounts.FindByID(ctx, 1)
+	to, _ := f.accounts.FindByID(ctx, 2)
+	if from.Balance != money.MustParse("400.00", "USD") || to.Balance != money.MustParse("101.77", "EUR") {
+		t.Errorf("Balances incorrectos: origen %v, destino %v", from.Balance, to.Balance)
+	}
+
+	// Cada pata registra la cotización, el tipo aplicado y el monto de la otra pata
+	for _, leg := range []struct {
+		accountID int
+		amount    money.Money
+		counter   money.Money
+	}{
+		{1, money.MustParse("100.00", "USD"), money.MustParse("91.77", "EUR")},
+		{2, money.MustParse("91.77", "EUR"), money.MustParse("100.00", "USD")},
+	} {
+		found, _ := f.transactions.FindByAccount(ctx, leg.accountID, transaction.Filter{Limit: 10})
+		if len(found) != 1 {
+			t.Fatalf("Cuenta %d: se esperaba una transacción, obtenidas %d", leg.accountID, len(found))
+		}
+		tr := found[0]
+		if tr.TransferID != transferID || tr.Amount != leg.amount || tr.Conversion == nil {
+			t.Fatalf("Cuenta %d: pata incorrecta: %+v", leg.accountID, tr)
+		}
+		c := tr.Conversion
+		if c.QuoteID != quote.ID || fx.FormatRate(c.Rate) != "0.9177" || c.CounterAmount != leg.counter {
+			t.Errorf("Cuenta %d: conversión incorrecta: %+v", leg.accountID, c)
+		}
+	}
+
+	// Los balances derivados del libro mayor coinciden y cada moneda suma cero
+	ledgerService := application.NewLedgerService(f.uow)
+	for _, id := range []int{1, 2} {
+		if v, err := ledgerService.VerifyAccount(ctx, id); err != nil || !v.Balanced {
+			t.Errorf("Cuenta %d descuadrada con el libro mayor (err: %v)", id, err)
+		}
+	}
+	if totals, balanced, err := ledgerService.VerifyJournal(ctx); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado: %v (err: %v)", totals, err)
+	}
+}
+
+// Prueba que una cotización vencida, desconocida o que no coincide con la transferencia no mueve fondos
+func TestTransferWithQuote_Rejected(t *testing.T) {
+	f := newFXFixture(t)
+	ctx := context.Background()
+	pair, _ := fx.NewPair("USD", "EUR")
+	quote, err := f.fx.Quote(ctx, money.MustParse("100.00", "USD"), pair)
+	if err != nil {
+		t.Fatalf("Error al cotizar: %v", err)
+	}
+
+	expired := *quote
+	expired.ID = "expired-quote"
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	f.quotes.Save(ctx, &expired)
+
+	tests := []struct {
+		name    string
+		from    int
+		to      int
+		amount  money.Money
+		quoteID string
+		want    error
+	}{
+		{"vencida", 1, 2, money.MustParse("100.00", "USD"), expired.ID, fx.ErrQuoteExpired},
+		{"inexistente", 1, 2, money.MustParse("100.00", "USD"), "no-existe", fx.ErrQuoteNotFound},
+		{"otro monto", 1, 2, money.MustParse("50.00", "USD"), quote.ID, fx.ErrQuoteMismatch},
+		{"destino en la moneda de origen", 2, 1, money.MustParse("100.00", "USD"), quote.ID, fx.ErrQuoteMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.service.TransferWithQuote(ctx, tt.from, tt.to, tt.amount, tt.quoteID); !errors.Is(err, tt.want) {
+				t.Errorf("Se esperaba %v, obtenido %v", tt.want, err)
+			}
+		})
+	}
+
+	from, _ := f.accounts.FindByID(ctx, 1)
+	if from.Balance != money.MustParse("500.00", "USD") {
+		t.Errorf("El balance de origen no debería haber cambiado, obtenido %v", from.Balance)
+	}
+}
+
+// Prueba que una cotización liquida una sola transferencia: la segunda se rechaza sin mover fondos
+func TestTransferWithQuote_QuoteUsedOnce(t *testing.T) {
+	f := newFXFixture(t)
+	ctx := context.Background()
+	pair, _ := fx.NewPair("USD", "EUR")
+	quote, err := f.fx.Quote(ctx, money.MustParse("100.00", "USD"), pair)
+	if err != nil {
+		t.Fatalf("Error al cotizar: %v", err)
+	}
+
+	if _, err := f.service.TransferWithQuote(ctx, 1, 2, money.MustParse("100.00", "USD"), quote.ID); err != nil {
+		t.Fatalf("Error en la primera transferencia: %v", err)
+	}
+	if _, err := f.service.TransferWithQuote(ctx, 1, 2, money.MustParse("100.00", "USD"), quote.ID); !errors.Is(err, fx.ErrQuoteUsed) {
+		t.Fatalf("Se esperaba ErrQuoteUsed en la segunda transferencia, obtenido %v", err)
+	}
+
+	from, _ := f.accounts.FindByID(ctx, 1)
+	to, _ := f.accounts.FindByID(ctx, 2)
+	if from.Balance != money.MustParse("400.00", "USD") || to.Balance != money.MustParse("101.77", "EUR") {
+		t.Errorf("La segunda transferencia no debería mover fondos: origen %v, destino %v", from.Balance, to.Balance)
+	}
+}
+
+// Prueba que un tipo fijado manualmente reemplaza al de la tabla estática hasta que se elimina
+func TestFXService_ManualRate(t *testing.T) {
+	f := newFXFixture(t)
+	ctx := context.Background()
+	pair, _ := fx.NewPair("USD", "EUR")
+
+	rate, err := f.fx.Validator().ValidateRate(application.RateCommand{Base: "USD", Quote: "EUR", Rate: "0.95"}, time.Now())
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if err := f.fx.SetRate(ctx, rate); err != nil {
+		t.Fatalf("Error al fijar el tipo: %v", err)
+	}
+	quote, err := f.fx.Quote(ctx, money.MustParse("100.00", "USD"), pair)
+	if err != nil || fx.FormatRate(quote.MidRate) != "0.95" || quote.RateSource != fx.SourceManual {
+		t.Fatalf("Se esperaba el tipo manual, obtenido %+v (err: %v)", quote, err)
+	}
+
+	if err := f.fx.DeleteRate(ctx, pair); err != nil {
+		t.Fatalf("Error al eliminar el tipo: %v", err)
+	}
+	if err := f.fx.DeleteRate(ctx, pair); !errors.Is(err, fx.ErrRateNotFound) {
+		t.Errorf("Se esperaba ErrRateNotFound, obtenido %v", err)
+	}
+	quote, err = f.fx.Quote(ctx, money.MustParse("100.00", "USD"), pair)
+	if err != nil || quote.RateSource != fx.SourceStatic {
+		t.Errorf("Se esperaba el tipo de la tabla estática, obtenido %+v (err: %v)", quote, err)
+	}
+
+	// Sin tipo para el par ni para su inverso no se puede cotizar
+	other, _ := fx.NewPair("GBP", "BRL")
+	if _, err := f.fx.Quote(ctx, money.MustParse("1.00", "GBP"), other); !errors.Is(err, fx.ErrRateNotFound) {
+		t.Errorf("Se esperaba ErrRateNotFound, obtenido %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"testing"
+	"time"
+)
+
+// Prueba que la paginación por cursor recorre el historial completo sin repetir ni omitir transacciones,
+// incluso cuando varias transacciones comparten la misma fecha de creación.
+func TestHistory_CursorPagination(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC0001", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+	)
+	transactionRepo := memory.NewTransactionRepository()
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo))
+
+	// Cinco transacciones: las tres primeras en el mismo instante
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for i, offset := range []time.Duration{0, 0, 0, time.Minute, 2 * time.Minute} {
+		tr := transaction.New(1, money.MustParse("10.00", money.DefaultCurrency), transaction.TypeDeposit)
+		tr.CreatedAt = base.Add(offset)
+		if i == 4 {
+			tr.TransactionType = transaction.TypeWithdrawal
+		}
+		transactionRepo.Save(context.Background(), tr)
+	}
+
+	// Recorrer el historial de dos en dos, pasando el cursor codificado como lo haría un cliente
+	var ids []int
+	filter := transaction.Filter{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("La paginación no terminó")
+		}
+		page, err := service.History(context.Background(), 1, filter)
+		if err != nil {
+			t.Fatalf("Error al consultar el historial: %v", err)
+		}
+		for _, tr := range page.Transactions {
+			ids = append(ids, tr.ID)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		cursor, err := transaction.DecodeCursor(page.NextCursor.Encode())
+		if err != nil {
+			t.Fatalf("Error al decodificar el cursor: %v", err)
+		}
+		filter.After = &cursor
+	}
+
+	// Orden esperado: de la más reciente a la más antigua, desempatando por ID descendente
+	expected := []int{5, 4, 3, 2, 1}
+	if len(ids) != len(expected) {
+		t.Fatalf("Se esperaban %v, obtenido %v", expected, ids)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Fatalf("Se esperaban %v, obtenido %v", expected, ids)
+		}
+	}
+
+	// Filtrar por tipo y rango de montos
+	minAmount := money.MustParse("10.00", money.DefaultCurrency)
+	page, err := service.History(context.Background(), 1, transaction.Filter{Types: []string{transaction.TypeWithdrawal}, MinAmount: &minAmount})
+	if err != nil {
+		t.Fatalf("Error al consultar el historial: %v", err)
+	}
+	if len(page.Transactions) != 1 || page.Transactions[0].ID != 5 || page.NextCursor != nil {
+		t.Errorf("Filtro por tipo incorrecto: %d transacciones", len(page.Transactions))
+	}
+}
+
+// Prueba que un cursor alterado se rechaza
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, raw := range []string{"%%%", "bm8tdmFsaWRv", ""} {
+		if _, err := transaction.DecodeCursor(raw); err == nil {
+			t.Errorf("Se esperaba un error para el cursor %q", raw)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/hold"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newHoldFixture crea un servicio de retenciones en memoria con una cuenta de 100.00 USD (ID 1).
+func newHoldFixture(t *testing.T) (*application.HoldService, *memory.AccountRepository, *memory.TransactionRepository, *memory.UnitOfWork) {
+	t.Helper()
+	accountRepo := newAccountRepository(t, &account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)})
+	transactions := memory.NewTransactionRepository()
+	uow := memory.NewUnitOfWork(accountRepo, transactions)
+	return application.NewHoldService(uow, application.HoldPolicy{}), accountRepo, transactions, uow
+}
+
+// Prueba que una retención reduce el disponible sin tocar el balance contable y que su captura parcial
+// debita sólo lo capturado, libera el resto y registra una transacción "capture" cuadrada en el libro mayor
+func TestHold_AuthorizeAndPartialCapture(t *testing.T) {
+	service, accountRepo, transactions, uow := newHoldFixture(t)
+	ctx := context.Background()
+
+	h, err := service.Authorize(ctx, 1, money.MustParse("60.00", money.DefaultCurrency), "pedido-981", 0)
+	if err != nil {
+		t.Fatalf("Error al autorizar la retención: %v", err)
+	}
+	if h.Status != hold.StatusActive || h.ExpiresAt.Sub(h.CreatedAt) != application.DefaultHoldTTL {
+		t.Errorf("Retención inesperada: %+v", h)
+	}
+	acc, _ := accountRepo.FindByID(ctx, 1)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) || acc.Available() != money.MustParse("40.00", money.DefaultCurrency) {
+		t.Errorf("Balance %v, disponible %v; se esperaba 100.00 y 40.00", acc.Balance, acc.Available())
+	}
+
+	// El disponible restante no alcanza para otra retención de 50.00
+	if _, err := service.Authorize(ctx, 1, money.MustParse("50.00", money.DefaultCurrency), "", 0); !errors.Is(err, account.ErrInsufficientFunds) {
+		t.Errorf("Se esperaba ErrInsufficientFunds, obtenido %v", err)
+	}
+
+	// Capturar más de lo retenido no modifica la retención
+	if _, err := service.Capture(ctx, h.ID, money.MustParse("60.01", money.DefaultCurrency)); !errors.Is(err, hold.ErrCaptureExceedsHold) {
+		t.Errorf("Se esperaba ErrCaptureExceedsHold, obtenido %v", err)
+	}
+
+	captured, err := service.Capture(ctx, h.ID, money.MustParse("45.00", money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al capturar la retención: %v", err)
+	}
+	if captured.Status != hold.StatusCaptured || captured.Captured != money.MustParse("45.00", money.DefaultCurrency) || captured.TransactionID == 0 {
+		t.Errorf("Captura inesperada: %+v", captured)
+	}
+	acc, _ = accountRepo.FindByID(ctx, 1)
+	if acc.Balance != money.MustParse("55.00", money.DefaultCurrency) || !acc.HeldAmount().IsZero() || acc.Available() != acc.Balance {
+		t.Errorf("Balance %v, retenido %v, disponible %v; se esperaba 55.00, 0 y 55.00", acc.Balance, acc.HeldAmount(), acc.Available())
+	}
+	history, _ := transactions.FindByAccount(ctx, 1, transaction.Filter{Types: []string{transaction.TypeCapture}, Limit: 10})
+	if len(history) != 1 || history[0].ID != captured.TransactionID {
+		t.Errorf("Se esperaba la transacción de captura %d, obtenidas %+v", captured.TransactionID, history)
+	}
+
+	// Una retención capturada no puede capturarse ni liberarse de nuevo
+	if _, err := service.Capture(ctx, h.ID, money.Money{}); !errors.Is(err, hold.ErrNotActive) {
+		t.Errorf("Se esperaba ErrNotActive al capturar dos veces, obtenido %v", err)
+	}
+	if _, err := service.Release(ctx, h.ID); !errors.Is(err, hold.ErrNotActive) {
+		t.Errorf("Se esperaba ErrNotActive al liberar una retención capturada, obtenido %v", err)
+	}
+
+	ledgerService := application.NewLedgerService(uow)
+	if verification, err := ledgerService.VerifyAccount(ctx, 1); err != nil || !verification.Balanced {
+		t.Errorf("La cuenta debería cuadrar con el libro mayor (err: %v, %+v)", err, verification)
+	}
+}
+
+// Prueba la captura total sin monto y la liberación de una retención
+func TestHold_FullCaptureAndRelease(t *testing.T) {
+	service, accountRepo, _, _ := newHoldFixture(t)
+	ctx := context.Background()
+
+	first, _ := service.Authorize(ctx, 1, money.MustParse("30.00", money.DefaultCurrency), "", time.Hour)
+	second, _ := service.Authorize(ctx, 1, money.MustParse("20.00", money.DefaultCurrency), "", time.Hour)
+
+	if _, err := service.Capture(ctx, first.ID, money.Money{}); err != nil {
+		t.Fatalf("Error al capturar la retención completa: %v", err)
+	}
+	released, err := service.Release(ctx, second.ID)
+	if err != nil || released.Status != hold.StatusReleased || released.ClosedAt.IsZero() {
+		t.Fatalf("Liberación inesperada: %+v (err: %v)", released, err)
+	}
+
+	acc, _ := accountRepo.FindByID(ctx, 1)
+	if acc.Balance != money.MustParse("70.00", money.DefaultCurrency) || acc.Available() != money.MustParse("70.00", money.DefaultCurrency) {
+		t.Errorf("Balance %v, disponible %v; se esperaba 70.00 y 70.00", acc.Balance, acc.Available())
+	}
+	if _, err := service.Get(ctx, "no-existe"); !errors.Is(err, hold.ErrNotFound) {
+		t.Errorf("Se esperaba ErrNotFound, obtenido %v", err)
+	}
+}
+
+// Prueba que la tarea de vencimiento libera sólo las retenciones vencidas y que éstas ya no pueden capturarse
+func TestHold_ExpireHolds(t *testing.T) {
+	service, accountRepo, _, _ := newHoldFixture(t)
+	ctx := context.Background()
+
+	stale, _ := service.Authorize(ctx, 1, money.MustParse("10.00", money.DefaultCurrency), "", time.Minute)
+	fresh, _ := service.Authorize(ctx, 1, money.MustParse("15.00", money.DefaultCurrency), "", time.Hour)
+
+	expired, err := service.ExpireHolds(ctx, time.Now().Add(10*time.Minute))
+	if err != nil || expired != 1 {
+		t.Fatalf("Se esperaba una retención vencida, obtenidas %d (err: %v)", expired, err)
+	}
+	if got, _ := service.Get(ctx, stale.ID); got.Status != hold.StatusExpired {
+		t.Errorf("La retención vencida debería estar expired, está %s", got.Status)
+	}
+	if got, _ := service.Get(ctx, fresh.ID); got.Status != hold.StatusActive {
+		t.Errorf("La retención vigente debería seguir activa, está %s", got.Status)
+	}
+	acc, _ := accountRepo.FindByID(ctx, 1)
+	if acc.HeldAmount() != money.MustParse("15.00", money.DefaultCurrency) {
+		t.Errorf("Retenido %v, se esperaba 15.00", acc.HeldAmount())
+	}
+	if _, err := service.Capture(ctx, stale.ID, money.Money{}); !errors.Is(err, hold.ErrNotActive) {
+		t.Errorf("Se esperaba ErrNotActive al capturar una retención vencida, obtenido %v", err)
+	}
+
+	// Repetir el vencimiento no encuentra nada más
+	if expired, err := service.ExpireHolds(ctx, time.Now().Add(10*time.Minute)); err != nil || expired != 0 {
+		t.Errorf("No deberían vencer más retenciones, obtenidas %d (err: %v)", expired, err)
+	}
+
+	// Una cuenta con fondos retenidos no puede cerrarse
+	acc.Balance = money.Zero(money.DefaultCurrency)
+	if err := acc.Close(); !errors.Is(err, account.ErrBalanceNotZero) {
+		t.Errorf("Se esperaba ErrBalanceNotZero al cerrar con fondos retenidos, obtenido %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/ledger"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// Prueba que un retiro puede usar el sobregiro autorizado y que excederlo devuelve un error propio
+func TestWithdraw_Overdraft(t *testing.T) {
+	accounts, transactions := newAccountService()
+	acc, _ := accounts.Open(context.Background(), money.MustParse("100.00", money.DefaultCurrency))
+
+	// Sin sobregiro, retirar más que el balance sigue siendo un error de fondos insuficientes
+	err := transactions.ProcessTransaction(context.Background(), acc.ID, money.MustParse("150.00", money.DefaultCurrency), "withdrawal")
+	if !errors.Is(err, account.ErrInsufficientFunds) {
+		t.Fatalf("Se esperaba ErrInsufficientFunds, obtenido %v", err)
+	}
+
+	if _, err := accounts.SetOverdraftLimit(context.Background(), acc.ID, money.MustParse("200.00", money.DefaultCurrency)); err != nil {
+		t.Fatalf("Error al fijar el sobregiro: %v", err)
+	}
+	if err := transactions.ProcessTransaction(context.Background(), acc.ID, money.MustParse("250.00", money.DefaultCurrency), "withdrawal"); err != nil {
+		t.Fatalf("El retiro dentro del sobregiro debería aceptarse: %v", err)
+	}
+	stored, _ := accounts.Get(context.Background(), acc.ID)
+	if stored.Balance != money.MustParse("-150.00", money.DefaultCurrency) ||
+		stored.OverdraftUsed() != money.MustParse("150.00", money.DefaultCurrency) ||
+		stored.Available() != money.MustParse("50.00", money.DefaultCurrency) {
+		t.Errorf("Balance %v, sobregiro en uso %v, disponible %v", stored.Balance, stored.OverdraftUsed(), stored.Available())
+	}
+
+	// Superar el sobregiro no modifica la cuenta
+	err = transactions.ProcessTransaction(context.Background(), acc.ID, money.MustParse("50.01", money.DefaultCurrency), "withdrawal")
+	if !errors.Is(err, account.ErrOverdraftLimitExceeded) {
+		t.Fatalf("Se esperaba ErrOverdraftLimitExceeded, obtenido %v", err)
+	}
+
+	// El límite no puede bajar por debajo del sobregiro en uso ni ser negativo
+	if _, err := accounts.SetOverdraftLimit(context.Background(), acc.ID, money.MustParse("100.00", money.DefaultCurrency)); !errors.Is(err, account.ErrOverdraftLimitExceeded) {
+		t.Errorf("Se esperaba ErrOverdraftLimitExceeded al reducir el límite, obtenido %v", err)
+	}
+	if _, err := accounts.SetOverdraftLimit(context.Background(), acc.ID, money.MustParse("-1.00", money.DefaultCurrency)); !errors.Is(err, account.ErrInvalidAmount) {
+		t.Errorf("Se esperaba ErrInvalidAmount con un límite negativo, obtenido %v", err)
+	}
+	var mismatch *account.CurrencyMismatchError
+	if _, err := accounts.SetOverdraftLimit(context.Background(), acc.ID, money.MustParse("500.00", "EUR")); !errors.As(err, &mismatch) {
+		t.Errorf("Se esperaba *CurrencyMismatchError con un límite en otra moneda, obtenido %v", err)
+	}
+}
+
+// Prueba el cobro diario de intereses sobre el sobregiro en uso: el cargo, su asiento contable,
+// que no se cobra dos veces el mismo día y que las cuentas sin sobregiro no se cobran
+func TestAccrueOverdraftInterest(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("-1000.00", money.DefaultCurrency), Overdraft: money.MustParse("2000.00", money.DefaultCurrency)},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("500.00", money.DefaultCurrency)},
+	)
+	transactions := memory.NewTransactionRepository()
+	uow := memory.NewUnitOfWork(accountRepo, transactions)
+	service := application.NewInterestService(uow, application.InterestPolicy{AnnualRate: big.NewRat(18, 100), DaysPerYear: 365})
+
+	day := time.Date(2024, time.September, 10, 15, 30, 0, 0, time.UTC)
+	report, err := service.AccrueOverdraftInterest(context.Background(), day)
+	if err != nil {
+		t.Fatalf("Error al cobrar los intereses: %v", err)
+	}
+
+	// 1000.00 × 0.18 / 365 = 0.4931… → 0.49
+	if len(report.Accruals) != 1 || report.Accruals[0].AccountID != 1 {
+		t.Fatalf("Se esperaba un cargo a la cuenta 1, obtenido %+v", report.Accruals)
+	}
+	if got := report.Accruals[0].Interest; got != money.MustParse("0.49", money.DefaultCurrency) {
+		t.Errorf("Intereses incorrectos, esperado 0.49, obtenido %v", got)
+	}
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("-1000.49", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado -1000.49, obtenido %v", acc.Balance)
+	}
+	history, _ := transactions.FindByAccount(context.Background(), 1, transaction.Filter{Types: []string{transaction.TypeOverdraftInterest}, Limit: 10})
+	if len(history) != 1 {
+		t.Errorf("Se esperaba una transacción de intereses, obtenidas %d", len(history))
+	}
+
+	// Volver a cobrar el mismo día no tiene efecto
+	report, err = service.AccrueOverdraftInterest(context.Background(), day.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Error al repetir el cobro: %v", err)
+	}
+	if len(report.Accruals) != 0 || report.Skipped != 1 {
+		t.Errorf("El segundo cobro del día no debería cobrar nada, obtenido %+v", report)
+	}
+
+	// El libro mayor sigue cuadrado con el cargo contra los ingresos por intereses
+	ledgerService := application.NewLedgerService(uow)
+	verification, err := ledgerService.VerifyAccount(context.Background(), 1)
+	if err != nil || !verification.Balanced {
+		t.Errorf("La cuenta debería cuadrar con el libro mayor (err: %v, %+v)", err, verification)
+	}
+	if _, balanced, err := ledgerService.VerifyJournal(context.Background()); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado (err: %v)", err)
+	}
+}
+
+// Prueba la comisión diaria de sobregiro: se cobra con su propia transacción contra los ingresos por
+// comisiones, en la moneda de cada cuenta, aunque no haya tasa de intereses, y una sola vez por día
+func TestAccrueOverdraftFee(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("-1000.00", money.DefaultCurrency), Overdraft: money.MustParse("2000.00", money.DefaultCurrency)},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("-500", "JPY"), Overdraft: money.MustParse("1000", "JPY")},
+		&account.Account{ID: 3, AccountNumber: "ACC789", Balance: money.MustParse("500.00", money.DefaultCurrency)},
+	)
+	transactions := memory.NewTransactionRepository()
+	uow := memory.NewUnitOfWork(accountRepo, transactions)
+	service := application.NewInterestService(uow, application.InterestPolicy{DailyFee: big.NewRat(5, 2)})
+
+	day := time.Date(2024, time.September, 10, 0, 0, 0, 0, time.UTC)
+	report, err := service.AccrueOverdraftInterest(context.Background(), day)
+	if err != nil {
+		t.Fatalf("Error al cobrar la comisión: %v", err)
+	}
+	if len(report.Accruals) != 2 {
+		t.Fatalf("Se esperaban cargos a las cuentas 1 y 2, obtenido %+v", report.Accruals)
+	}
+
+	// 2.50 USD; en yenes, sin decimales, 2.5 se redondea a 2 (redondeo bancario)
+	for i, want := range []money.Money{money.MustParse("2.50", money.DefaultCurrency), money.MustParse("2", "JPY")} {
+		accrual := report.Accruals[i]
+		if accrual.Fee != want || !accrual.Interest.IsZero() || accrual.TransactionID != 0 || accrual.FeeTransactionID == 0 {
+			t.Errorf("Cargo %d incorrecto, esperada una comisión de %v sin intereses: %+v", i, want, accrual)
+		}
+	}
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("-1002.50", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado -1002.50, obtenido %v", acc.Balance)
+	}
+	history, _ := transactions.FindByAccount(context.Background(), 1, transaction.Filter{Types: []string{transaction.TypeOverdraftFee}, Limit: 10})
+	if len(history) != 1 || history[0].ID != report.Accruals[0].FeeTransactionID {
+		t.Errorf("Se esperaba una transacción de comisión, obtenidas %+v", history)
+	}
+
+	// La comisión se acredita a los ingresos por comisiones y el libro mayor sigue cuadrado
+	if fees, _, err := uow.Ledger().Sum(context.Background(), ledger.Fees.Code, money.DefaultCurrency); err != nil || fees != money.MustParse("-2.50", money.DefaultCurrency) {
+		t.Errorf("Ingresos por comisiones incorrectos: %v (err: %v)", fees, err)
+	}
+	ledgerService := application.NewLedgerService(uow)
+	if verification, err := ledgerService.VerifyAccount(context.Background(), 1); err != nil || !verification.Balanced {
+		t.Errorf("La cuenta debería cuadrar con el libro mayor (err: %v, %+v)", err, verification)
+	}
+
+	// Volver a cobrar el mismo día no tiene efecto
+	report, err = service.AccrueOverdraftInterest(context.Background(), day)
+	if err != nil || len(report.Accruals) != 0 || report.Skipped != 2 {
+		t.Errorf("El segundo cobro del día no debería cobrar nada, obtenido %+v (err: %v)", report, err)
+	}
+}
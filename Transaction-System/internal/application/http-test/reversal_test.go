@@ -0,0 +1,121 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"testing"
+)
+
+// lastTransaction devuelve la transacción más reciente de la cuenta.
+func lastTransaction(t *testing.T, service *application.TransactionService, accountID int) *transaction.Transaction {
+	t.Helper()
+	page, err := service.History(context.Background(), accountID, transaction.Filter{Limit: 1})
+	if err != nil || len(page.Transactions) == 0 {
+		t.Fatalf("No se encontró la última transacción de la cuenta %d (err: %v)", accountID, err)
+	}
+	return page.Transactions[0]
+}
+
+// Prueba revertir un depósito y un retiro: la reversión mueve el monto en sentido contrario, queda enlazada
+// a la original, no puede repetirse y el libro mayor sigue cuadrado
+func TestReverse(t *testing.T) {
+	accountRepo := newAccountRepository(t, &account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)})
+	uow := memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository())
+	service := application.NewTransactionService(uow)
+	ctx := context.Background()
+
+	if err := service.ProcessTransaction(ctx, 1, money.MustParse("30.00", money.DefaultCurrency), transaction.TypeWithdrawal); err != nil {
+		t.Fatalf("Error en el retiro: %v", err)
+	}
+	withdrawal := lastTransaction(t, service, 1)
+
+	reversal, err := service.Reverse(ctx, withdrawal.ID)
+	if err != nil {
+		t.Fatalf("Error al revertir el retiro: %v", err)
+	}
+	if reversal.TransactionType != transaction.TypeReversal || reversal.ReversalOf != withdrawal.ID || reversal.Amount != withdrawal.Amount {
+		t.Errorf("Reversión inesperada: %+v", reversal)
+	}
+	acc, _ := accountRepo.FindByID(ctx, 1)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto tras revertir el retiro, esperado 100.00, obtenido %v", acc.Balance)
+	}
+
+	// Una transacción se revierte una sola vez y una reversión no se revierte
+	if _, err := service.Reverse(ctx, withdrawal.ID); !errors.Is(err, transaction.ErrAlreadyReversed) {
+		t.Errorf("Se esperaba ErrAlreadyReversed, obtenido %v", err)
+	}
+	if _, err := service.Reverse(ctx, reversal.ID); !errors.Is(err, transaction.ErrNotReversible) {
+		t.Errorf("Se esperaba ErrNotReversible al revertir una reversión, obtenido %v", err)
+	}
+	if _, err := service.Reverse(ctx, 9999); !errors.Is(err, transaction.ErrNotFound) {
+		t.Errorf("Se esperaba ErrNotFound, obtenido %v", err)
+	}
+
+	// Revertir un depósito debita la cuenta
+	if err := service.ProcessTransaction(ctx, 1, money.MustParse("50.00", money.DefaultCurrency), transaction.TypeDeposit); err != nil {
+		t.Fatalf("Error en el depósito: %v", err)
+	}
+	deposit := lastTransaction(t, service, 1)
+	if _, err := service.Reverse(ctx, deposit.ID); err != nil {
+		t.Fatalf("Error al revertir el depósito: %v", err)
+	}
+	acc, _ = accountRepo.FindByID(ctx, 1)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto tras revertir el depósito, esperado 100.00, obtenido %v", acc.Balance)
+	}
+
+	ledgerService := application.NewLedgerService(uow)
+	if verification, err := ledgerService.VerifyAccount(ctx, 1); err != nil || !verification.Balanced {
+		t.Errorf("La cuenta debería cuadrar con el libro mayor (err: %v, %+v)", err, verification)
+	}
+	if _, balanced, err := ledgerService.VerifyJournal(ctx); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado (err: %v)", err)
+	}
+}
+
+// Prueba que revertir un depósito ya gastado respeta las reglas de fondos y que las patas de una
+// transferencia no se revierten por separado
+func TestReverse_Rejected(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("0.00", money.DefaultCurrency)},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("0.00", money.DefaultCurrency)},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+	ctx := context.Background()
+
+	if err := service.ProcessTransaction(ctx, 1, money.MustParse("80.00", money.DefaultCurrency), transaction.TypeDeposit); err != nil {
+		t.Fatalf("Error en el depósito: %v", err)
+	}
+	deposit := lastTransaction(t, service, 1)
+	if _, err := service.Transfer(ctx, 1, 2, money.MustParse("50.00", money.DefaultCurrency)); err != nil {
+		t.Fatalf("Error en la transferencia: %v", err)
+	}
+
+	// El balance restante (30.00) no alcanza para devolver el depósito de 80.00
+	if _, err := service.Reverse(ctx, deposit.ID); !errors.Is(err, account.ErrInsufficientFunds) {
+		t.Errorf("Se esperaba ErrInsufficientFunds, obtenido %v", err)
+	}
+	acc, _ := accountRepo.FindByID(ctx, 1)
+	if acc.Balance != money.MustParse("30.00", money.DefaultCurrency) {
+		t.Errorf("La reversión rechazada no debería modificar el balance, obtenido %v", acc.Balance)
+	}
+
+	transferIn := lastTransaction(t, service, 2)
+	if _, err := service.Reverse(ctx, transferIn.ID); !errors.Is(err, transaction.ErrNotReversible) {
+		t.Errorf("Se esperaba ErrNotReversible al revertir una pata de transferencia, obtenido %v", err)
+	}
+
+	// Tras un depósito que repone los fondos, la reversión se acepta
+	if err := service.ProcessTransaction(ctx, 1, money.MustParse("50.00", money.DefaultCurrency), transaction.TypeDeposit); err != nil {
+		t.Fatalf("Error en el depósito: %v", err)
+	}
+	if _, err := service.Reverse(ctx, deposit.ID); err != nil {
+		t.Errorf("La reversión debería aceptarse con fondos suficientes: %v", err)
+	}
+}
@@ -3,110 +3,322 @@ package http_test
 import (
 	"Transaction-System/internal/application"
 	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
 	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
 	"errors"
 	"testing"
 )
 
-// Mock para el repositorio de cuentas
-// Este mock almacena las cuentas en un mapa simulado en memoria, que actúa como base de datos temporal.
-type mockAccountRepository struct {
-	accounts map[int]*account.Account
-}
-
-// Método mock para guardar una cuenta
-func (m *mockAccountRepository) Save(a *account.Account) error {
-	m.accounts[a.ID] = a // Simula el almacenamiento de la cuenta en la "base de datos" (mapa en memoria)
-	return nil
-}
-
-// Método mock para buscar una cuenta por ID
-func (m *mockAccountRepository) FindByID(id int) (*account.Account, error) {
-	if account, exists := m.accounts[id]; exists {
-		return account, nil
+// newAccountRepository crea un repositorio de cuentas en memoria precargado con las cuentas indicadas.
+// Las pruebas usan el backend en memoria en lugar de mocks escritos a mano.
+func newAccountRepository(t testing.TB, accounts ...*account.Account) *memory.AccountRepository {
+	t.Helper()
+	repo := memory.NewAccountRepository()
+	for _, a := range accounts {
+		if err := repo.Save(context.Background(), a); err != nil {
+			t.Fatalf("No se pudo precargar la cuenta %d: %v", a.ID, err)
+		}
 	}
-	return nil, errors.New("cuenta no encontrada") // Devuelve un error si la cuenta no existe
+	return repo
 }
 
-// Mock para el repositorio de transacciones
-// Este mock simula la creación de transacciones sin interactuar con una base de datos real.
-type mockTransactionRepository struct{}
-
-// Método mock para guardar una transacción
-func (m *mockTransactionRepository) Save(t *transaction.Transaction) error {
-	// En este mock, no se hace nada, simplemente se simula que la transacción fue guardada correctamente.
-	return nil
+// countTransactions devuelve cuántas transacciones tiene guardadas la cuenta.
+func countTransactions(t testing.TB, repo transaction.Repository, accountID int) int {
+	t.Helper()
+	found, err := repo.FindByAccount(context.Background(), accountID, transaction.Filter{Limit: 1000})
+	if err != nil {
+		t.Fatalf("No se pudo consultar el historial de la cuenta %d: %v", accountID, err)
+	}
+	return len(found)
 }
 
 // Prueba para el procesamiento de depósitos
 func TestProcessTransaction_Deposit(t *testing.T) {
-	// Crear un mock del repositorio de cuentas con una cuenta inicial
-	accountRepo := &mockAccountRepository{
-		accounts: map[int]*account.Account{
-			1: {ID: 1, AccountNumber: "ACC123", Balance: 100.0}, // Cuenta con un balance inicial de 100.0
-		},
-	}
-	transactionRepo := &mockTransactionRepository{}                            // Mock del repositorio de transacciones
-	service := application.NewTransactionService(accountRepo, transactionRepo) // Crear el servicio de transacciones
+	// Crear el repositorio de cuentas en memoria con una cuenta inicial
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)}, // Cuenta con un balance inicial de 100.0
+	)
+	transactionRepo := memory.NewTransactionRepository()                                             // Repositorio de transacciones en memoria
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo)) // Crear el servicio de transacciones
 
 	// Probar un depósito de 50.0 a la cuenta
-	err := service.ProcessTransaction(1, 50.0, "deposit")
+	err := service.ProcessTransaction(context.Background(), 1, money.MustParse("50.00", money.DefaultCurrency), "deposit")
 	if err != nil {
 		t.Fatalf("Error al procesar el depósito: %v", err)
 	}
 
 	// Verificar que el balance de la cuenta sea el correcto tras el depósito
-	acc, _ := accountRepo.FindByID(1)
-	if acc.Balance != 150.0 { // Balance esperado: 100.0 + 50.0 = 150.0
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("150.00", money.DefaultCurrency) { // Balance esperado: 100.0 + 50.0 = 150.0
 		t.Errorf("Balance incorrecto tras el depósito, esperado 150.0, obtenido %v", acc.Balance)
 	}
 }
 
 // Prueba para el procesamiento de retiros
 func TestProcessTransaction_Withdraw(t *testing.T) {
-	// Crear un mock del repositorio de cuentas con una cuenta inicial
-	accountRepo := &mockAccountRepository{
-		accounts: map[int]*account.Account{
-			1: {ID: 1, AccountNumber: "ACC123", Balance: 100.0}, // Balance inicial: 100.0
-		},
-	}
-	transactionRepo := &mockTransactionRepository{}                            // Mock del repositorio de transacciones
-	service := application.NewTransactionService(accountRepo, transactionRepo) // Crear el servicio de transacciones
+	// Crear el repositorio de cuentas en memoria con una cuenta inicial
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)}, // Balance inicial: 100.0
+	)
+	transactionRepo := memory.NewTransactionRepository()                                             // Repositorio de transacciones en memoria
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo)) // Crear el servicio de transacciones
 
 	// Probar un retiro de 50.0 de la cuenta
-	err := service.ProcessTransaction(1, 50.0, "withdrawal")
+	err := service.ProcessTransaction(context.Background(), 1, money.MustParse("50.00", money.DefaultCurrency), "withdrawal")
 	if err != nil {
 		t.Fatalf("Error al procesar el retiro: %v", err)
 	}
 
 	// Verificar que el balance de la cuenta sea el correcto tras el retiro
-	acc, _ := accountRepo.FindByID(1)
-	if acc.Balance != 50.0 { // Balance esperado: 100.0 - 50.0 = 50.0
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("50.00", money.DefaultCurrency) { // Balance esperado: 100.0 - 50.0 = 50.0
 		t.Errorf("Balance incorrecto tras el retiro, esperado 50.0, obtenido %v", acc.Balance)
 	}
 }
 
 // Prueba para el retiro con fondos insuficientes
 func TestProcessTransaction_Withdraw_InsufficientFunds(t *testing.T) {
-	// Crear un mock del repositorio de cuentas con una cuenta inicial
-	accountRepo := &mockAccountRepository{
-		accounts: map[int]*account.Account{
-			1: {ID: 1, AccountNumber: "ACC123", Balance: 100.0}, // Balance inicial: 100.0
-		},
-	}
-	transactionRepo := &mockTransactionRepository{}                            // Mock del repositorio de transacciones
-	service := application.NewTransactionService(accountRepo, transactionRepo) // Crear el servicio de transacciones
+	// Crear el repositorio de cuentas en memoria con una cuenta inicial
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)}, // Balance inicial: 100.0
+	)
+	transactionRepo := memory.NewTransactionRepository()                                             // Repositorio de transacciones en memoria
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo)) // Crear el servicio de transacciones
 
 	// Probar un retiro de 150.0 (más de lo que hay en la cuenta)
-	err := service.ProcessTransaction(1, 150.0, "withdrawal")
-	if err == nil {
+	err := service.ProcessTransaction(context.Background(), 1, money.MustParse("150.00", money.DefaultCurrency), "withdrawal")
+	if !errors.Is(err, account.ErrInsufficientFunds) {
 		// Se espera un error debido a fondos insuficientes
-		t.Fatal("Se esperaba un error por fondos insuficientes, pero no se recibió ninguno")
+		t.Fatalf("Se esperaba account.ErrInsufficientFunds, obtenido %v", err)
+	}
+
+	// Verificar que el balance de la cuenta no haya cambiado
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) { // Balance esperado: 100.0 (sin cambios)
+		t.Errorf("El balance no debería haber cambiado, esperado 100.0, obtenido %v", acc.Balance)
+	}
+}
+
+// Mock de repositorio de transacciones que siempre falla al guardar
+// Se utiliza para comprobar que el balance no cambia si el registro de la transacción falla.
+type failingTransactionRepository struct{}
+
+// Método mock que simula un error de la base de datos al guardar la transacción
+func (m *failingTransactionRepository) Save(ctx context.Context, t *transaction.Transaction) error {
+	return errors.New("error al guardar la transacción")
+}
+
+// Métodos mock para buscar una transacción, su reversión o la transferencia de una cotización; no hay transacciones guardadas
+func (m *failingTransactionRepository) FindByID(ctx context.Context, id int) (*transaction.Transaction, error) {
+	return nil, transaction.ErrNotFound
+}
+
+func (m *failingTransactionRepository) FindReversal(ctx context.Context, originalID int) (*transaction.Transaction, error) {
+	return nil, transaction.ErrNotFound
+}
+
+func (m *failingTransactionRepository) FindByQuote(ctx context.Context, quoteID string) (*transaction.Transaction, error) {
+	return nil, transaction.ErrNotFound
+}
+
+// Método mock para consultar el historial; no hay transacciones guardadas
+func (m *failingTransactionRepository) FindByAccount(ctx context.Context, accountID int, filter transaction.Filter) ([]*transaction.Transaction, error) {
+	return nil, nil
+}
+
+// Prueba que el balance y la transacción se confirman o se revierten juntos
+func TestProcessTransaction_RollbackWhenTransactionSaveFails(t *testing.T) {
+	// Crear el repositorio de cuentas en memoria con una cuenta inicial
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)}, // Balance inicial: 100.0
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, &failingTransactionRepository{}))
+
+	// El depósito debe fallar porque no se puede registrar la transacción
+	if err := service.ProcessTransaction(context.Background(), 1, money.MustParse("50.00", money.DefaultCurrency), "deposit"); err == nil {
+		t.Fatal("Se esperaba un error al guardar la transacción, pero no se recibió ninguno")
 	}
 
 	// Verificar que el balance de la cuenta no haya cambiado
-	acc, _ := accountRepo.FindByID(1)
-	if acc.Balance != 100.0 { // Balance esperado: 100.0 (sin cambios)
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) { // Balance esperado: 100.0 (sin cambios)
+		t.Errorf("El balance no debería haber cambiado, esperado 100.0, obtenido %v", acc.Balance)
+	}
+}
+
+// Mock de repositorio de cuentas que cancela el contexto de la solicitud al leer la cuenta
+// Simula un cliente que se desconecta mientras su depósito se está procesando.
+type cancelingAccountRepository struct {
+	*memory.AccountRepository
+	cancel context.CancelFunc // Cancela el contexto de la solicitud
+}
+
+// Método mock que lee la cuenta y luego cancela la solicitud
+func (m *cancelingAccountRepository) FindByIDForUpdate(ctx context.Context, id int) (*account.Account, error) {
+	m.cancel()
+	return m.AccountRepository.FindByIDForUpdate(ctx, id)
+}
+
+// Prueba que una solicitud cancelada no confirma cambios y devuelve el error del contexto
+func TestProcessTransaction_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	accountRepo := &cancelingAccountRepository{
+		AccountRepository: newAccountRepository(t,
+			&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+		),
+		cancel: cancel,
+	}
+	transactionRepo := memory.NewTransactionRepository()
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo))
+
+	err := service.ProcessTransaction(ctx, 1, money.MustParse("50.00", money.DefaultCurrency), "deposit")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Se esperaba context.Canceled, obtenido %v", err)
+	}
+
+	// Ni el balance ni el historial deben reflejar el depósito cancelado
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) {
 		t.Errorf("El balance no debería haber cambiado, esperado 100.0, obtenido %v", acc.Balance)
 	}
+	if n := countTransactions(t, transactionRepo, 1); n != 0 {
+		t.Errorf("No se esperaban transacciones guardadas, obtenidas %d", n)
+	}
+}
+
+// Prueba para una transferencia exitosa entre dos cuentas
+func TestTransfer(t *testing.T) {
+	// Crear el repositorio de cuentas en memoria con dos cuentas iniciales
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("20.00", money.DefaultCurrency)},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+
+	// Transferir 15.50 de la cuenta 2 a la cuenta 1 (origen con ID mayor que destino)
+	transferID, err := service.Transfer(context.Background(), 2, 1, money.MustParse("15.50", money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al procesar la transferencia: %v", err)
+	}
+	if transferID == "" {
+		t.Error("Se esperaba un identificador de transferencia")
+	}
+
+	// Verificar los balances de ambas cuentas
+	from, _ := accountRepo.FindByID(context.Background(), 2)
+	to, _ := accountRepo.FindByID(context.Background(), 1)
+	if from.Balance != money.MustParse("4.50", money.DefaultCurrency) {
+		t.Errorf("Balance de origen incorrecto, esperado 4.50, obtenido %v", from.Balance)
+	}
+	if to.Balance != money.MustParse("115.50", money.DefaultCurrency) {
+		t.Errorf("Balance de destino incorrecto, esperado 115.50, obtenido %v", to.Balance)
+	}
+}
+
+// Prueba que una transferencia sin fondos suficientes no modifica ninguna cuenta
+func TestTransfer_InsufficientFunds(t *testing.T) {
+	// Crear el repositorio de cuentas en memoria con dos cuentas iniciales
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("20.00", money.DefaultCurrency)},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+
+	// Intentar transferir más de lo que tiene la cuenta de origen
+	if _, err := service.Transfer(context.Background(), 2, 1, money.MustParse("50.00", money.DefaultCurrency)); err == nil {
+		t.Fatal("Se esperaba un error por fondos insuficientes, pero no se recibió ninguno")
+	}
+
+	// Verificar que ningún balance haya cambiado
+	from, _ := accountRepo.FindByID(context.Background(), 2)
+	to, _ := accountRepo.FindByID(context.Background(), 1)
+	if from.Balance != money.MustParse("20.00", money.DefaultCurrency) || to.Balance != money.MustParse("100.00", money.DefaultCurrency) {
+		t.Errorf("Los balances no deberían haber cambiado, obtenidos %v y %v", from.Balance, to.Balance)
+	}
+}
+
+// Prueba que un movimiento en otra moneda se rechaza con un error tipado y no modifica la cuenta
+func TestProcessTransaction_CurrencyMismatch(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", "EUR")},
+	)
+	transactionRepo := memory.NewTransactionRepository()
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo))
+
+	err := service.ProcessTransaction(context.Background(), 1, money.MustParse("50.00", "USD"), "deposit")
+	var mismatch *account.CurrencyMismatchError
+	if !errors.As(err, &mismatch) || mismatch.AccountCurrency != "EUR" || mismatch.Currency != "USD" {
+		t.Fatalf("Se esperaba un CurrencyMismatchError EUR/USD, obtenido %v", err)
+	}
+	if !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("El error debería envolver money.ErrCurrencyMismatch: %v", err)
+	}
+
+	acc, _ := accountRepo.FindByID(context.Background(), 1)
+	if acc.Balance != money.MustParse("100.00", "EUR") || countTransactions(t, transactionRepo, 1) != 0 {
+		t.Errorf("La cuenta no debería haber cambiado, balance %v", acc.Balance)
+	}
+}
+
+// Prueba que una transferencia entre cuentas de distinta moneda no mueve fondos
+func TestTransfer_CurrencyMismatch(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.000", "KWD")},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("20.00", "USD")},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+
+	_, err := service.Transfer(context.Background(), 1, 2, money.MustParse("10.125", "KWD"))
+	var mismatch *account.CurrencyMismatchError
+	if !errors.As(err, &mismatch) || mismatch.AccountID != 2 {
+		t.Fatalf("Se esperaba un CurrencyMismatchError sobre la cuenta de destino, obtenido %v", err)
+	}
+
+	from, _ := accountRepo.FindByID(context.Background(), 1)
+	if from.Balance != money.MustParse("100.000", "KWD") {
+		t.Errorf("El balance de origen no debería haber cambiado, obtenido %v", from.Balance)
+	}
+}
+
+// Prueba que depósitos, retiros y transferencias generan asientos balanceados
+// y que los balances derivados del libro mayor coinciden con los de las cuentas
+func TestLedger_BalancesDerivedFromJournal(t *testing.T) {
+	// Crear el repositorio de cuentas en memoria con dos cuentas iniciales
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC123", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+		&account.Account{ID: 2, AccountNumber: "ACC456", Balance: money.MustParse("20.00", money.DefaultCurrency)},
+	)
+	uow := memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository())
+	service := application.NewTransactionService(uow)
+
+	// Ejecutar una serie de operaciones sobre ambas cuentas
+	if err := service.ProcessTransaction(context.Background(), 1, money.MustParse("10.25", money.DefaultCurrency), "deposit"); err != nil {
+		t.Fatalf("Error al procesar el depósito: %v", err)
+	}
+	if err := service.ProcessTransaction(context.Background(), 2, money.MustParse("5.00", money.DefaultCurrency), "withdrawal"); err != nil {
+		t.Fatalf("Error al procesar el retiro: %v", err)
+	}
+	if _, err := service.Transfer(context.Background(), 1, 2, money.MustParse("60.00", money.DefaultCurrency)); err != nil {
+		t.Fatalf("Error al procesar la transferencia: %v", err)
+	}
+
+	// Cada cuenta debe coincidir con el balance derivado del libro mayor
+	ledgerService := application.NewLedgerService(uow)
+	for _, id := range []int{1, 2} {
+		verification, err := ledgerService.VerifyAccount(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Error al verificar la cuenta %d: %v", id, err)
+		}
+		if !verification.Balanced {
+			t.Errorf("Cuenta %d descuadrada: guardado %v, libro mayor %v", id, verification.StoredBalance, verification.LedgerBalance)
+		}
+	}
+
+	// La suma de todos los movimientos del libro debe ser cero
+	if _, balanced, err := ledgerService.VerifyJournal(context.Background()); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado (err: %v)", err)
+	}
 }
@@ -0,0 +1,146 @@
+package http_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Prueba la validación de las solicitudes de depósito y retiro
+func TestValidateTransaction(t *testing.T) {
+	validator := application.NewValidator(nil)
+
+	tests := []struct {
+		name  string
+		cmd   application.TransactionCommand
+		field string // Campo con error esperado; vacío si la solicitud es válida
+		code  string // Código de error esperado
+	}{
+		{"válida", application.TransactionCommand{AccountID: 1, Amount: "10.50", Currency: "USD"}, "", ""},
+		{"sin cuenta", application.TransactionCommand{Amount: "10.50", Currency: "USD"}, "account_id", application.FieldRequired},
+		{"sin monto", application.TransactionCommand{AccountID: 1, Currency: "USD"}, "amount", application.FieldRequired},
+		{"monto cero", application.TransactionCommand{AccountID: 1, Amount: "0", Currency: "USD"}, "amount", application.FieldNotPositive},
+		{"monto negativo", application.TransactionCommand{AccountID: 1, Amount: "-5", Currency: "USD"}, "amount", application.FieldNotPositive},
+		{"NaN", application.TransactionCommand{AccountID: 1, Amount: "NaN", Currency: "USD"}, "amount", application.FieldInvalid},
+		{"tres decimales", application.TransactionCommand{AccountID: 1, Amount: "10.505", Currency: "USD"}, "amount", application.FieldPrecision},
+		{"sobre el máximo", application.TransactionCommand{AccountID: 1, Amount: "1000000.01", Currency: "USD"}, "amount", application.FieldTooLarge},
+		{"fuera de rango", application.TransactionCommand{AccountID: 1, Amount: "1e30", Currency: "USD"}, "amount", application.FieldTooLarge},
+		{"moneda desconocida", application.TransactionCommand{AccountID: 1, Amount: "10", Currency: "XXX"}, "currency", application.FieldInvalid},
+		{"sin moneda", application.TransactionCommand{AccountID: 1, Amount: "10.50"}, "currency", application.FieldRequired},
+		{"tres decimales en KWD", application.TransactionCommand{AccountID: 1, Amount: "10.505", Currency: "KWD"}, "", ""},
+		{"decimales en JPY", application.TransactionCommand{AccountID: 1, Amount: "100.5", Currency: "JPY"}, "amount", application.FieldPrecision},
+		{"moneda en minúsculas", application.TransactionCommand{AccountID: 1, Amount: "10.50", Currency: "eur"}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateTransaction(tt.cmd)
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("No se esperaba un error: %v", err)
+				}
+				return
+			}
+
+			var verr *application.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Se esperaba un ValidationError, obtenido %v", err)
+			}
+			if len(verr.Errors) != 1 || verr.Errors[0].Field != tt.field || verr.Errors[0].Code != tt.code {
+				t.Errorf("Errores incorrectos: %+v, esperado %s/%s", verr.Errors, tt.field, tt.code)
+			}
+		})
+	}
+}
+
+// Prueba que la validación de una transferencia informa todos los campos inválidos a la vez
+func TestValidateTransfer_ReportsAllFields(t *testing.T) {
+	_, err := application.NewValidator(nil).ValidateTransfer(application.TransferCommand{FromAccountID: 3, ToAccountID: 3, Amount: "-1", Currency: "USD"})
+
+	var verr *application.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Se esperaba un ValidationError, obtenido %v", err)
+	}
+	if len(verr.Errors) != 2 || verr.Errors[0].Code != application.FieldSameAsSource || verr.Errors[1].Field != "amount" {
+		t.Errorf("Errores incorrectos: %+v", verr.Errors)
+	}
+}
+
+// Prueba que sin moneda no se valida la precisión del monto, porque depende de los decimales de la moneda
+func TestValidateTransfer_MissingCurrency(t *testing.T) {
+	_, err := application.NewValidator(nil).ValidateTransfer(application.TransferCommand{FromAccountID: 1, ToAccountID: 2, Amount: "10.505"})
+
+	var verr *application.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Se esperaba un ValidationError, obtenido %v", err)
+	}
+	if len(verr.Errors) != 1 || verr.Errors[0].Field != "currency" || verr.Errors[0].Code != application.FieldRequired {
+		t.Errorf("Errores incorrectos: %+v", verr.Errors)
+	}
+}
+
+// Prueba que una cuenta se abre en la moneda indicada aunque no tenga depósito inicial
+func TestValidateInitialDeposit_Currency(t *testing.T) {
+	validator := application.NewValidator(nil)
+
+	zero, err := validator.ValidateInitialDeposit("", "jpy")
+	if err != nil || zero.Currency() != "JPY" || !zero.IsZero() {
+		t.Errorf("Depósito inicial incorrecto: %v (%v)", zero, err)
+	}
+
+	_, err = validator.ValidateInitialDeposit("", "XXX")
+	var verr *application.ValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 1 || verr.Errors[0].Field != "currency" {
+		t.Errorf("Se esperaba un error en currency, obtenido %v", err)
+	}
+}
+
+// Prueba la validación de las solicitudes de cotización y de tipos de cambio
+func TestValidateQuoteAndRate(t *testing.T) {
+	v := application.NewValidator(nil)
+
+	amount, pair, err := v.ValidateQuote(application.QuoteCommand{Amount: "100.00", Currency: "USD", TargetCurrency: "eur"})
+	if err != nil || amount.Amount() != 10000 || pair.String() != "USD/EUR" {
+		t.Errorf("Cotización válida rechazada: %v %v (err: %v)", amount, pair, err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want []application.FieldError
+	}{
+		{"cotización sin destino", second(v.ValidateQuote(application.QuoteCommand{Amount: "1", Currency: "USD"})),
+			[]application.FieldError{{Field: "target_currency", Code: application.FieldRequired}}},
+		{"cotización en la misma moneda", second(v.ValidateQuote(application.QuoteCommand{Amount: "1", Currency: "USD", TargetCurrency: "USD"})),
+			[]application.FieldError{{Field: "target_currency", Code: application.FieldSameCurrency}}},
+		{"tipo vacío", errOf(v.ValidateRate(application.RateCommand{Base: "USD", Quote: "EUR"}, time.Now())),
+			[]application.FieldError{{Field: "rate", Code: application.FieldRequired}}},
+		{"tipo inválido y moneda desconocida", errOf(v.ValidateRate(application.RateCommand{Base: "USD", Quote: "XXX", Rate: "-1"}, time.Now())),
+			[]application.FieldError{{Field: "quote", Code: application.FieldInvalid}, {Field: "rate", Code: application.FieldInvalid}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verr *application.ValidationError
+			if !errors.As(tt.err, &verr) {
+				t.Fatalf("Se esperaba un ValidationError, obtenido %v", tt.err)
+			}
+			if len(verr.Errors) != len(tt.want) {
+				t.Fatalf("Errores incorrectos: %+v", verr.Errors)
+			}
+			for i, want := range tt.want {
+				if verr.Errors[i].Field != want.Field || verr.Errors[i].Code != want.Code {
+					t.Errorf("Error %d: obtenido %+v, esperado %+v", i, verr.Errors[i], want)
+				}
+			}
+		})
+	}
+}
+
+// second devuelve el error de ValidateQuote.
+func second(_ money.Money, _ fx.Pair, err error) error { return err }
+
+// errOf devuelve el error de ValidateRate.
+func errOf(_ fx.Rate, err error) error { return err }
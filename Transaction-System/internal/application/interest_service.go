@@ -0,0 +1,192 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/account"     // Importación del dominio de cuentas
+	"Transaction-System/internal/domain/money"       // Tipo Money para montos exactos
+	"Transaction-System/internal/domain/transaction" // Importación del dominio de transacciones
+	"context"                                        // Contexto de la solicitud
+	"errors"                                         // Agrupación de los errores por cuenta
+	"fmt"                                            // Paquete para formatear errores
+	"log/slog"                                       // Logging de los intereses cobrados
+	"math/big"                                       // Tasa de interés exacta
+	"time"                                           // Día de cálculo de los intereses
+)
+
+// DefaultDaysPerYear es la base de días del año con que se prorratea la tasa anual si la política no indica otra.
+const DefaultDaysPerYear = 365
+
+// InterestPolicy define cómo se calculan los intereses y la comisión diarios sobre el sobregiro en uso.
+type InterestPolicy struct {
+	AnnualRate  *big.Rat // Tasa nominal anual sobre el sobregiro en uso (0.18 = 18 %); nil o cero no cobra intereses
+	DaysPerYear int      // Base de días del año con que se prorratea la tasa
+	DailyFee    *big.Rat // Comisión fija por cada día sobregirado, en unidades de la moneda de la cuenta; nil o cero no cobra comisión
+}
+
+// InterestAccrual es el cargo de intereses y comisión de un día sobre el sobregiro de una cuenta.
+type InterestAccrual struct {
+	AccountID        int         // Cuenta a la que se cobraron los intereses
+	Used             money.Money // Sobregiro en uso sobre el que se calcularon
+	Interest         money.Money // Intereses cobrados; cero si redondean a cero
+	TransactionID    int         // Transacción "overdraft_interest" con que se cobraron; 0 si no hubo intereses
+	Fee              money.Money // Comisión de sobregiro cobrada; cero si no hay comisión
+	FeeTransactionID int         // Transacción "overdraft_fee" con que se cobró; 0 si no hubo comisión
+}
+
+// InterestReport es el resultado del cálculo de intereses de un día.
+type InterestReport struct {
+	Day      time.Time         // Día por el que se cobraron los intereses (medianoche UTC)
+	Accruals []InterestAccrual // Cargos realizados, por ID de cuenta
+	Skipped  int               // Cuentas sobregiradas sin cargo: ya cobradas ese día o con intereses y comisión que redondean a cero
+}
+
+// InterestService calcula y cobra los intereses y la comisión diarios sobre el sobregiro en uso de las cuentas.
+type InterestService struct {
+	uow    UnitOfWork     // Unidad de trabajo que provee los repositorios transaccionales
+	policy InterestPolicy // Tasa, base de días y comisión diaria
+}
+
+// NewInterestService crea una instancia del servicio de intereses.
+// Parametros:
+//   - uow: unidad de trabajo con la que se accede a cuentas, transacciones y libro mayor
+//   - policy: tasa, base de días y comisión diaria; una base no positiva usa DefaultDaysPerYear
+func NewInterestService(uow UnitOfWork, policy InterestPolicy) *InterestService {
+	if policy.DaysPerYear <= 0 {
+		policy.DaysPerYear = DefaultDaysPerYear
+	}
+	if policy.AnnualRate == nil {
+		policy.AnnualRate = new(big.Rat)
+	}
+	if policy.DailyFee == nil {
+		policy.DailyFee = new(big.Rat)
+	}
+	return &InterestService{uow: uow, policy: policy}
+}
+
+// AccrueOverdraftInterest cobra los intereses y la comisión del día indicado a cada cuenta sobregirada.
+// Los intereses se calculan sobre el sobregiro en uso al momento del cálculo, por lo que el cálculo de un
+// día debe ejecutarse al cerrarlo. Cada cuenta se cobra en su propia unidad de trabajo: se debita su balance,
+// se registran una transacción "overdraft_interest" con su asiento contra los ingresos por intereses y una
+// "overdraft_fee" con su asiento contra los ingresos por comisiones, y se anota el día cobrado en la cuenta,
+// de modo que volver a ejecutar el cálculo del mismo día no cobra dos veces.
+// Un error en una cuenta no detiene el cálculo de las demás; los errores se devuelven agrupados junto con
+// el informe de los cargos realizados.
+func (s *InterestService) AccrueOverdraftInterest(ctx context.Context, day time.Time) (*InterestReport, error) {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	report := &InterestReport{Day: day}
+	if s.policy.AnnualRate.Sign() <= 0 && s.policy.DailyFee.Sign() <= 0 {
+		return report, nil
+	}
+
+	ids, err := s.overdrawnAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		accrual, err := s.accrue(ctx, id, day)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("cuenta %d: %w", id, err))
+		case accrual == nil:
+			report.Skipped++
+		default:
+			report.Accruals = append(report.Accruals, *accrual)
+			slog.InfoContext(ctx, "intereses de sobregiro cobrados", "account_id", id, "day", day.Format(time.DateOnly),
+				"used", accrual.Used.String(), "interest", accrual.Interest.String(), "transaction_id", accrual.TransactionID,
+				"fee", accrual.Fee.String(), "fee_transaction_id", accrual.FeeTransactionID)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// overdrawnAccounts devuelve los IDs de las cuentas con sobregiro en uso, recorriendo todas las páginas.
+func (s *InterestService) overdrawnAccounts(ctx context.Context) ([]int, error) {
+	var ids []int
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		for offset := 0; ; offset += MaxListLimit {
+			accounts, total, err := repos.Accounts.List(ctx, account.ListFilter{Overdrawn: true, Limit: MaxListLimit, Offset: offset})
+			if err != nil {
+				return err
+			}
+			for _, acc := range accounts {
+				ids = append(ids, acc.ID)
+			}
+			if offset+MaxListLimit >= total {
+				return nil
+			}
+		}
+	})
+	return ids, err
+}
+
+// accrue cobra los intereses y la comisión del día a una cuenta. Devuelve nil sin error si la cuenta dejó de
+// estar sobregirada, ya se le cobró ese día o los intereses y la comisión redondean a cero (en ese caso el día
+// se anota igual).
+func (s *InterestService) accrue(ctx context.Context, accountID int, day time.Time) (*InterestAccrual, error) {
+	var accrual *InterestAccrual
+	err := executeWithRetry(ctx, s.uow, DefaultRetryPolicy, func(repos Repositories) error {
+		accrual = nil
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if !acc.InterestDue(day) {
+			return nil
+		}
+
+		used := acc.OverdraftUsed()
+		interest, err := acc.DailyInterest(s.policy.AnnualRate, s.policy.DaysPerYear)
+		if err != nil {
+			return err
+		}
+		fee, err := acc.DailyFee(s.policy.DailyFee)
+		if err != nil {
+			return err
+		}
+		acc.InterestAccruedOn = day
+		if interest.IsZero() && fee.IsZero() {
+			return repos.Accounts.Update(ctx, acc)
+		}
+
+		// Registrar en el libro mayor el saldo inicial de la cuenta si aún no tiene movimientos
+		if err := ensureOpeningBalance(ctx, repos, acc); err != nil {
+			return err
+		}
+		result := &InterestAccrual{AccountID: acc.ID, Used: used, Interest: interest, Fee: fee}
+		charges := []struct {
+			amount          money.Money
+			transactionType string
+			transactionID   *int
+		}{
+			{interest, transaction.TypeOverdraftInterest, &result.TransactionID},
+			{fee, transaction.TypeOverdraftFee, &result.FeeTransactionID},
+		}
+		for _, c := range charges {
+			if !c.amount.IsZero() {
+				if err := acc.Charge(c.amount); err != nil {
+					return err
+				}
+			}
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+		for _, c := range charges {
+			if c.amount.IsZero() {
+				continue
+			}
+			tr := transaction.New(acc.ID, c.amount, c.transactionType)
+			if err := repos.Transactions.Save(ctx, tr); err != nil {
+				return err
+			}
+			if err := repos.Ledger.Append(ctx, transactionEntry(tr)); err != nil {
+				return err
+			}
+			*c.transactionID = tr.ID
+		}
+		accrual = result
+		return nil
+	})
+	return accrual, err
+}
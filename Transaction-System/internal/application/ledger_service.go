@@ -0,0 +1,80 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/ledger" // Importación del libro mayor
+	"Transaction-System/internal/domain/money"  // Tipo Money para montos exactos
+	"context"                                   // Contexto de la solicitud
+)
+
+// AccountVerification es el resultado de conciliar una cuenta con el libro mayor.
+type AccountVerification struct {
+	AccountID     int         `json:"account_id"`     // ID de la cuenta conciliada
+	StoredBalance money.Money `json:"stored_balance"` // Balance guardado en la cuenta
+	LedgerBalance money.Money `json:"ledger_balance"` // Balance derivado de los movimientos del libro mayor
+	Postings      int         `json:"postings"`       // Cantidad de movimientos de la cuenta en el libro
+	Balanced      bool        `json:"balanced"`       // Indica si ambos balances coinciden
+}
+
+// LedgerService es el servicio que permite derivar y verificar balances a partir del libro mayor.
+type LedgerService struct {
+	uow UnitOfWork // Unidad de trabajo que provee los repositorios
+}
+
+// NewLedgerService crea una instancia del servicio del libro mayor.
+func NewLedgerService(uow UnitOfWork) *LedgerService {
+	return &LedgerService{uow: uow}
+}
+
+// VerifyAccount deriva el balance de una cuenta a partir de sus movimientos en el libro mayor
+// y lo compara con el balance guardado en la cuenta.
+func (s *LedgerService) VerifyAccount(ctx context.Context, accountID int) (*AccountVerification, error) {
+	var result *AccountVerification
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		acc, err := repos.Accounts.FindByID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		// Sumar los movimientos de la cuenta contable del cliente
+		customer := ledger.CustomerAccount(acc.ID)
+		sum, postings, err := repos.Ledger.Sum(ctx, customer.Code, acc.Balance.Currency())
+		if err != nil {
+			return err
+		}
+
+		derived := customer.BalanceFromSum(sum)
+		result = &AccountVerification{
+			AccountID:     acc.ID,
+			StoredBalance: acc.Balance,
+			LedgerBalance: derived,
+			Postings:      postings,
+			Balanced:      derived == acc.Balance,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// VerifyJournal calcula el balance de comprobación del libro mayor.
+// Devuelve la suma de movimientos por moneda y si todas las sumas son cero.
+func (s *LedgerService) VerifyJournal(ctx context.Context) (map[string]money.Money, bool, error) {
+	var totals map[string]money.Money
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		var err error
+		totals, err = repos.Ledger.TrialBalance(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, total := range totals {
+		if !total.IsZero() {
+			return totals, false, nil
+		}
+	}
+	return totals, true, nil
+}
@@ -0,0 +1,21 @@
+package application
+
+import "Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+
+// TypeTransfer es el tipo con el que se informan las transferencias a un TransactionObserver.
+const TypeTransfer = "transfer"
+
+// TransactionObserver recibe el resultado de cada movimiento procesado por TransactionService,
+// por ejemplo para publicar métricas. Se invoca una vez por operación, después de los reintentos.
+// Las implementaciones deben ser seguras para uso concurrente y no bloquear.
+type TransactionObserver interface {
+	// TransactionProcessed informa el tipo de movimiento ("deposit", "withdrawal" o TypeTransfer),
+	// su monto y el error con el que terminó (nil si se completó).
+	TransactionProcessed(transactionType string, amount money.Money, err error)
+}
+
+// noopObserver es el observador por defecto, que descarta los resultados.
+type noopObserver struct{}
+
+// TransactionProcessed implementa la interfaz TransactionObserver.
+func (noopObserver) TransactionProcessed(string, money.Money, error) {}
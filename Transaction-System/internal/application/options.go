@@ -0,0 +1,34 @@
+package application
+
+import "Transaction-System/internal/domain/fx" // Cotizaciones de cambio de divisas
+
+// Option configura un TransactionService al crearlo.
+type Option func(*TransactionService)
+
+// WithValidator reemplaza el validador de montos (por ejemplo, para usar otros máximos por moneda).
+func WithValidator(validator *Validator) Option {
+	return func(s *TransactionService) {
+		s.validator = validator
+	}
+}
+
+// WithObserver registra un observador que recibe el resultado de cada depósito, retiro y transferencia.
+func WithObserver(observer TransactionObserver) Option {
+	return func(s *TransactionService) {
+		s.observer = observer
+	}
+}
+
+// WithRetryPolicy reemplaza la política de reintentos ante conflictos de concurrencia.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(s *TransactionService) {
+		s.retry = policy
+	}
+}
+
+// WithQuotes habilita las transferencias entre monedas, que se liquidan con las cotizaciones del repositorio.
+func WithQuotes(quotes fx.QuoteRepository) Option {
+	return func(s *TransactionService) {
+		s.quotes = quotes
+	}
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/account"     // Importación del dominio de cuentas
+	"Transaction-System/internal/domain/ledger"      // Importación del libro mayor
+	"Transaction-System/internal/domain/money"       // Tipo Money para montos exactos
+	"Transaction-System/internal/domain/transaction" // Importación del dominio de transacciones
+	"context"                                        // Contexto de la solicitud
+	"fmt"                                            // Paquete para formatear referencias
+)
+
+// ensureOpeningBalance registra el saldo inicial de una cuenta en el libro mayor.
+// Las cuentas creadas antes de existir el libro (por ejemplo, por el generador de datos) tienen
+// balance pero ningún movimiento; su saldo se contabiliza una única vez contra la cuenta transitoria,
+// de modo que el saldo derivado del libro coincida con el balance de la cuenta.
+// Debe invocarse antes de modificar el balance de la cuenta.
+func ensureOpeningBalance(ctx context.Context, repos Repositories, acc *account.Account) error {
+	customer := ledger.CustomerAccount(acc.ID)
+	_, postings, err := repos.Ledger.Sum(ctx, customer.Code, acc.Balance.Currency())
+	if err != nil {
+		return err
+	}
+	if postings > 0 || acc.Balance.IsZero() {
+		return nil
+	}
+
+	entry := ledger.NewEntry(fmt.Sprintf("opening:%d", acc.ID), "Saldo inicial de la cuenta").
+		Debit(ledger.Suspense, acc.Balance).
+		Credit(customer, acc.Balance)
+	return repos.Ledger.Append(ctx, entry)
+}
+
+// transactionEntry construye el asiento contable de un depósito, retiro o cargo de intereses ya guardado.
+// Depósito: débito a caja y crédito a la cuenta del cliente. Retiro: el asiento inverso.
+// Intereses de sobregiro: débito a la cuenta del cliente y crédito a los ingresos por intereses.
+func transactionEntry(tr *transaction.Transaction) *ledger.JournalEntry {
+	customer := ledger.CustomerAccount(tr.AccountID)
+	entry := ledger.NewEntry(fmt.Sprintf("transaction:%d", tr.ID), "Transacción "+tr.TransactionType)
+	switch tr.TransactionType {
+	case transaction.TypeDeposit:
+		return entry.Debit(ledger.Cash, tr.Amount).Credit(customer, tr.Amount)
+	case transaction.TypeOverdraftInterest:
+		return entry.Debit(customer, tr.Amount).Credit(ledger.Interest, tr.Amount)
+	case transaction.TypeOverdraftFee:
+		return entry.Debit(customer, tr.Amount).Credit(ledger.Fees, tr.Amount)
+	default:
+		return entry.Debit(customer, tr.Amount).Credit(ledger.Cash, tr.Amount)
+	}
+}
+
+// reversalEntry construye el asiento contable de la reversión de una transacción: el asiento de la
+// original con los débitos y créditos invertidos, referenciado a la transacción de reversión.
+func reversalEntry(original, reversal *transaction.Transaction) *ledger.JournalEntry {
+	entry := ledger.NewEntry(fmt.Sprintf("transaction:%d", reversal.ID), fmt.Sprintf("Reversión de la transacción %d", original.ID))
+	for _, p := range transactionEntry(original).Postings {
+		entry.Postings = append(entry.Postings, ledger.Posting{Account: p.Account, Amount: p.Amount.Neg()})
+	}
+	return entry
+}
+
+// transferEntry construye el asiento contable de una transferencia entre dos cuentas de clientes.
+func transferEntry(transferID string, fromAccountID, toAccountID int, amount money.Money) *ledger.JournalEntry {
+	return ledger.NewEntry("transfer:"+transferID, "Transferencia entre cuentas").
+		Debit(ledger.CustomerAccount(fromAccountID), amount).
+		Credit(ledger.CustomerAccount(toAccountID), amount)
+}
+
+// exchangeEntries construye los asientos contables de una transferencia entre monedas: uno en la moneda
+// de origen, que debita la cuenta de origen y acredita la posición de cambio, y otro en la moneda de destino,
+// que debita la posición de cambio y acredita la cuenta de destino. Cada asiento está balanceado en su moneda.
+func exchangeEntries(transferID string, fromAccountID, toAccountID int, debited, credited money.Money) []*ledger.JournalEntry {
+	reference := "transfer:" + transferID
+	return []*ledger.JournalEntry{
+		ledger.NewEntry(reference, "Transferencia entre monedas: venta de "+debited.Currency()).
+			Debit(ledger.CustomerAccount(fromAccountID), debited).
+			Credit(ledger.FX, debited),
+		ledger.NewEntry(reference, "Transferencia entre monedas: compra de "+credited.Currency()).
+			Debit(ledger.FX, credited).
+			Credit(ledger.CustomerAccount(toAccountID), credited),
+	}
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/account" // Importación del dominio de cuentas
+	"context"                                    // Contexto de la solicitud
+	"errors"                                     // Paquete para inspeccionar errores
+	"fmt"                                        // Paquete para formatear errores
+	"log/slog"                                   // Logging estructurado de los reintentos
+	"math/rand/v2"                               // Aleatoriedad para repartir los reintentos
+	"time"                                       // Paquete para manejar duraciones
+)
+
+// RetryPolicy define cuántas veces se reintenta una unidad de trabajo que falló por un conflicto
+// de concurrencia optimista y cuánto se espera entre intentos.
+// La espera crece exponencialmente desde BaseDelay hasta MaxDelay, con una variación aleatoria
+// para que las solicitudes que chocaron no vuelvan a coincidir.
+type RetryPolicy struct {
+	MaxAttempts int           // Cantidad máxima de intentos, incluido el primero
+	BaseDelay   time.Duration // Espera antes del primer reintento
+	MaxDelay    time.Duration // Espera máxima entre intentos
+}
+
+// DefaultRetryPolicy es la política de reintentos usada si no se indica otra.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 5, BaseDelay: 5 * time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+// ConflictError indica que una operación no pudo completarse porque la cuenta fue modificada
+// por otras operaciones en cada uno de los intentos.
+type ConflictError struct {
+	Attempts int   // Cantidad de intentos realizados
+	Err      error // Último error de conflicto recibido
+}
+
+// Error implementa la interfaz error.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflicto de concurrencia tras %d intentos: %v", e.Attempts, e.Err)
+}
+
+// Unwrap permite usar errors.Is(err, account.ErrVersionConflict) sobre el error.
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// executeWithRetry ejecuta fn en la unidad de trabajo y la repite mientras falle por un conflicto de versión.
+// Cada intento es una unidad de trabajo nueva, por lo que vuelve a leer las cuentas ya actualizadas.
+// Si se agotan los intentos devuelve un *ConflictError; cualquier otro error se devuelve sin reintentar.
+// Si ctx se cancela durante la espera entre intentos, se devuelve el error del contexto.
+func executeWithRetry(ctx context.Context, uow UnitOfWork, policy RetryPolicy, fn func(repos Repositories) error) error {
+	attempts := max(policy.MaxAttempts, 1)
+	delay := policy.BaseDelay
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = uow.Execute(ctx, fn)
+		if !errors.Is(err, account.ErrVersionConflict) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		// Esperar entre la mitad y el total del retardo actual antes de reintentar
+		wait := time.Duration(0)
+		if delay > 0 {
+			wait = delay/2 + rand.N(delay/2+1)
+			if delay *= 2; policy.MaxDelay > 0 && delay > policy.MaxDelay {
+				delay = policy.MaxDelay
+			}
+		}
+		slog.DebugContext(ctx, "conflicto de concurrencia, reintentando",
+			"attempt", attempt, "max_attempts", attempts, "wait", wait, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	slog.WarnContext(ctx, "conflicto de concurrencia tras agotar los reintentos", "attempts", attempts, "error", err)
+	return &ConflictError{Attempts: attempts, Err: err}
+}
@@ -2,59 +2,436 @@ package application
 
 import (
 	"Transaction-System/internal/domain/account"     // Importación del dominio de cuentas
+	"Transaction-System/internal/domain/fx"          // Cotizaciones de cambio de divisas
+	"Transaction-System/internal/domain/money"       // Tipo Money para montos exactos
 	"Transaction-System/internal/domain/transaction" // Importación del dominio de transacciones
+	"context"                                        // Contexto de la solicitud
+	"errors"                                         // Detección de transacciones ya revertidas y cotizaciones ya usadas
 	"fmt"                                            // Paquete para formatear errores
+	"log/slog"                                       // Logging estructurado de los movimientos
+	"time"                                           // Vigencia de las cotizaciones
 )
 
 // TransactionService es el servicio encargado de procesar transacciones
-// como depósitos y retiros. Este servicio utiliza repositorios para interactuar con
-// la capa de persistencia (base de datos).
+// como depósitos y retiros. Este servicio utiliza una unidad de trabajo para que la
+// actualización del balance y el registro de la transacción se confirmen de forma atómica.
+// Si otra operación modifica la misma cuenta al mismo tiempo, la unidad de trabajo se reintenta
+// según su política de reintentos.
 type TransactionService struct {
-	accountRepo     account.Repository     // Repositorio de cuentas, utilizado para acceder a las cuentas
-	transactionRepo transaction.Repository // Repositorio de transacciones, utilizado para guardar transacciones
+	uow       UnitOfWork          // Unidad de trabajo que provee los repositorios transaccionales
+	quotes    fx.QuoteRepository  // Cotizaciones de cambio; nil si no se admiten transferencias entre monedas
+	retry     RetryPolicy         // Política de reintentos ante conflictos de concurrencia
+	validator *Validator          // Validador de montos y solicitudes
+	observer  TransactionObserver // Recibe el resultado de cada movimiento
 }
 
 // NewTransactionService crea una instancia del servicio de transacciones
-// Recibe dos repositorios: uno para cuentas y otro para transacciones.
-func NewTransactionService(aRepo account.Repository, tRepo transaction.Repository) *TransactionService {
-	return &TransactionService{
-		accountRepo:     aRepo,
-		transactionRepo: tRepo,
+// Recibe la unidad de trabajo con la que se accede a cuentas y transacciones,
+// y opciones adicionales (por ejemplo, WithRetryPolicy o WithValidator).
+func NewTransactionService(uow UnitOfWork, opts ...Option) *TransactionService {
+	s := &TransactionService{
+		uow:       uow,
+		retry:     DefaultRetryPolicy,
+		validator: NewValidator(nil),
+		observer:  noopObserver{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Validator devuelve el validador del servicio, para validar las solicitudes antes de procesarlas.
+func (s *TransactionService) Validator() *Validator {
+	return s.validator
 }
 
 // ProcessTransaction procesa una transacción de depósito o retiro para una cuenta dada
 // Parametros:
+//   - ctx: contexto de la solicitud; su identificador se incluye en los logs del movimiento
 //   - accountID: ID de la cuenta a la que se aplicará la transacción
 //   - amount: Monto de la transacción
 //   - transactionType: Tipo de transacción ("deposit" o "withdrawal")
 //
-// Devuelve un error si la transacción no puede ser procesada.
-func (s *TransactionService) ProcessTransaction(accountID int, amount float64, transactionType string) error {
-	// Obtener la cuenta por su ID
-	acc, err := s.accountRepo.FindByID(accountID)
+// El nuevo balance de la cuenta, la transacción y su asiento contable se guardan dentro de la misma
+// unidad de trabajo, por lo que todos los cambios se confirman o se revierten juntos.
+// Devuelve un error si la transacción no puede ser procesada, o un *ConflictError si la cuenta
+// siguió siendo modificada por otras operaciones después de agotar los reintentos.
+// Un monto no positivo o que supera el máximo de su moneda devuelve un *ValidationError, y un monto
+// en una moneda distinta a la de la cuenta devuelve un *account.CurrencyMismatchError.
+func (s *TransactionService) ProcessTransaction(ctx context.Context, accountID int, amount money.Money, transactionType string) (err error) {
+	defer func() { s.observer.TransactionProcessed(transactionType, amount, err) }()
+
+	if err := s.validator.CheckAmount("amount", amount); err != nil {
+		return err
+	}
+	var tr *transaction.Transaction
+	err = executeWithRetry(ctx, s.uow, s.retry, func(repos Repositories) error {
+		// Obtener la cuenta por su ID
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, accountID)
+		if err != nil {
+			// Si la cuenta no se encuentra, devolver un error
+			return err
+		}
+
+		// Registrar en el libro mayor el saldo inicial de la cuenta si aún no tiene movimientos
+		if err := ensureOpeningBalance(ctx, repos, acc); err != nil {
+			return err
+		}
+
+		// Aplicar el movimiento, persistir el balance y registrar la transacción y su asiento
+		tr, err = applyTransaction(ctx, repos, acc, amount, transactionType)
+		return err
+	})
 	if err != nil {
-		// Si la cuenta no se encuentra, devolver un error
 		return err
 	}
+	slog.InfoContext(ctx, "transacción procesada",
+		"transaction_id", tr.ID, "account_id", accountID, "type", transactionType, "amount", amount.String())
+	return nil
+}
 
+// applyTransaction aplica un depósito o retiro sobre una cuenta ya leída dentro de la unidad de trabajo:
+// modifica el balance, lo persiste, guarda la transacción y registra su asiento contable.
+// Devuelve la transacción guardada.
+func applyTransaction(ctx context.Context, repos Repositories, acc *account.Account, amount money.Money, transactionType string) (*transaction.Transaction, error) {
 	// Procesar la transacción dependiendo del tipo (depósito o retiro)
 	switch transactionType {
-	case "deposit":
+	case transaction.TypeDeposit:
 		// Si es un depósito, aumentar el balance de la cuenta
-		acc.Deposit(amount)
-	case "withdrawal":
+		if err := acc.Deposit(amount); err != nil {
+			return nil, err
+		}
+	case transaction.TypeWithdrawal:
 		// Si es un retiro, intentar disminuir el balance de la cuenta
 		// Si los fondos son insuficientes, devolver un error
 		if err := acc.Withdraw(amount); err != nil {
-			return err
+			return nil, err
 		}
 	default:
 		// Si el tipo de transacción no es válido, devolver un error
-		return fmt.Errorf("tipo de transacción no válido")
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTransactionType, transactionType)
+	}
+
+	// Persistir el nuevo balance de la cuenta
+	if err := repos.Accounts.Update(ctx, acc); err != nil {
+		return nil, err
 	}
 
 	// Crear una nueva transacción y guardarla en la base de datos
-	tr := transaction.New(accountID, amount, transactionType)
-	return s.transactionRepo.Save(tr)
+	tr := transaction.New(acc.ID, amount, transactionType)
+	if err := repos.Transactions.Save(ctx, tr); err != nil {
+		return nil, err
+	}
+
+	// Registrar el asiento contable de la transacción
+	if err := repos.Ledger.Append(ctx, transactionEntry(tr)); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// Reverse revierte una transacción ya guardada con una transacción "reversal" enlazada a la original,
+// que mueve el mismo monto en sentido contrario: revertir un depósito debita la cuenta y revertir un
+// retiro, una captura o un cargo de intereses la acredita.
+// Parametros:
+//   - ctx: contexto de la solicitud; su identificador se incluye en los logs de la reversión
+//   - transactionID: ID de la transacción a revertir
+//
+// El nuevo balance, la reversión y su asiento contable (el de la original con los débitos y créditos
+// invertidos) se guardan dentro de la misma unidad de trabajo. Una transacción sólo puede revertirse una vez:
+// como la reversión actualiza la cuenta bajo su control de versión, dos reversiones simultáneas no pueden
+// confirmarse ambas. Revertir un depósito aplica las mismas reglas que un retiro: si el disponible no alcanza
+// devuelve account.ErrInsufficientFunds o account.ErrOverdraftLimitExceeded.
+// Devuelve transaction.ErrNotFound si la transacción no existe, transaction.ErrAlreadyReversed si ya fue
+// revertida y transaction.ErrNotReversible si su tipo no admite reversión (las patas de una transferencia
+// y las propias reversiones).
+func (s *TransactionService) Reverse(ctx context.Context, transactionID int) (reversal *transaction.Transaction, err error) {
+	defer func() {
+		var amount money.Money
+		if reversal != nil {
+			amount = reversal.Amount
+		}
+		s.observer.TransactionProcessed(transaction.TypeReversal, amount, err)
+	}()
+
+	var original *transaction.Transaction
+	err = executeWithRetry(ctx, s.uow, s.retry, func(repos Repositories) error {
+		var err error
+		if original, err = repos.Transactions.FindByID(ctx, transactionID); err != nil {
+			return err
+		}
+		if err := original.Reversible(); err != nil {
+			return err
+		}
+
+		// Una transacción se revierte una sola vez
+		existing, err := repos.Transactions.FindReversal(ctx, original.ID)
+		switch {
+		case err == nil:
+			return fmt.Errorf("%w: transacción %d revertida por la transacción %d", transaction.ErrAlreadyReversed, original.ID, existing.ID)
+		case !errors.Is(err, transaction.ErrNotFound):
+			return err
+		}
+
+		acc, err := repos.Accounts.FindByIDForUpdate(ctx, original.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// Registrar en el libro mayor el saldo inicial de la cuenta si aún no tiene movimientos
+		if err := ensureOpeningBalance(ctx, repos, acc); err != nil {
+			return err
+		}
+
+		// Mover el monto en sentido contrario al de la original
+		if original.CreditsAccount() {
+			err = acc.Withdraw(original.Amount)
+		} else {
+			err = acc.Deposit(original.Amount)
+		}
+		if err != nil {
+			return err
+		}
+		if err := repos.Accounts.Update(ctx, acc); err != nil {
+			return err
+		}
+
+		tr, err := transaction.NewReversal(original)
+		if err != nil {
+			return err
+		}
+		if err := repos.Transactions.Save(ctx, tr); err != nil {
+			return err
+		}
+		if err := repos.Ledger.Append(ctx, reversalEntry(original, tr)); err != nil {
+			return err
+		}
+		reversal = tr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "transacción revertida",
+		"transaction_id", reversal.ID, "reversal_of", original.ID, "account_id", original.AccountID,
+		"type", original.TransactionType, "amount", reversal.Amount.String())
+	return reversal, nil
+}
+
+// Transfer transfiere fondos de una cuenta a otra de forma atómica
+// Parametros:
+//   - ctx: contexto de la solicitud; su identificador se incluye en los logs de la transferencia
+//   - fromAccountID: ID de la cuenta de origen, a la que se debita el monto
+//   - toAccountID: ID de la cuenta de destino, a la que se acredita el monto
+//   - amount: Monto de la transferencia
+//
+// El débito, el crédito y las dos transacciones (una por cada pata) se guardan dentro de la misma
+// unidad de trabajo. Ambas transacciones comparten el mismo identificador de transferencia.
+// Las cuentas se actualizan siempre en orden ascendente de ID: cada actualización bloquea la fila hasta
+// confirmar, y el orden fijo evita interbloqueos cuando dos transferencias opuestas entre las mismas
+// cuentas se ejecutan al mismo tiempo.
+// Ambas cuentas deben operar en la moneda del monto; si alguna no lo hace se devuelve un
+// *account.CurrencyMismatchError y no se mueve ningún fondo. Las transferencias entre monedas
+// se realizan con TransferWithQuote.
+// Devuelve el identificador de la transferencia, o un error si no puede ser procesada
+// (un *ConflictError si se agotan los reintentos por modificaciones concurrentes).
+func (s *TransactionService) Transfer(ctx context.Context, fromAccountID, toAccountID int, amount money.Money) (string, error) {
+	return s.TransferWithQuote(ctx, fromAccountID, toAccountID, amount, "")
+}
+
+// TransferWithQuote transfiere fondos entre dos cuentas liquidando el cambio de divisas con una cotización.
+// Parametros:
+//   - ctx: contexto de la solicitud
+//   - fromAccountID: ID de la cuenta de origen, que debe operar en la moneda del monto
+//   - toAccountID: ID de la cuenta de destino, que debe operar en la moneda de destino de la cotización
+//   - amount: Monto que se debita; debe coincidir con el monto cotizado
+//   - quoteID: cotización emitida por FXService.Quote; vacío para una transferencia en una sola moneda
+//
+// Con una cotización, la cuenta de destino recibe el monto convertido de la cotización y ambas patas
+// registran la cotización, el tipo de cambio aplicado y el monto de la otra pata. El libro mayor recibe
+// un asiento por moneda contra la posición de cambio del banco (ledger.FX).
+// Cada cotización liquida una sola transferencia.
+// Retorna fx.ErrQuoteNotFound si la cotización no existe, fx.ErrQuoteExpired si venció,
+// fx.ErrQuoteUsed si ya liquidó otra transferencia y fx.ErrQuoteMismatch si el monto o las monedas
+// de las cuentas no coinciden con ella.
+func (s *TransactionService) TransferWithQuote(ctx context.Context, fromAccountID, toAccountID int, amount money.Money, quoteID string) (_ string, err error) {
+	defer func() { s.observer.TransactionProcessed(TypeTransfer, amount, err) }()
+
+	// Validar la solicitud antes de abrir la unidad de trabajo
+	if fromAccountID == toAccountID {
+		return "", ErrSameAccount
+	}
+	if err := s.validator.CheckAmount("amount", amount); err != nil {
+		return "", err
+	}
+
+	// Obtener la cotización; su vigencia se verifica al liquidar la transferencia
+	var quote *fx.Quote
+	if quoteID != "" {
+		if s.quotes == nil {
+			return "", fmt.Errorf("%w: el servicio no admite transferencias entre monedas", fx.ErrQuoteNotFound)
+		}
+		if quote, err = s.quotes.FindByID(ctx, quoteID); err != nil {
+			return "", err
+		}
+	}
+
+	// Generar el identificador que enlaza las dos patas de la transferencia
+	transferID, err := transaction.NewTransferID()
+	if err != nil {
+		return "", err
+	}
+
+	err = executeWithRetry(ctx, s.uow, s.retry, func(repos Repositories) error {
+		// Leer las cuentas en orden determinista: primero el ID menor
+		lockOrder := []int{fromAccountID, toAccountID}
+		if fromAccountID > toAccountID {
+			lockOrder = []int{toAccountID, fromAccountID}
+		}
+		accounts := make(map[int]*account.Account, len(lockOrder))
+		for _, id := range lockOrder {
+			acc, err := repos.Accounts.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			// Registrar en el libro mayor el saldo inicial de la cuenta si aún no tiene movimientos
+			if err := ensureOpeningBalance(ctx, repos, acc); err != nil {
+				return err
+			}
+			accounts[id] = acc
+		}
+
+		// El monto acreditado es el mismo salvo que la cotización lo convierta a otra moneda
+		credited := amount
+		if quote != nil {
+			if err := quote.Check(amount, accounts[toAccountID].Currency(), time.Now()); err != nil {
+				return err
+			}
+			// La cotización se consume dentro de la misma unidad de trabajo que la transferencia
+			settled, err := repos.Transactions.FindByQuote(ctx, quote.ID)
+			if err == nil {
+				return fmt.Errorf("%w: la cotización %s liquidó la transferencia %s", fx.ErrQuoteUsed, quote.ID, settled.TransferID)
+			}
+			if !errors.Is(err, transaction.ErrNotFound) {
+				return err
+			}
+			credited = quote.Target
+		}
+
+		// Debitar la cuenta de origen; si los fondos son insuficientes, devolver un error
+		if err := accounts[fromAccountID].Withdraw(amount); err != nil {
+			return err
+		}
+		// Acreditar la cuenta de destino
+		if err := accounts[toAccountID].Deposit(credited); err != nil {
+			return err
+		}
+
+		// Persistir los nuevos balances respetando el mismo orden
+		for _, id := range lockOrder {
+			if err := repos.Accounts.Update(ctx, accounts[id]); err != nil {
+				return err
+			}
+		}
+
+		// Registrar las dos patas de la transferencia enlazadas por el mismo identificador
+		debit := transaction.New(fromAccountID, amount, transaction.TypeTransferOut)
+		debit.TransferID = transferID
+		credit := transaction.New(toAccountID, credited, transaction.TypeTransferIn)
+		credit.TransferID = transferID
+		if quote != nil {
+			// Cada pata registra la cotización, el tipo aplicado y el monto de la otra pata
+			debit.Conversion = &transaction.Conversion{QuoteID: quote.ID, Rate: quote.Rate, CounterAmount: credited}
+			credit.Conversion = &transaction.Conversion{QuoteID: quote.ID, Rate: quote.Rate, CounterAmount: amount}
+		}
+		if err := repos.Transactions.Save(ctx, debit); err != nil {
+			return err
+		}
+		if err := repos.Transactions.Save(ctx, credit); err != nil {
+			return err
+		}
+
+		// Registrar el asiento contable: uno que debita la cuenta de origen y acredita la de destino o,
+		// entre monedas, uno por moneda contra la posición de cambio
+		if quote == nil {
+			return repos.Ledger.Append(ctx, transferEntry(transferID, fromAccountID, toAccountID, amount))
+		}
+		for _, entry := range exchangeEntries(transferID, fromAccountID, toAccountID, amount, credited) {
+			if err := repos.Ledger.Append(ctx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if quote != nil {
+		slog.InfoContext(ctx, "transferencia entre monedas procesada", "transfer_id", transferID, "quote_id", quote.ID,
+			"from_account_id", fromAccountID, "to_account_id", toAccountID, "amount", amount.String(),
+			"credited", quote.Target.String(), "rate", fx.FormatRate(quote.Rate))
+		return transferID, nil
+	}
+	slog.InfoContext(ctx, "transferencia procesada", "transfer_id", transferID,
+		"from_account_id", fromAccountID, "to_account_id", toAccountID, "amount", amount.String())
+	return transferID, nil
+}
+
+// TransactionPage es una página del historial de transacciones de una cuenta.
+type TransactionPage struct {
+	Transactions []*transaction.Transaction // Transacciones de la página, de la más reciente a la más antigua
+	NextCursor   *transaction.Cursor        // Cursor para pedir la página siguiente; nil si no hay más
+	Limit        int                        // Límite efectivo aplicado
+}
+
+// History devuelve una página del historial de transacciones de una cuenta
+// Parametros:
+//   - ctx: contexto de la solicitud
+//   - accountID: ID de la cuenta cuyo historial se consulta
+//   - filter: criterios de búsqueda; filter.After indica desde dónde continuar
+//
+// Si el límite no es válido se usa DefaultListLimit; nunca se devuelven más de MaxListLimit transacciones.
+// Los montos mínimo y máximo del filtro deben estar en la moneda de la cuenta (*account.CurrencyMismatchError).
+// Devuelve un error si la cuenta no existe o si la consulta falla.
+func (s *TransactionService) History(ctx context.Context, accountID int, filter transaction.Filter) (*TransactionPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+
+	page := &TransactionPage{Limit: filter.Limit}
+	err := s.uow.Execute(ctx, func(repos Repositories) error {
+		// Verificar que la cuenta exista y que el rango de montos esté en su moneda
+		acc, err := repos.Accounts.FindByID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		for _, bound := range []*money.Money{filter.MinAmount, filter.MaxAmount} {
+			if bound != nil && bound.Currency() != acc.Currency() {
+				return &account.CurrencyMismatchError{AccountID: acc.ID, AccountCurrency: acc.Currency(), Currency: bound.Currency()}
+			}
+		}
+
+		// Pedir un elemento más que el límite para saber si hay una página siguiente
+		query := filter
+		query.Limit++
+		found, err := repos.Transactions.FindByAccount(ctx, accountID, query)
+		if err != nil {
+			return err
+		}
+		if len(found) > filter.Limit {
+			found = found[:filter.Limit]
+			next := transaction.CursorOf(found[len(found)-1])
+			page.NextCursor = &next
+		}
+		page.Transactions = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
 }
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/account"     // Importación del dominio de cuentas
+	"Transaction-System/internal/domain/hold"        // Importación de las retenciones de fondos
+	"Transaction-System/internal/domain/ledger"      // Importación del libro mayor
+	"Transaction-System/internal/domain/transaction" // Importación del dominio de transacciones
+	"context"                                        // Contexto de la solicitud que origina la unidad de trabajo
+)
+
+// Repositories agrupa los repositorios que participan en una misma unidad de trabajo.
+// Todas las operaciones realizadas a través de estos repositorios se confirman o se
+// revierten juntas.
+type Repositories struct {
+	Accounts     account.Repository     // Repositorio de cuentas ligado a la unidad de trabajo
+	Transactions transaction.Repository // Repositorio de transacciones ligado a la unidad de trabajo
+	Ledger       ledger.Repository      // Libro mayor de partida doble ligado a la unidad de trabajo
+	Holds        hold.Repository        // Repositorio de retenciones de fondos ligado a la unidad de trabajo
+}
+
+// UnitOfWork define una unidad de trabajo atómica sobre la capa de persistencia.
+// Execute ejecuta fn con repositorios ligados a una misma transacción: si fn devuelve un
+// error, todos los cambios se revierten; en caso contrario, se confirman en bloque.
+// ctx es el contexto de la solicitud: cancela la transacción si la solicitud se cancela y
+// transporta los datos de correlación (como el identificador de la solicitud) para los logs.
+type UnitOfWork interface {
+	Execute(ctx context.Context, fn func(repos Repositories) error) error
+}
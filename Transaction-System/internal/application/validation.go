@@ -0,0 +1,369 @@
+package application
+
+import (
+	"Transaction-System/internal/domain/fx"    // Pares de monedas y tipos de cambio
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"errors"                                   // Paquete para inspeccionar errores
+	"fmt"                                      // Paquete para formatear mensajes
+	"strings"                                  // Paquete para construir el mensaje de error
+	"time"                                     // Fecha de los tipos de cambio fijados y vigencia de las retenciones
+)
+
+// Códigos de los errores de validación por campo.
+const (
+	FieldRequired     = "required"      // El campo es obligatorio y no se indicó
+	FieldInvalid      = "invalid"       // El valor no tiene un formato válido
+	FieldInvalidType  = "invalid_type"  // El valor JSON no es del tipo esperado
+	FieldNotPositive  = "not_positive"  // El valor debe ser mayor que cero
+	FieldNegative     = "negative"      // El valor no puede ser negativo
+	FieldPrecision    = "precision"     // El monto tiene más decimales de los que admite la moneda
+	FieldTooLarge     = "too_large"     // El monto supera el máximo permitido para la moneda
+	FieldUnknown      = "unknown_field" // La solicitud incluye un campo que no existe
+	FieldSameAsSource = "same_account"  // La cuenta de destino es la misma que la de origen
+	FieldSameCurrency = "same_currency" // La moneda de destino es la misma que la de origen
+)
+
+// FieldError describe un error de validación de un campo de la solicitud.
+type FieldError struct {
+	Field   string `json:"field"`   // Nombre del campo tal como aparece en la solicitud
+	Code    string `json:"code"`    // Código estable del error (una de las constantes Field*)
+	Message string `json:"message"` // Descripción legible del error
+}
+
+// ValidationError agrupa los errores de validación de una solicitud.
+// Se obtiene con errors.As para informar cada campo por separado.
+type ValidationError struct {
+	Errors []FieldError // Errores encontrados, en el orden de los campos
+}
+
+// Error implementa la interfaz error.
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, f := range e.Errors {
+		messages = append(messages, f.Field+": "+f.Message)
+	}
+	return "solicitud inválida: " + strings.Join(messages, "; ")
+}
+
+// Add registra un error de validación sobre un campo.
+func (e *ValidationError) Add(field, code, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err devuelve el error de validación si se registró algún error, o nil en caso contrario.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// DefaultMaxAmounts contiene el monto máximo de un movimiento para cada moneda.
+// Las monedas sin entrada no tienen un máximo propio.
+var DefaultMaxAmounts = map[string]money.Money{
+	"USD": money.MustParse("1000000", "USD"),
+	"EUR": money.MustParse("1000000", "EUR"),
+	"GBP": money.MustParse("1000000", "GBP"),
+	"COP": money.MustParse("4000000000", "COP"),
+	"MXN": money.MustParse("20000000", "MXN"),
+	"BRL": money.MustParse("5000000", "BRL"),
+	"CLP": money.MustParse("1000000000", "CLP"),
+	"JPY": money.MustParse("150000000", "JPY"),
+	"KWD": money.MustParse("300000", "KWD"),
+	"BHD": money.MustParse("375000", "BHD"),
+}
+
+// TransactionCommand son los datos de una solicitud de depósito o retiro tal como llegan del cliente.
+// El monto se recibe como texto para validarlo sin perder precisión.
+type TransactionCommand struct {
+	AccountID int    // ID de la cuenta; cero si no se indicó
+	Amount    string // Monto decimal; vacío si no se indicó
+	Currency  string // Moneda del monto; obligatoria y debe coincidir con la de la cuenta
+}
+
+// TransferCommand son los datos de una solicitud de transferencia tal como llegan del cliente.
+type TransferCommand struct {
+	FromAccountID int    // ID de la cuenta de origen; cero si no se indicó
+	ToAccountID   int    // ID de la cuenta de destino; cero si no se indicó
+	Amount        string // Monto decimal; vacío si no se indicó
+	Currency      string // Moneda del monto; obligatoria y debe coincidir con la de la cuenta de origen
+	QuoteID       string // Cotización de cambio; obligatoria si la cuenta de destino opera en otra moneda
+}
+
+// OverdraftCommand son los datos de una solicitud para fijar el sobregiro autorizado de una cuenta.
+type OverdraftCommand struct {
+	Limit    string // Sobregiro autorizado, como decimal; cero lo deshabilita
+	Currency string // Moneda del límite; obligatoria y debe coincidir con la de la cuenta
+}
+
+// HoldCommand son los datos de una solicitud de autorización (retención de fondos) tal como llegan del cliente.
+type HoldCommand struct {
+	AccountID int    // ID de la cuenta; cero si no se indicó
+	Amount    string // Monto decimal a retener; vacío si no se indicó
+	Currency  string // Moneda del monto; obligatoria y debe coincidir con la de la cuenta
+	Reference string // Referencia del comercio u origen de la autorización; opcional
+	TTL       string // Vigencia de la retención como duración de Go ("72h"); vacía para la vigencia por defecto
+}
+
+// CaptureCommand son los datos de una solicitud de captura de una retención.
+type CaptureCommand struct {
+	Amount   string // Monto decimal a capturar; vacío para capturar todo el monto retenido
+	Currency string // Moneda del monto; obligatoria si se indica el monto
+}
+
+// QuoteCommand son los datos de una solicitud de cotización de cambio tal como llegan del cliente.
+type QuoteCommand struct {
+	Amount         string // Monto decimal a convertir; vacío si no se indicó
+	Currency       string // Moneda del monto a convertir; obligatoria
+	TargetCurrency string // Moneda a la que se convierte; obligatoria y distinta de Currency
+}
+
+// RateCommand son los datos de una solicitud para fijar manualmente un tipo de cambio.
+type RateCommand struct {
+	Base  string // Moneda base del par; obligatoria
+	Quote string // Moneda cotizada del par; obligatoria y distinta de Base
+	Rate  string // Unidades de Quote por unidad de Base, como decimal; obligatorio
+}
+
+// Validator valida las solicitudes antes de que lleguen a los servicios.
+// Reúne todos los errores de una solicitud en un único *ValidationError.
+type Validator struct {
+	maxAmounts map[string]money.Money // Monto máximo por moneda
+}
+
+// NewValidator crea un validador con los montos máximos por moneda indicados.
+// Si maxAmounts es nil se usa DefaultMaxAmounts.
+func NewValidator(maxAmounts map[string]money.Money) *Validator {
+	if maxAmounts == nil {
+		maxAmounts = DefaultMaxAmounts
+	}
+	return &Validator{maxAmounts: maxAmounts}
+}
+
+// ValidateTransaction valida una solicitud de depósito o retiro y devuelve el monto interpretado
+// con los decimales de su moneda. Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateTransaction(cmd TransactionCommand) (money.Money, error) {
+	verr := &ValidationError{}
+	v.accountID(verr, "account_id", cmd.AccountID)
+	amount := v.movementAmount(verr, "amount", cmd.Amount, cmd.Currency)
+	return amount, verr.Err()
+}
+
+// ValidateTransfer valida una solicitud de transferencia y devuelve el monto interpretado
+// con los decimales de su moneda. Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateTransfer(cmd TransferCommand) (money.Money, error) {
+	verr := &ValidationError{}
+	v.accountID(verr, "from_account_id", cmd.FromAccountID)
+	v.accountID(verr, "to_account_id", cmd.ToAccountID)
+	if cmd.FromAccountID > 0 && cmd.FromAccountID == cmd.ToAccountID {
+		verr.Add("to_account_id", FieldSameAsSource, ErrSameAccount.Error())
+	}
+	amount := v.movementAmount(verr, "amount", cmd.Amount, cmd.Currency)
+	return amount, verr.Err()
+}
+
+// ValidateQuote valida una solicitud de cotización y devuelve el monto a convertir y el par de monedas.
+// Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateQuote(cmd QuoteCommand) (money.Money, fx.Pair, error) {
+	verr := &ValidationError{}
+	amount := v.movementAmount(verr, "amount", cmd.Amount, cmd.Currency)
+	if cmd.TargetCurrency == "" {
+		verr.Add("target_currency", FieldRequired, "el campo es obligatorio")
+		return amount, fx.Pair{}, verr.Err()
+	}
+	if len(verr.Errors) > 0 {
+		return amount, fx.Pair{}, verr
+	}
+	pair := v.pair(verr, "currency", "target_currency", amount.Currency(), cmd.TargetCurrency)
+	return amount, pair, verr.Err()
+}
+
+// ValidateRate valida una solicitud para fijar un tipo de cambio y devuelve el tipo fechado en now.
+// Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateRate(cmd RateCommand, now time.Time) (fx.Rate, error) {
+	verr := &ValidationError{}
+	for _, f := range []struct{ field, value string }{{"base", cmd.Base}, {"quote", cmd.Quote}, {"rate", cmd.Rate}} {
+		if f.value == "" {
+			verr.Add(f.field, FieldRequired, "el campo es obligatorio")
+		}
+	}
+	if len(verr.Errors) > 0 {
+		return fx.Rate{}, verr
+	}
+
+	pair := v.pair(verr, "base", "quote", cmd.Base, cmd.Quote)
+	rate, err := fx.NewRate(pair, cmd.Rate, fx.SourceManual, now)
+	if err != nil {
+		verr.Add("rate", FieldInvalid, fmt.Sprintf("el tipo de cambio debe ser un decimal positivo con a lo sumo %d decimales", fx.RateDecimals))
+	}
+	return rate, verr.Err()
+}
+
+// ValidateInitialDeposit valida el depósito inicial de una cuenta nueva; puede omitirse o ser cero.
+// La moneda es la de la cuenta que se abre; vacía para DefaultCurrency.
+// Retorna un *ValidationError si el monto o la moneda no son válidos.
+func (v *Validator) ValidateInitialDeposit(raw, currency string) (money.Money, error) {
+	verr := &ValidationError{}
+	if raw == "" {
+		// Sin depósito la cuenta se abre con balance cero, pero la moneda debe estar soportada
+		zero, err := money.New(0, currency)
+		if err != nil {
+			verr.Add("currency", FieldInvalid, "moneda no soportada")
+		}
+		return zero, verr.Err()
+	}
+	amount := v.amount(verr, "initial_deposit", raw, currency, false)
+	return amount, verr.Err()
+}
+
+// ValidateOverdraft valida una solicitud de sobregiro y devuelve el límite interpretado con los decimales
+// de su moneda. El límite puede ser cero, pero no negativo ni superior al máximo por operación de la moneda.
+// Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateOverdraft(cmd OverdraftCommand) (money.Money, error) {
+	verr := &ValidationError{}
+	if cmd.Currency == "" {
+		if cmd.Limit == "" {
+			verr.Add("limit", FieldRequired, "el campo es obligatorio")
+		}
+		verr.Add("currency", FieldRequired, "el campo es obligatorio")
+		return money.Money{}, verr
+	}
+	limit := v.amount(verr, "limit", cmd.Limit, cmd.Currency, false)
+	return limit, verr.Err()
+}
+
+// MaxHoldReference es la longitud máxima de la referencia de una retención.
+const MaxHoldReference = 64
+
+// ValidateHold valida una solicitud de autorización y devuelve el monto a retener y su vigencia;
+// una vigencia cero indica que se use la vigencia por defecto. La vigencia no puede superar maxTTL.
+// Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateHold(cmd HoldCommand, maxTTL time.Duration) (money.Money, time.Duration, error) {
+	verr := &ValidationError{}
+	v.accountID(verr, "account_id", cmd.AccountID)
+	amount := v.movementAmount(verr, "amount", cmd.Amount, cmd.Currency)
+	if len(cmd.Reference) > MaxHoldReference {
+		verr.Add("reference", FieldInvalid, fmt.Sprintf("la referencia admite como máximo %d caracteres", MaxHoldReference))
+	}
+
+	var ttl time.Duration
+	if cmd.TTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(cmd.TTL)
+		switch {
+		case err != nil:
+			verr.Add("ttl", FieldInvalid, "la vigencia debe ser una duración como 30m o 72h")
+		case ttl <= 0:
+			verr.Add("ttl", FieldNotPositive, "la vigencia debe ser mayor que cero")
+		case maxTTL > 0 && ttl > maxTTL:
+			verr.Add("ttl", FieldTooLarge, fmt.Sprintf("la vigencia máxima es %s", maxTTL))
+		}
+	}
+	return amount, ttl, verr.Err()
+}
+
+// ValidateCapture valida una solicitud de captura y devuelve el monto a capturar.
+// Si no se indica el monto devuelve el Money cero sin moneda, que indica capturar todo el monto retenido.
+// Retorna un *ValidationError con los errores de cada campo.
+func (v *Validator) ValidateCapture(cmd CaptureCommand) (money.Money, error) {
+	if cmd.Amount == "" && cmd.Currency == "" {
+		return money.Money{}, nil
+	}
+	verr := &ValidationError{}
+	amount := v.movementAmount(verr, "amount", cmd.Amount, cmd.Currency)
+	return amount, verr.Err()
+}
+
+// CheckAmount valida un monto ya interpretado: debe ser positivo y no superar el máximo de su moneda.
+// Los servicios la usan para rechazar montos inválidos aunque no provengan de una solicitud HTTP.
+func (v *Validator) CheckAmount(field string, amount money.Money) error {
+	verr := &ValidationError{}
+	v.checkRange(verr, field, amount, true)
+	return verr.Err()
+}
+
+// accountID valida que el ID de una cuenta se haya indicado y sea positivo.
+func (v *Validator) accountID(verr *ValidationError, field string, id int) {
+	switch {
+	case id == 0:
+		verr.Add(field, FieldRequired, "el campo es obligatorio")
+	case id < 0:
+		verr.Add(field, FieldNotPositive, "el ID de cuenta debe ser positivo")
+	}
+}
+
+// movementAmount interpreta y valida el monto de un movimiento, cuya moneda es obligatoria.
+// Sin moneda no se puede saber cuántos decimales admite el monto, por lo que sólo se verifica que se haya indicado.
+func (v *Validator) movementAmount(verr *ValidationError, field, raw, currency string) money.Money {
+	if currency == "" {
+		if raw == "" {
+			verr.Add(field, FieldRequired, "el campo es obligatorio")
+		}
+		verr.Add("currency", FieldRequired, "el campo es obligatorio")
+		return money.Money{}
+	}
+	return v.amount(verr, field, raw, currency, true)
+}
+
+// pair valida un par de monedas: ambas deben estar soportadas y ser distintas.
+func (v *Validator) pair(verr *ValidationError, baseField, quoteField, base, quote string) fx.Pair {
+	for _, f := range []struct{ field, currency string }{{baseField, base}, {quoteField, quote}} {
+		if _, err := money.New(0, f.currency); err != nil {
+			verr.Add(f.field, FieldInvalid, "moneda no soportada")
+		}
+	}
+	if len(verr.Errors) > 0 {
+		return fx.Pair{}
+	}
+	pair, err := fx.NewPair(base, quote)
+	if err != nil {
+		verr.Add(quoteField, FieldSameCurrency, fx.ErrSameCurrency.Error())
+	}
+	return pair
+}
+
+// amount interpreta y valida un monto recibido como texto.
+// Si positive es verdadero el monto debe ser mayor que cero; en caso contrario basta con que no sea negativo.
+func (v *Validator) amount(verr *ValidationError, field, raw, currency string, positive bool) money.Money {
+	if raw == "" {
+		verr.Add(field, FieldRequired, "el campo es obligatorio")
+		return money.Money{}
+	}
+
+	m, err := money.Parse(raw, currency)
+	switch {
+	case errors.Is(err, money.ErrUnknownCurrency):
+		verr.Add("currency", FieldInvalid, "moneda no soportada")
+		return money.Money{}
+	case errors.Is(err, money.ErrPrecision):
+		units, _ := money.MinorUnits(money.Zero(currency).Currency())
+		verr.Add(field, FieldPrecision, fmt.Sprintf("el monto admite como máximo %d decimales", units))
+		return money.Money{}
+	case errors.Is(err, money.ErrOverflow):
+		verr.Add(field, FieldTooLarge, "el monto excede el rango permitido")
+		return money.Money{}
+	case err != nil:
+		verr.Add(field, FieldInvalid, "el monto debe ser un número decimal")
+		return money.Money{}
+	}
+
+	v.checkRange(verr, field, m, positive)
+	return m
+}
+
+// checkRange valida el signo del monto y su máximo por moneda.
+func (v *Validator) checkRange(verr *ValidationError, field string, m money.Money, positive bool) {
+	switch {
+	case positive && !m.IsPositive():
+		verr.Add(field, FieldNotPositive, "el monto debe ser mayor que cero")
+		return
+	case m.IsNegative():
+		verr.Add(field, FieldNegative, "el monto no puede ser negativo")
+		return
+	}
+	if max, ok := v.maxAmounts[m.Currency()]; ok {
+		if cmp, err := m.Cmp(max); err == nil && cmp > 0 {
+			verr.Add(field, FieldTooLarge, fmt.Sprintf("el monto máximo por operación es %s", max))
+		}
+	}
+}
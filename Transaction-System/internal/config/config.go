@@ -0,0 +1,450 @@
+// Package config carga la configuración del servicio bancario.
+// Los valores se resuelven en este orden, donde cada fuente reemplaza a la anterior:
+// valores por defecto, archivo YAML, variables de entorno y banderas de la línea de comandos.
+package config
+
+import (
+	"bytes"    // Lectura del archivo YAML en memoria
+	"errors"   // Paquete para agrupar errores de validación
+	"flag"     // Banderas de la línea de comandos
+	"fmt"      // Paquete para formatear errores
+	"io"       // Detección de un archivo vacío
+	"io/fs"    // Detección de archivos inexistentes
+	"log/slog" // Validación del nivel de log
+	"math/big" // Tasa de interés exacta
+	"os"       // Lectura del archivo y de las variables de entorno
+	"strconv"  // Conversión de variables de entorno numéricas
+	"strings"  // Validación de rutas
+	"time"     // Paquete para manejar duraciones
+
+	"gopkg.in/yaml.v3" // Decodificador YAML
+)
+
+// DefaultPath es la ruta del archivo de configuración usada si no se indica otra.
+const DefaultPath = "configs/config.yaml"
+
+// Backends de almacenamiento admitidos en database.driver.
+const (
+	DriverMySQL    = "mysql"    // Base de datos MySQL; database.dsn es el DSN de conexión
+	DriverPostgres = "postgres" // Base de datos PostgreSQL; database.dsn es la URL o la lista clave=valor de conexión
+	DriverSQLite   = "sqlite"   // Base de datos SQLite; database.dsn es la ruta del archivo
+	DriverMemory   = "memory"   // Repositorios en memoria; los datos se pierden al detener el servicio
+)
+
+// Config es la configuración completa del servicio.
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`      // Servidor HTTP principal
+	Database    DatabaseConfig    `yaml:"database"`    // Backend de almacenamiento y conexión a MySQL
+	Migrations  MigrationsConfig  `yaml:"migrations"`  // Migraciones del esquema
+	Pprof       PprofConfig       `yaml:"pprof"`       // Servidor de perfilado
+	Trace       TraceConfig       `yaml:"trace"`       // Trace de ejecución
+	Idempotency IdempotencyConfig `yaml:"idempotency"` // Claves de idempotencia
+	Retry       RetryConfig       `yaml:"retry"`       // Reintentos ante conflictos de concurrencia
+	FX          FXConfig          `yaml:"fx"`          // Cambio de divisas
+	Overdraft   OverdraftConfig   `yaml:"overdraft"`   // Intereses sobre el sobregiro
+	Holds       HoldsConfig       `yaml:"holds"`       // Retenciones de fondos
+	Health      HealthConfig      `yaml:"health"`      // Comprobaciones de estado
+	Metrics     MetricsConfig     `yaml:"metrics"`     // Métricas de Prometheus
+	Logging     LoggingConfig     `yaml:"logging"`     // Logging estructurado
+	Admin       AdminConfig       `yaml:"admin"`       // Rutas de administración
+}
+
+// ServerConfig configura el servidor HTTP principal.
+type ServerConfig struct {
+	Addr              string        `yaml:"addr"`                // Dirección de escucha (por ejemplo ":8080")
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"` // Tiempo máximo para leer las cabeceras
+	ReadTimeout       time.Duration `yaml:"read_timeout"`        // Tiempo máximo para leer la solicitud completa
+	WriteTimeout      time.Duration `yaml:"write_timeout"`       // Tiempo máximo para escribir la respuesta
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`        // Tiempo máximo de una conexión keep-alive inactiva
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`    // Plazo para terminar las solicitudes en curso al apagar
+}
+
+// DatabaseConfig configura el backend de almacenamiento y la conexión y el pool de conexiones a la base de datos.
+// Con el backend en memoria sólo se usa Driver.
+type DatabaseConfig struct {
+	Driver             string        `yaml:"driver"`              // Backend de almacenamiento: "mysql", "postgres", "sqlite" o "memory"
+	DSN                string        `yaml:"dsn"`                 // Data Source Name con credenciales y dirección, o ruta del archivo de SQLite
+	MaxOpenConns       int           `yaml:"max_open_conns"`      // Conexiones abiertas como máximo; 0 sin límite
+	MaxIdleConns       int           `yaml:"max_idle_conns"`      // Conexiones inactivas que se conservan
+	ConnMaxLifetime    time.Duration `yaml:"conn_max_lifetime"`   // Vida máxima de una conexión; 0 sin límite
+	ConnMaxIdleTime    time.Duration `yaml:"conn_max_idle_time"`  // Inactividad máxima de una conexión; 0 sin límite
+	QueryTimeout       time.Duration `yaml:"query_timeout"`       // Plazo de cada operación de un repositorio; 0 sin límite
+	TransactionTimeout time.Duration `yaml:"transaction_timeout"` // Plazo de una unidad de trabajo completa; 0 sin límite
+}
+
+// MigrationsConfig configura la aplicación de las migraciones del esquema.
+type MigrationsConfig struct {
+	Auto        bool          `yaml:"auto"`         // Aplica las migraciones pendientes al iniciar el servicio
+	LockTimeout time.Duration `yaml:"lock_timeout"` // Espera máxima mientras otra instancia aplica migraciones
+}
+
+// PprofConfig configura el servidor de perfilado pprof.
+type PprofConfig struct {
+	Enabled bool   `yaml:"enabled"` // Habilita el servidor pprof
+	Addr    string `yaml:"addr"`    // Dirección de escucha; debería ser local
+}
+
+// TraceConfig configura la captura del trace de ejecución.
+type TraceConfig struct {
+	Enabled bool   `yaml:"enabled"` // Habilita la captura del trace
+	File    string `yaml:"file"`    // Archivo donde se escribe el trace
+}
+
+// IdempotencyConfig configura el soporte de la cabecera Idempotency-Key.
+type IdempotencyConfig struct {
+	Enabled       bool          `yaml:"enabled"`        // Habilita la deduplicación de reintentos
+	Retention     time.Duration `yaml:"retention"`      // Tiempo durante el cual se conserva cada clave
+	PurgeInterval time.Duration `yaml:"purge_interval"` // Frecuencia con la que se eliminan las claves expiradas
+}
+
+// RetryConfig configura los reintentos ante conflictos de concurrencia optimista.
+type RetryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts"` // Cantidad máxima de intentos, incluido el primero
+	BaseDelay   time.Duration `yaml:"base_delay"`   // Espera antes del primer reintento
+	MaxDelay    time.Duration `yaml:"max_delay"`    // Espera máxima entre intentos
+}
+
+// FXConfig configura el cambio de divisas de las transferencias entre cuentas de distinta moneda.
+type FXConfig struct {
+	RatesFile string        `yaml:"rates_file"` // Archivo YAML con la tabla estática de tipos de cambio; vacío para no cargar ninguna
+	SpreadBPS int           `yaml:"spread_bps"` // Margen del banco sobre el tipo de mercado, en puntos básicos
+	QuoteTTL  time.Duration `yaml:"quote_ttl"`  // Plazo de validez de cada cotización
+}
+
+// OverdraftConfig configura el cobro diario de intereses y comisión sobre el sobregiro en uso de las cuentas.
+type OverdraftConfig struct {
+	InterestEnabled bool          `yaml:"interest_enabled"` // Habilita la tarea que cobra los intereses y la comisión de cada día al cerrarlo
+	InterestRate    string        `yaml:"interest_rate"`    // Tasa nominal anual, como decimal (0.18 = 18 %)
+	DailyFee        string        `yaml:"daily_fee"`        // Comisión fija por día sobregirado, en unidades de la moneda de la cuenta
+	DaysPerYear     int           `yaml:"days_per_year"`    // Base de días del año con que se prorratea la tasa
+	AccrualInterval time.Duration `yaml:"accrual_interval"` // Frecuencia con que la tarea revisa si hay un día cerrado sin cobrar
+}
+
+// AnnualRate devuelve la tasa anual de intereses como número racional exacto.
+// Retorna un error si la tasa no es un decimal entre 0 y 1.
+func (c OverdraftConfig) AnnualRate() (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(c.InterestRate)
+	if !ok || strings.ContainsAny(c.InterestRate, "/eE") || rate.Sign() < 0 || rate.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, fmt.Errorf("tasa de interés inválida: %q", c.InterestRate)
+	}
+	return rate, nil
+}
+
+// Fee devuelve la comisión diaria de sobregiro como número racional exacto.
+// Retorna un error si la comisión no es un decimal no negativo.
+func (c OverdraftConfig) Fee() (*big.Rat, error) {
+	fee, ok := new(big.Rat).SetString(c.DailyFee)
+	if !ok || strings.ContainsAny(c.DailyFee, "/eE") || fee.Sign() < 0 {
+		return nil, fmt.Errorf("comisión de sobregiro inválida: %q", c.DailyFee)
+	}
+	return fee, nil
+}
+
+// HoldsConfig configura la vigencia y el vencimiento de las retenciones de fondos.
+type HoldsConfig struct {
+	DefaultTTL     time.Duration `yaml:"default_ttl"`     // Vigencia de una retención si la solicitud no indica otra
+	MaxTTL         time.Duration `yaml:"max_ttl"`         // Vigencia máxima que puede pedir una solicitud
+	ExpiryInterval time.Duration `yaml:"expiry_interval"` // Frecuencia con que la tarea libera las retenciones vencidas
+}
+
+// HealthConfig configura las comprobaciones de estado de /readyz.
+type HealthConfig struct {
+	CheckTimeout time.Duration `yaml:"check_timeout"` // Plazo de cada comprobación (por ejemplo, el ping a la base de datos)
+}
+
+// MetricsConfig configura la exposición de métricas en formato Prometheus.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"` // Habilita la recolección y la ruta de métricas
+	Path    string `yaml:"path"`    // Ruta del servidor HTTP principal donde se exponen
+}
+
+// LoggingConfig configura el logging estructurado.
+type LoggingConfig struct {
+	Level  string `yaml:"level"`  // Nivel mínimo: "debug", "info", "warn" o "error"
+	Format string `yaml:"format"` // Formato de salida: "json" o "text"
+}
+
+// MinAdminTokenLength es la longitud mínima del token de administración, para que no pueda adivinarse.
+const MinAdminTokenLength = 32
+
+// AdminConfig configura el acceso a las rutas de administración (/admin/...).
+type AdminConfig struct {
+	Token string `yaml:"token"` // Token que deben enviar las solicitudes (Authorization: Bearer); vacío deshabilita las rutas
+}
+
+// Default devuelve la configuración por defecto, equivalente al comportamiento histórico del servicio.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   15 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:             DriverMySQL,
+			DSN:                "bankuser:bankpassword@tcp(127.0.0.1:3306)/bankdb",
+			MaxOpenConns:       25,
+			MaxIdleConns:       25,
+			ConnMaxLifetime:    5 * time.Minute,
+			ConnMaxIdleTime:    time.Minute,
+			QueryTimeout:       3 * time.Second,
+			TransactionTimeout: 10 * time.Second,
+		},
+		Migrations:  MigrationsConfig{Auto: true, LockTimeout: 30 * time.Second},
+		Pprof:       PprofConfig{Enabled: true, Addr: "localhost:6060"},
+		Trace:       TraceConfig{Enabled: true, File: "trace.out"},
+		Idempotency: IdempotencyConfig{Enabled: true, Retention: 24 * time.Hour, PurgeInterval: time.Hour},
+		Retry:       RetryConfig{MaxAttempts: 5, BaseDelay: 5 * time.Millisecond, MaxDelay: 100 * time.Millisecond},
+		FX:          FXConfig{RatesFile: "configs/fx_rates.yaml", SpreadBPS: 25, QuoteTTL: 30 * time.Second},
+		Overdraft:   OverdraftConfig{InterestEnabled: true, InterestRate: "0.18", DailyFee: "0", DaysPerYear: 365, AccrualInterval: time.Hour},
+		Holds:       HoldsConfig{DefaultTTL: 7 * 24 * time.Hour, MaxTTL: 30 * 24 * time.Hour, ExpiryInterval: time.Minute},
+		Health:      HealthConfig{CheckTimeout: 2 * time.Second},
+		Metrics:     MetricsConfig{Enabled: true, Path: "/metrics"},
+		Logging:     LoggingConfig{Level: "info", Format: "json"},
+	}
+}
+
+// Load resuelve la configuración a partir de los argumentos de la línea de comandos (sin el nombre
+// del programa) y de las variables de entorno obtenidas con lookupEnv (normalmente os.LookupEnv).
+// El archivo se toma de la bandera -config, de BANK_CONFIG o de DefaultPath; sólo en este último caso
+// se tolera que no exista. La configuración resultante se valida antes de devolverse.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+
+	// Definir las banderas; sus valores sólo se aplican si se indicaron explícitamente
+	fset := flag.NewFlagSet("bankservice", flag.ContinueOnError)
+	path := fset.String("config", "", "ruta del archivo de configuración YAML")
+	addr := fset.String("addr", "", "dirección de escucha del servidor HTTP")
+	dsn := fset.String("dsn", "", "DSN de la base de datos MySQL o PostgreSQL, o ruta del archivo de SQLite")
+	pprof := fset.Bool("pprof", false, "habilita el servidor pprof")
+	traceOn := fset.Bool("trace", false, "habilita la captura del trace de ejecución")
+	if err := fset.Parse(args); err != nil {
+		return cfg, err
+	}
+	set := map[string]bool{}
+	fset.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// Archivo YAML
+	file, required := DefaultPath, false
+	if v, ok := lookupEnv("BANK_CONFIG"); ok && v != "" {
+		file, required = v, true
+	}
+	if set["config"] {
+		file, required = *path, true
+	}
+	if err := cfg.loadFile(file, required); err != nil {
+		return cfg, err
+	}
+
+	// Variables de entorno
+	if err := cfg.applyEnv(lookupEnv); err != nil {
+		return cfg, err
+	}
+
+	// Banderas de la línea de comandos
+	if set["addr"] {
+		cfg.Server.Addr = *addr
+	}
+	if set["dsn"] {
+		cfg.Database.DSN = *dsn
+	}
+	if set["pprof"] {
+		cfg.Pprof.Enabled = *pprof
+	}
+	if set["trace"] {
+		cfg.Trace.Enabled = *traceOn
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// loadFile superpone el contenido del archivo YAML sobre la configuración actual.
+// Los campos ausentes en el archivo conservan su valor y los campos desconocidos se rechazan.
+// Si required es falso, un archivo inexistente no es un error.
+func (c *Config) loadFile(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("no se puede leer la configuración: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("configuración inválida en %s: %w", path, err)
+	}
+	return nil
+}
+
+// envVar asocia una variable de entorno con el campo que reemplaza.
+type envVar struct {
+	name  string             // Nombre de la variable
+	apply func(string) error // Interpreta el valor y lo asigna al campo
+}
+
+// applyEnv reemplaza los campos cuyas variables de entorno estén definidas.
+// PORT se mantiene por compatibilidad y equivale a BANK_SERVER_ADDR=":<PORT>".
+func (c *Config) applyEnv(lookupEnv func(string) (string, bool)) error {
+	vars := []envVar{
+		{"PORT", func(v string) error { c.Server.Addr = ":" + v; return nil }},
+		{"BANK_SERVER_ADDR", stringVar(&c.Server.Addr)},
+		{"BANK_SERVER_READ_HEADER_TIMEOUT", durationVar(&c.Server.ReadHeaderTimeout)},
+		{"BANK_SERVER_READ_TIMEOUT", durationVar(&c.Server.ReadTimeout)},
+		{"BANK_SERVER_WRITE_TIMEOUT", durationVar(&c.Server.WriteTimeout)},
+		{"BANK_SERVER_IDLE_TIMEOUT", durationVar(&c.Server.IdleTimeout)},
+		{"BANK_SERVER_SHUTDOWN_TIMEOUT", durationVar(&c.Server.ShutdownTimeout)},
+		{"BANK_DB_DRIVER", stringVar(&c.Database.Driver)},
+		{"BANK_DB_DSN", stringVar(&c.Database.DSN)},
+		{"BANK_DB_MAX_OPEN_CONNS", intVar(&c.Database.MaxOpenConns)},
+		{"BANK_DB_MAX_IDLE_CONNS", intVar(&c.Database.MaxIdleConns)},
+		{"BANK_DB_CONN_MAX_LIFETIME", durationVar(&c.Database.ConnMaxLifetime)},
+		{"BANK_DB_CONN_MAX_IDLE_TIME", durationVar(&c.Database.ConnMaxIdleTime)},
+		{"BANK_DB_QUERY_TIMEOUT", durationVar(&c.Database.QueryTimeout)},
+		{"BANK_DB_TRANSACTION_TIMEOUT", durationVar(&c.Database.TransactionTimeout)},
+		{"BANK_MIGRATIONS_AUTO", boolVar(&c.Migrations.Auto)},
+		{"BANK_MIGRATIONS_LOCK_TIMEOUT", durationVar(&c.Migrations.LockTimeout)},
+		{"BANK_PPROF_ENABLED", boolVar(&c.Pprof.Enabled)},
+		{"BANK_PPROF_ADDR", stringVar(&c.Pprof.Addr)},
+		{"BANK_TRACE_ENABLED", boolVar(&c.Trace.Enabled)},
+		{"BANK_TRACE_FILE", stringVar(&c.Trace.File)},
+		{"BANK_IDEMPOTENCY_ENABLED", boolVar(&c.Idempotency.Enabled)},
+		{"BANK_IDEMPOTENCY_RETENTION", durationVar(&c.Idempotency.Retention)},
+		{"BANK_IDEMPOTENCY_PURGE_INTERVAL", durationVar(&c.Idempotency.PurgeInterval)},
+		{"BANK_RETRY_MAX_ATTEMPTS", intVar(&c.Retry.MaxAttempts)},
+		{"BANK_RETRY_BASE_DELAY", durationVar(&c.Retry.BaseDelay)},
+		{"BANK_RETRY_MAX_DELAY", durationVar(&c.Retry.MaxDelay)},
+		{"BANK_FX_RATES_FILE", stringVar(&c.FX.RatesFile)},
+		{"BANK_FX_SPREAD_BPS", intVar(&c.FX.SpreadBPS)},
+		{"BANK_FX_QUOTE_TTL", durationVar(&c.FX.QuoteTTL)},
+		{"BANK_OVERDRAFT_INTEREST_ENABLED", boolVar(&c.Overdraft.InterestEnabled)},
+		{"BANK_OVERDRAFT_INTEREST_RATE", stringVar(&c.Overdraft.InterestRate)},
+		{"BANK_OVERDRAFT_DAILY_FEE", stringVar(&c.Overdraft.DailyFee)},
+		{"BANK_OVERDRAFT_DAYS_PER_YEAR", intVar(&c.Overdraft.DaysPerYear)},
+		{"BANK_OVERDRAFT_ACCRUAL_INTERVAL", durationVar(&c.Overdraft.AccrualInterval)},
+		{"BANK_HOLDS_DEFAULT_TTL", durationVar(&c.Holds.DefaultTTL)},
+		{"BANK_HOLDS_MAX_TTL", durationVar(&c.Holds.MaxTTL)},
+		{"BANK_HOLDS_EXPIRY_INTERVAL", durationVar(&c.Holds.ExpiryInterval)},
+		{"BANK_HEALTH_CHECK_TIMEOUT", durationVar(&c.Health.CheckTimeout)},
+		{"BANK_METRICS_ENABLED", boolVar(&c.Metrics.Enabled)},
+		{"BANK_METRICS_PATH", stringVar(&c.Metrics.Path)},
+		{"BANK_LOG_LEVEL", stringVar(&c.Logging.Level)},
+		{"BANK_LOG_FORMAT", stringVar(&c.Logging.Format)},
+		{"BANK_ADMIN_TOKEN", stringVar(&c.Admin.Token)},
+	}
+	for _, v := range vars {
+		value, ok := lookupEnv(v.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := v.apply(value); err != nil {
+			return fmt.Errorf("variable de entorno %s inválida: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+// stringVar asigna el valor tal cual.
+func stringVar(dst *string) func(string) error {
+	return func(v string) error { *dst = v; return nil }
+}
+
+// intVar interpreta el valor como un entero.
+func intVar(dst *int) func(string) error {
+	return func(v string) (err error) { *dst, err = strconv.Atoi(v); return err }
+}
+
+// boolVar interpreta el valor como un booleano ("true", "false", "1", "0", ...).
+func boolVar(dst *bool) func(string) error {
+	return func(v string) (err error) { *dst, err = strconv.ParseBool(v); return err }
+}
+
+// durationVar interpreta el valor como una duración ("5s", "250ms", ...).
+func durationVar(dst *time.Duration) func(string) error {
+	return func(v string) (err error) { *dst, err = time.ParseDuration(v); return err }
+}
+
+// Validate comprueba que la configuración sea coherente.
+// Retorna todos los problemas encontrados en un único error.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Addr != "", "server.addr es obligatorio")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout debe ser mayor que cero")
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"database.conn_max_lifetime", c.Database.ConnMaxLifetime},
+		{"database.conn_max_idle_time", c.Database.ConnMaxIdleTime},
+		{"database.query_timeout", c.Database.QueryTimeout},
+		{"database.transaction_timeout", c.Database.TransactionTimeout},
+	} {
+		check(d.value >= 0, "%s no puede ser negativo", d.name)
+	}
+
+	check(c.Database.Driver == DriverMySQL || c.Database.Driver == DriverPostgres || c.Database.Driver == DriverSQLite || c.Database.Driver == DriverMemory,
+		"database.driver debe ser %s, %s, %s o %s", DriverMySQL, DriverPostgres, DriverSQLite, DriverMemory)
+	check(c.Database.Driver == DriverMemory || c.Database.DSN != "", "database.dsn es obligatorio con el driver %s", c.Database.Driver)
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns no puede ser negativo")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns no puede ser negativo")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns (%d) no puede superar database.max_open_conns (%d)",
+		c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	check(c.Database.TransactionTimeout == 0 || c.Database.QueryTimeout <= c.Database.TransactionTimeout,
+		"database.query_timeout (%s) no puede superar database.transaction_timeout (%s)",
+		c.Database.QueryTimeout, c.Database.TransactionTimeout)
+
+	check(c.Migrations.LockTimeout > 0, "migrations.lock_timeout debe ser mayor que cero")
+
+	check(!c.Pprof.Enabled || c.Pprof.Addr != "", "pprof.addr es obligatorio si pprof está habilitado")
+	check(!c.Trace.Enabled || c.Trace.File != "", "trace.file es obligatorio si el trace está habilitado")
+
+	if c.Idempotency.Enabled {
+		check(c.Idempotency.Retention > 0, "idempotency.retention debe ser mayor que cero")
+		check(c.Idempotency.PurgeInterval > 0, "idempotency.purge_interval debe ser mayor que cero")
+	}
+
+	check(c.Retry.MaxAttempts >= 1, "retry.max_attempts debe ser al menos 1")
+	check(c.Retry.BaseDelay >= 0, "retry.base_delay no puede ser negativo")
+	check(c.Retry.MaxDelay >= c.Retry.BaseDelay, "retry.max_delay no puede ser menor que retry.base_delay")
+
+	check(c.FX.SpreadBPS >= 0 && c.FX.SpreadBPS < 10000, "fx.spread_bps debe estar entre 0 y 9999")
+	check(c.FX.QuoteTTL > 0, "fx.quote_ttl debe ser mayor que cero")
+
+	_, rateErr := c.Overdraft.AnnualRate()
+	check(rateErr == nil, "overdraft.interest_rate debe ser un decimal entre 0 y 1: %q", c.Overdraft.InterestRate)
+	_, feeErr := c.Overdraft.Fee()
+	check(feeErr == nil, "overdraft.daily_fee debe ser un decimal no negativo: %q", c.Overdraft.DailyFee)
+	check(c.Overdraft.DaysPerYear == 360 || c.Overdraft.DaysPerYear == 365, "overdraft.days_per_year debe ser 360 o 365")
+	check(!c.Overdraft.InterestEnabled || c.Overdraft.AccrualInterval > 0, "overdraft.accrual_interval debe ser mayor que cero si los intereses están habilitados")
+
+	check(c.Holds.DefaultTTL > 0, "holds.default_ttl debe ser mayor que cero")
+	check(c.Holds.MaxTTL >= c.Holds.DefaultTTL, "holds.max_ttl no puede ser menor que holds.default_ttl")
+	check(c.Holds.ExpiryInterval > 0, "holds.expiry_interval debe ser mayor que cero")
+
+	check(c.Health.CheckTimeout > 0, "health.check_timeout debe ser mayor que cero")
+	check(!c.Metrics.Enabled || strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path debe comenzar con /")
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Logging.Level)) == nil, "logging.level inválido: %q", c.Logging.Level)
+	check(c.Logging.Format == "json" || c.Logging.Format == "text", "logging.format debe ser json o text")
+	check(c.Admin.Token == "" || len(c.Admin.Token) >= MinAdminTokenLength, "admin.token debe tener al menos %d caracteres", MinAdminTokenLength)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("configuración inválida: %w", errors.Join(errs...))
+	}
+	return nil
+}
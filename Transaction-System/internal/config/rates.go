@@ -0,0 +1,45 @@
+package config
+
+import (
+	"bytes"  // Lectura del archivo YAML en memoria
+	"errors" // Detección de archivos inexistentes y vacíos
+	"fmt"    // Paquete para formatear errores
+	"io"     // Detección de un archivo vacío
+	"io/fs"  // Detección de archivos inexistentes
+	"os"     // Lectura del archivo
+
+	"gopkg.in/yaml.v3" // Decodificador YAML
+)
+
+// ratesFile es el formato del archivo de la tabla estática de tipos de cambio:
+//
+//	rates:
+//	  USD/EUR: "0.92"
+//	  USD/COP: "3950.50"
+//
+// Cada clave es un par BASE/COTIZADA y cada valor las unidades de la moneda cotizada por unidad de la base.
+// Los valores se escriben entre comillas para que YAML no los convierta en números de punto flotante.
+type ratesFile struct {
+	Rates map[string]string `yaml:"rates"` // Tipos de cambio por par
+}
+
+// LoadRates lee la tabla estática de tipos de cambio del archivo indicado.
+// Un archivo inexistente equivale a una tabla vacía, salvo que required sea verdadero.
+// La validez de los pares y de los valores la comprueba fx.NewStaticProvider.
+func LoadRates(path string, required bool) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no se puede leer la tabla de tipos de cambio: %w", err)
+	}
+
+	var file ratesFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("tabla de tipos de cambio inválida en %s: %w", path, err)
+	}
+	return file.Rates, nil
+}
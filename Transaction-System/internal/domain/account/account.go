@@ -1,43 +1,120 @@
 package account
 
 import (
-	"fmt"  // Paquete para formatear y manejar errores
-	"time" // Paquete para manejar fechas y horas
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"time"                                     // Paquete para manejar fechas y horas
 )
 
 // Account representa una cuenta bancaria en el dominio del sistema.
-// Contiene un número de cuenta, un balance actual, una identificación única y
-// la fecha de creación de la cuenta.
+// Contiene un número de cuenta, un balance actual, una identificación única,
+// el estado de la cuenta, su versión y la fecha de creación de la cuenta.
+// La moneda de la cuenta es la de su balance y no cambia: todos sus movimientos deben estar en esa moneda.
+// Con un sobregiro autorizado el balance puede ser negativo hasta ese límite.
+// Balance es el balance contable: sólo cambia con los movimientos registrados. Las retenciones de fondos
+// autorizadas y aún no capturadas no lo modifican, pero reducen el disponible (ver Available).
 type Account struct {
-	ID            int       // Identificador único de la cuenta
-	AccountNumber string    // Número de cuenta único
-	Balance       float64   // Balance actual de la cuenta
-	CreatedAt     time.Time // Fecha de creación de la cuenta
+	ID                int         // Identificador único de la cuenta
+	AccountNumber     string      // Número de cuenta único
+	Balance           money.Money // Balance contable de la cuenta; negativo si usa el sobregiro
+	Held              money.Money // Fondos retenidos por autorizaciones pendientes (ver HeldAmount)
+	Overdraft         money.Money // Sobregiro autorizado; cero si la cuenta no admite sobregiro (ver OverdraftLimit)
+	InterestAccruedOn time.Time   // Último día por el que se cobraron intereses de sobregiro; cero si nunca
+	Status            Status      // Estado de la cuenta (activa, congelada o cerrada)
+	Version           int         // Versión de la cuenta; el repositorio la incrementa en cada actualización
+	CreatedAt         time.Time   // Fecha de creación de la cuenta
 }
 
 // NewAccount es un constructor que crea una nueva instancia de una cuenta bancaria.
-// Recibe el número de cuenta y el balance inicial como parámetros.
-func NewAccount(accountNumber string, balance float64) *Account {
+// Recibe el número de cuenta y el balance inicial como parámetros; la moneda del balance es la de la cuenta.
+func NewAccount(accountNumber string, balance money.Money) *Account {
 	return &Account{
 		AccountNumber: accountNumber, // Asigna el número de cuenta
 		Balance:       balance,       // Asigna el balance inicial
+		Status:        StatusActive,  // Las cuentas nuevas se abren activas
 		CreatedAt:     time.Now(),    // Establece la fecha de creación como la fecha y hora actual
 	}
 }
 
+// Currency devuelve el código ISO 4217 de la moneda de la cuenta.
+func (a *Account) Currency() string {
+	return a.Balance.Currency()
+}
+
 // Withdraw realiza un retiro de la cuenta bancaria.
-// Si el monto del retiro es mayor que el balance actual, devuelve un error de fondos insuficientes.
-func (a *Account) Withdraw(amount float64) error {
-	// Verificar si hay suficientes fondos
-	if amount > a.Balance {
-		return fmt.Errorf("fondos insuficientes") // Devuelve un error si no hay fondos suficientes
-	}
-	a.Balance -= amount // Disminuye el balance con el monto retirado
-	return nil          // No se devuelve ningún error si el retiro es exitoso
+// Si el monto del retiro es mayor que el disponible (balance más sobregiro autorizado menos retenciones), devuelve
+// ErrInsufficientFunds si la cuenta no tiene sobregiro, o ErrOverdraftLimitExceeded si lo tiene.
+// Las cuentas congeladas o cerradas no admiten retiros, y el monto debe ser positivo (ErrInvalidAmount).
+// Un monto en una moneda distinta a la de la cuenta devuelve un *CurrencyMismatchError.
+func (a *Account) Withdraw(amount money.Money) error {
+	// Verificar que la cuenta admita movimientos en la moneda del monto
+	if err := a.checkOperable(); err != nil {
+		return err
+	}
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+
+	// Verificar si hay suficientes fondos, contando el sobregiro autorizado y las retenciones
+	if err := a.checkFunds(amount); err != nil {
+		return err
+	}
+
+	// Disminuye el balance con el monto retirado
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+	a.Balance = balance
+	return nil // No se devuelve ningún error si el retiro es exitoso
 }
 
 // Deposit realiza un depósito en la cuenta bancaria.
-// Simplemente aumenta el balance de la cuenta con el monto especificado.
-func (a *Account) Deposit(amount float64) {
-	a.Balance += amount // Aumenta el balance con el monto depositado
+// Las cuentas congeladas o cerradas no admiten depósitos, y el monto debe ser positivo (ErrInvalidAmount).
+// Aumenta el balance de la cuenta con el monto especificado; devuelve un *CurrencyMismatchError si el
+// monto está en una moneda distinta a la de la cuenta, o un error si el resultado excede el rango permitido.
+func (a *Account) Deposit(amount money.Money) error {
+	// Verificar que la cuenta admita movimientos en la moneda del monto
+	if err := a.checkOperable(); err != nil {
+		return err
+	}
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+
+	// Aumenta el balance con el monto depositado
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	a.Balance = balance
+	return nil
+}
+
+// checkFunds verifica que el disponible alcance para el monto indicado.
+func (a *Account) checkFunds(amount money.Money) error {
+	cmp, err := amount.Cmp(a.Available())
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		if a.OverdraftLimit().IsZero() {
+			return ErrInsufficientFunds // Devuelve un error si no hay fondos suficientes
+		}
+		return ErrOverdraftLimitExceeded // La cuenta tiene sobregiro, pero el movimiento lo supera
+	}
+	return nil
+}
+
+// checkCurrency verifica que el monto esté en la moneda de la cuenta.
+func (a *Account) checkCurrency(amount money.Money) error {
+	if amount.Currency() != a.Currency() {
+		return &CurrencyMismatchError{AccountID: a.ID, AccountCurrency: a.Currency(), Currency: amount.Currency()}
+	}
+	return nil
 }
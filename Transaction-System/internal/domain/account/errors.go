@@ -0,0 +1,36 @@
+package account
+
+import (
+	"Transaction-System/internal/domain/money"
+	"errors"
+	"fmt"
+)
+
+// Errores de las operaciones sobre una cuenta.
+// Se comparan con errors.Is; los repositorios y servicios pueden envolverlos con más contexto.
+var (
+	// ErrNotFound indica que no existe una cuenta con el ID indicado.
+	ErrNotFound = errors.New("cuenta no encontrada")
+	// ErrInsufficientFunds indica que el balance no alcanza para cubrir un retiro o una transferencia.
+	ErrInsufficientFunds = errors.New("fondos insuficientes")
+	// ErrInvalidAmount indica que el monto de un movimiento no es positivo.
+	ErrInvalidAmount = errors.New("el monto debe ser positivo")
+)
+
+// CurrencyMismatchError indica que un movimiento está en una moneda distinta a la de la cuenta.
+// Envuelve money.ErrCurrencyMismatch, por lo que también puede compararse con errors.Is.
+type CurrencyMismatchError struct {
+	AccountID       int    // Cuenta sobre la que se intentó el movimiento
+	AccountCurrency string // Moneda de la cuenta
+	Currency        string // Moneda del monto recibido
+}
+
+// Error implementa la interfaz error.
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("la cuenta %d opera en %s y el monto está en %s", e.AccountID, e.AccountCurrency, e.Currency)
+}
+
+// Unwrap permite usar errors.Is(err, money.ErrCurrencyMismatch) sobre el error.
+func (e *CurrencyMismatchError) Unwrap() error {
+	return money.ErrCurrencyMismatch
+}
@@ -0,0 +1,91 @@
+package account
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"fmt"                                      // Paquete para formatear errores
+)
+
+// HeldAmount devuelve los fondos retenidos por autorizaciones pendientes, en la moneda de la cuenta.
+func (a *Account) HeldAmount() money.Money {
+	if a.Held.IsZero() {
+		return money.Zero(a.Currency())
+	}
+	return a.Held
+}
+
+// Authorize retiene fondos de la cuenta para una captura posterior, sin modificar su balance contable.
+// La retención reduce el disponible: no se autoriza si el monto supera el disponible (ErrInsufficientFunds,
+// o ErrOverdraftLimitExceeded si la cuenta tiene sobregiro), igual que un retiro.
+// Las cuentas congeladas o cerradas no admiten autorizaciones, y el monto debe ser positivo (ErrInvalidAmount)
+// y estar en la moneda de la cuenta (*CurrencyMismatchError).
+func (a *Account) Authorize(amount money.Money) error {
+	if err := a.checkOperable(); err != nil {
+		return err
+	}
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if err := a.checkFunds(amount); err != nil {
+		return err
+	}
+
+	held, err := a.HeldAmount().Add(amount)
+	if err != nil {
+		return err
+	}
+	a.Held = held
+	return nil
+}
+
+// CaptureHold cobra una retención: libera los fondos retenidos (held) y debita del balance el monto
+// capturado (captured), que puede ser menor que el retenido. No se verifican los fondos, que ya estaban
+// reservados por la autorización. Las cuentas congeladas o cerradas no admiten capturas; la retención
+// sigue vigente y puede liberarse.
+func (a *Account) CaptureHold(held, captured money.Money) error {
+	if err := a.checkOperable(); err != nil {
+		return err
+	}
+	if err := a.checkCurrency(captured); err != nil {
+		return err
+	}
+	if !captured.IsPositive() {
+		return ErrInvalidAmount
+	}
+	cmp, err := captured.Cmp(held)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("%w: se capturan %s de una retención de %s", ErrInvalidAmount, captured, held)
+	}
+
+	if err := a.ReleaseHold(held); err != nil {
+		return err
+	}
+	balance, err := a.Balance.Sub(captured)
+	if err != nil {
+		return err
+	}
+	a.Balance = balance
+	return nil
+}
+
+// ReleaseHold devuelve al disponible los fondos de una retención liberada o vencida, sin modificar el balance.
+// Se admite con la cuenta congelada. El monto no puede superar los fondos retenidos de la cuenta.
+func (a *Account) ReleaseHold(amount money.Money) error {
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	held, err := a.HeldAmount().Sub(amount)
+	if err != nil {
+		return err
+	}
+	if held.IsNegative() {
+		return fmt.Errorf("%w: se liberan %s y la cuenta %d sólo retiene %s", ErrInvalidAmount, amount, a.ID, a.HeldAmount())
+	}
+	a.Held = held
+	return nil
+}
@@ -0,0 +1,21 @@
+package account
+
+import (
+	"crypto/rand" // Generador de números aleatorios criptográficamente seguro
+	"fmt"         // Paquete para formatear el número de cuenta
+	"math/big"    // Enteros grandes para generar dígitos uniformes
+)
+
+// accountNumberDigits es la cantidad de dígitos aleatorios de los números de cuenta generados.
+const accountNumberDigits = 12
+
+// GenerateNumber genera un número de cuenta aleatorio con el formato "ACC" seguido de 12 dígitos.
+// Los números generados no colisionan con los del generador de datos, que usa 4 dígitos.
+func GenerateNumber() (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(accountNumberDigits), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("no se pudo generar el número de cuenta: %w", err)
+	}
+	return fmt.Sprintf("ACC%0*d", accountNumberDigits, n), nil
+}
@@ -0,0 +1,126 @@
+package account
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"errors"                                   // Paquete para definir errores del dominio
+	"fmt"                                      // Paquete para formatear errores
+	"math/big"                                 // Tasas de interés exactas
+	"time"                                     // Días de cálculo de intereses
+)
+
+// ErrOverdraftLimitExceeded indica que un retiro o una transferencia dejaría el balance por debajo del
+// sobregiro autorizado de la cuenta. Las cuentas sin sobregiro siguen devolviendo ErrInsufficientFunds.
+var ErrOverdraftLimitExceeded = errors.New("el movimiento excede el sobregiro autorizado")
+
+// OverdraftLimit devuelve el sobregiro autorizado de la cuenta, en su moneda; cero si no admite sobregiro.
+func (a *Account) OverdraftLimit() money.Money {
+	if a.Overdraft.IsZero() {
+		return money.Zero(a.Currency())
+	}
+	return a.Overdraft
+}
+
+// OverdraftUsed devuelve el monto del sobregiro en uso: el balance negativo de la cuenta, o cero.
+func (a *Account) OverdraftUsed() money.Money {
+	if a.Balance.IsNegative() {
+		return a.Balance.Neg()
+	}
+	return money.Zero(a.Currency())
+}
+
+// Available devuelve el monto que la cuenta puede retirar o retener: el balance más el sobregiro
+// autorizado, menos los fondos retenidos. Puede ser negativo si los cargos del banco superaron el sobregiro.
+func (a *Account) Available() money.Money {
+	available, err := a.Balance.Add(a.OverdraftLimit())
+	if err != nil {
+		// El sobregiro se valida contra el rango al fijarse; el balance solo no puede desbordar
+		available = a.Balance
+	}
+	if available, err = available.Sub(a.HeldAmount()); err != nil {
+		return a.Balance
+	}
+	return available
+}
+
+// SetOverdraftLimit fija el sobregiro autorizado de la cuenta; cero lo deshabilita.
+// El límite debe estar en la moneda de la cuenta (*CurrencyMismatchError), no puede ser negativo
+// (ErrInvalidAmount) ni menor que el sobregiro en uso (ErrOverdraftLimitExceeded).
+// Una cuenta cerrada no admite sobregiro (ErrAccountClosed).
+func (a *Account) SetOverdraftLimit(limit money.Money) error {
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	if err := a.checkCurrency(limit); err != nil {
+		return err
+	}
+	if limit.IsNegative() {
+		return ErrInvalidAmount
+	}
+	if _, err := a.Balance.Add(limit); err != nil {
+		return err
+	}
+
+	// Reducir el límite por debajo del sobregiro en uso dejaría la cuenta excedida
+	cmp, err := limit.Cmp(a.OverdraftUsed())
+	if err != nil {
+		return err
+	}
+	if cmp < 0 {
+		return fmt.Errorf("%w: la cuenta %d usa %s de sobregiro", ErrOverdraftLimitExceeded, a.ID, a.OverdraftUsed())
+	}
+	a.Overdraft = limit
+	return nil
+}
+
+// Charge debita un cargo del banco (por ejemplo, intereses de sobregiro) sin verificar los fondos
+// disponibles: el cargo se cobra aunque deje la cuenta por encima de su sobregiro.
+// Las cuentas congeladas también reciben cargos; las cerradas no (ErrAccountClosed).
+// El monto debe ser positivo (ErrInvalidAmount) y estar en la moneda de la cuenta (*CurrencyMismatchError).
+func (a *Account) Charge(amount money.Money) error {
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+	a.Balance = balance
+	return nil
+}
+
+// DailyInterest calcula el interés de un día sobre el sobregiro en uso de la cuenta:
+// sobregiro × tasa anual / días del año, redondeado a los decimales de la moneda (redondeo bancario).
+// Devuelve cero si la cuenta no está sobregirada.
+func (a *Account) DailyInterest(annualRate *big.Rat, daysPerYear int) (money.Money, error) {
+	used := a.OverdraftUsed()
+	if used.IsZero() {
+		return used, nil
+	}
+	factor := new(big.Rat).Quo(annualRate, big.NewRat(int64(daysPerYear), 1))
+	return used.MulRat(factor, money.RoundHalfEven)
+}
+
+// DailyFee devuelve la comisión diaria fija de una cuenta sobregirada: fee unidades de la moneda de la
+// cuenta, redondeadas a sus decimales (redondeo bancario). Devuelve cero si la cuenta no está sobregirada.
+func (a *Account) DailyFee(fee *big.Rat) (money.Money, error) {
+	if a.OverdraftUsed().IsZero() {
+		return money.Zero(a.Currency()), nil
+	}
+	unit, err := money.Parse("1", a.Currency())
+	if err != nil {
+		return money.Money{}, err
+	}
+	return unit.MulRat(fee, money.RoundHalfEven)
+}
+
+// InterestDue indica si corresponde cobrar los intereses del día indicado: la cuenta está sobregirada
+// y todavía no se le cobraron intereses por ese día ni por uno posterior.
+func (a *Account) InterestDue(day time.Time) bool {
+	return a.Balance.IsNegative() && (a.InterestAccruedOn.IsZero() || a.InterestAccruedOn.Before(day))
+}
@@ -1,14 +1,48 @@
 package account
 
+import (
+	"context" // Contexto de la operación: cancelación y plazos
+	"errors"  // Paquete para definir errores
+)
+
+// ErrVersionConflict indica que la cuenta fue modificada por otra operación después de ser leída,
+// por lo que la actualización se rechazó para no sobrescribir esos cambios.
+var ErrVersionConflict = errors.New("la cuenta fue modificada por otra operación")
+
+// ListFilter define los criterios de búsqueda y paginación para listar cuentas.
+type ListFilter struct {
+	Status    Status // Filtra por estado; vacío para incluir todos los estados
+	Overdrawn bool   // Incluye sólo las cuentas con balance negativo (sobregiro en uso)
+	Limit     int    // Cantidad máxima de cuentas a devolver
+	Offset    int    // Cantidad de cuentas a omitir (para paginar)
+}
+
 // Repository define las operaciones que un repositorio de cuentas debe implementar.
 // Este patrón de diseño se llama "Repository Pattern" y permite desacoplar la lógica de negocio
 // de la capa de persistencia (por ejemplo, una base de datos).
+// Todos los métodos reciben el contexto de la operación: si se cancela o vence su plazo,
+// la operación se interrumpe y retorna el error del contexto.
 type Repository interface {
-	// Save guarda una cuenta (Account) en el repositorio.
+	// Save guarda una cuenta (Account) nueva en el repositorio y le asigna su ID.
 	// Retorna un error si no se puede realizar la operación.
-	Save(a *Account) error
+	Save(ctx context.Context, a *Account) error
+
+	// Update persiste los cambios de una cuenta existente (por ejemplo, su balance o su estado).
+	// La actualización es condicional (compare-and-swap): sólo se aplica si la versión guardada
+	// coincide con a.Version, y en ese caso incrementa a.Version. Si otra operación modificó la
+	// cuenta después de leerla, retorna un error que envuelve ErrVersionConflict.
+	Update(ctx context.Context, a *Account) error
 
 	// FindByID busca una cuenta por su ID único.
-	// Retorna un puntero a la cuenta (Account) y un error si no se encuentra.
-	FindByID(id int) (*Account, error)
+	// Retorna un puntero a la cuenta (Account), o un error que envuelve ErrNotFound si no existe.
+	FindByID(ctx context.Context, id int) (*Account, error)
+
+	// FindByIDForUpdate busca una cuenta como FindByID para modificarla dentro de la misma unidad de trabajo.
+	// Los motores que lo soportan bloquean la cuenta hasta confirmar, de modo que las operaciones
+	// concurrentes sobre ella se esperan en lugar de fallar por conflicto de versión.
+	FindByIDForUpdate(ctx context.Context, id int) (*Account, error)
+
+	// List devuelve las cuentas que cumplen el filtro, ordenadas por ID,
+	// junto con el total de cuentas que lo cumplen (sin paginar).
+	List(ctx context.Context, filter ListFilter) ([]*Account, int, error)
 }
@@ -0,0 +1,100 @@
+package account
+
+import (
+	"errors" // Paquete para definir errores del dominio
+	"fmt"    // Paquete para formatear errores
+)
+
+// Status representa el estado del ciclo de vida de una cuenta.
+type Status string
+
+const (
+	StatusActive Status = "active" // La cuenta admite depósitos y retiros
+	StatusFrozen Status = "frozen" // La cuenta está bloqueada temporalmente; no admite movimientos
+	StatusClosed Status = "closed" // La cuenta fue cerrada definitivamente
+)
+
+// Errores del ciclo de vida de la cuenta.
+var (
+	// ErrAccountFrozen indica que la cuenta está congelada y no admite movimientos.
+	ErrAccountFrozen = errors.New("la cuenta está congelada")
+	// ErrAccountClosed indica que la cuenta está cerrada y no admite movimientos.
+	ErrAccountClosed = errors.New("la cuenta está cerrada")
+	// ErrInvalidStatus indica que el estado solicitado no existe.
+	ErrInvalidStatus = errors.New("estado de cuenta no válido")
+	// ErrBalanceNotZero indica que la cuenta no puede cerrarse porque su balance no es cero.
+	ErrBalanceNotZero = errors.New("la cuenta no puede cerrarse con balance distinto de cero")
+)
+
+// ParseStatus convierte un texto en un estado de cuenta válido.
+func ParseStatus(s string) (Status, error) {
+	switch status := Status(s); status {
+	case StatusActive, StatusFrozen, StatusClosed:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, s)
+	}
+}
+
+// Freeze congela la cuenta, impidiendo depósitos y retiros hasta que se reactive.
+// Una cuenta cerrada no puede congelarse.
+func (a *Account) Freeze() error {
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	a.Status = StatusFrozen
+	return nil
+}
+
+// Activate reactiva una cuenta congelada.
+// Una cuenta cerrada no puede reactivarse.
+func (a *Account) Activate() error {
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	a.Status = StatusActive
+	return nil
+}
+
+// Close cierra la cuenta de forma definitiva.
+// Sólo pueden cerrarse cuentas con balance cero y sin fondos retenidos que no estén ya cerradas.
+func (a *Account) Close() error {
+	if a.Status == StatusClosed {
+		return ErrAccountClosed
+	}
+	if !a.Balance.IsZero() {
+		return ErrBalanceNotZero
+	}
+	if held := a.HeldAmount(); !held.IsZero() {
+		return fmt.Errorf("%w: la cuenta tiene %s retenidos", ErrBalanceNotZero, held)
+	}
+	a.Status = StatusClosed
+	return nil
+}
+
+// ChangeStatus aplica la transición al estado indicado.
+func (a *Account) ChangeStatus(status Status) error {
+	switch status {
+	case StatusActive:
+		return a.Activate()
+	case StatusFrozen:
+		return a.Freeze()
+	case StatusClosed:
+		return a.Close()
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+}
+
+// checkOperable verifica que la cuenta admita movimientos de dinero.
+// Las cuentas sin estado explícito se consideran activas.
+func (a *Account) checkOperable() error {
+	switch a.Status {
+	case StatusFrozen:
+		return ErrAccountFrozen
+	case StatusClosed:
+		return ErrAccountClosed
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+package fx_test
+
+import (
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// mustPair crea un par de monedas válido.
+func mustPair(t *testing.T, base, quote string) fx.Pair {
+	t.Helper()
+	pair, err := fx.NewPair(base, quote)
+	if err != nil {
+		t.Fatalf("Par inválido %s/%s: %v", base, quote, err)
+	}
+	return pair
+}
+
+// Prueba la validación de los pares de monedas
+func TestNewPair(t *testing.T) {
+	if pair, err := fx.ParsePair("usd/eur"); err != nil || pair.String() != "USD/EUR" {
+		t.Errorf("Par incorrecto: obtenido %v (err: %v)", pair, err)
+	}
+	if _, err := fx.NewPair("USD", "USD"); !errors.Is(err, fx.ErrSameCurrency) {
+		t.Errorf("Se esperaba ErrSameCurrency, obtenido %v", err)
+	}
+	if _, err := fx.NewPair("USD", "XXX"); !errors.Is(err, money.ErrUnknownCurrency) {
+		t.Errorf("Se esperaba ErrUnknownCurrency, obtenido %v", err)
+	}
+	if _, err := fx.ParsePair("USDEUR"); err == nil {
+		t.Error("Se esperaba un error para un par sin separador")
+	}
+}
+
+// Prueba la interpretación de los tipos de cambio
+func TestParseRateValue(t *testing.T) {
+	valid := map[string]string{"0.92": "0.92", "3950.50": "3950.5", "1": "1", "0.0000000001": "0.0000000001"}
+	for input, want := range valid {
+		v, err := fx.ParseRateValue(input)
+		if err != nil {
+			t.Errorf("%q: error inesperado: %v", input, err)
+			continue
+		}
+		if got := fx.FormatRate(v); got != want {
+			t.Errorf("%q: obtenido %s, esperado %s", input, got, want)
+		}
+	}
+	for _, input := range []string{"", "0", "-1", "1e3", "1/3", "abc", "0.00000000001"} {
+		if _, err := fx.ParseRateValue(input); !errors.Is(err, fx.ErrInvalidRate) {
+			t.Errorf("%q: se esperaba ErrInvalidRate, obtenido %v", input, err)
+		}
+	}
+}
+
+// Prueba que la cadena de proveedores respeta la prioridad y deriva los pares inversos
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	static, err := fx.NewStaticProvider(map[string]string{"USD/EUR": "0.92", "USD/JPY": "150"}, time.Now())
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	manual, _ := fx.NewStaticProvider(map[string]string{"USD/EUR": "0.95"}, time.Now())
+	chain := fx.Chain{manual, static}
+
+	// El primer proveedor tiene prioridad
+	rate, err := chain.Rate(ctx, mustPair(t, "USD", "EUR"))
+	if err != nil || rate.Decimal() != "0.95" {
+		t.Errorf("Se esperaba el tipo del primer proveedor (0.95), obtenido %v (err: %v)", rate.Value, err)
+	}
+
+	// El par inverso se deriva redondeando hacia abajo a 10 decimales
+	rate, err = chain.Rate(ctx, mustPair(t, "JPY", "USD"))
+	if err != nil || rate.Decimal() != "0.0066666666" || rate.Pair.String() != "JPY/USD" {
+		t.Errorf("Inverso incorrecto: obtenido %v %s (err: %v)", rate.Pair, rate.Decimal(), err)
+	}
+
+	if _, err := chain.Rate(ctx, mustPair(t, "GBP", "BRL")); !errors.Is(err, fx.ErrRateNotFound) {
+		t.Errorf("Se esperaba ErrRateNotFound, obtenido %v", err)
+	}
+
+	// El listado no repite pares y respeta la prioridad
+	rates, _ := chain.Rates(ctx)
+	if len(rates) != 2 || rates[0].Decimal() != "0.95" {
+		t.Errorf("Listado incorrecto: %v", rates)
+	}
+}
+
+// Prueba que una tabla estática inválida se rechaza
+func TestNewStaticProvider_Invalid(t *testing.T) {
+	for _, table := range []map[string]string{
+		{"USD/EUR": "0"},
+		{"USD/USD": "1"},
+		{"USD-EUR": "0.92"},
+	} {
+		if _, err := fx.NewStaticProvider(table, time.Now()); err == nil {
+			t.Errorf("Se esperaba un error para la tabla %v", table)
+		}
+	}
+}
+
+// Prueba la emisión de una cotización con margen y redondeo hacia abajo
+func TestNewQuote(t *testing.T) {
+	now := time.Now()
+	rate, _ := fx.NewRate(mustPair(t, "USD", "JPY"), "149.80", fx.SourceStatic, now)
+	spread := big.NewRat(25, 10000) // 0,25 %
+
+	quote, err := fx.NewQuote(rate, money.MustParse("100.00", "USD"), spread, 30*time.Second, now)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	// 149.80 * (1 - 0.0025) = 149.4255; 100 USD -> 14942.55 JPY, redondeado hacia abajo a 14942
+	if fx.FormatRate(quote.Rate) != "149.4255" {
+		t.Errorf("Tipo aplicado incorrecto: %s", fx.FormatRate(quote.Rate))
+	}
+	if quote.Target != money.MustParse("14942", "JPY") {
+		t.Errorf("Monto de destino incorrecto: %v", quote.Target)
+	}
+	if quote.ID == "" || !quote.ExpiresAt.Equal(now.Add(30*time.Second)) {
+		t.Errorf("Cotización incompleta: %+v", quote)
+	}
+
+	// La cotización sólo sirve para el monto y las monedas cotizadas, y hasta su vencimiento
+	if err := quote.Check(money.MustParse("100.00", "USD"), "JPY", now); err != nil {
+		t.Errorf("Error inesperado: %v", err)
+	}
+	if err := quote.Check(money.MustParse("99.99", "USD"), "JPY", now); !errors.Is(err, fx.ErrQuoteMismatch) {
+		t.Errorf("Se esperaba ErrQuoteMismatch por el monto, obtenido %v", err)
+	}
+	if err := quote.Check(money.MustParse("100.00", "USD"), "EUR", now); !errors.Is(err, fx.ErrQuoteMismatch) {
+		t.Errorf("Se esperaba ErrQuoteMismatch por la moneda, obtenido %v", err)
+	}
+	if err := quote.Check(money.MustParse("100.00", "USD"), "JPY", now.Add(30*time.Second)); !errors.Is(err, fx.ErrQuoteExpired) {
+		t.Errorf("Se esperaba ErrQuoteExpired, obtenido %v", err)
+	}
+}
+
+// Prueba los errores al emitir una cotización
+func TestNewQuote_Errors(t *testing.T) {
+	now := time.Now()
+	rate, _ := fx.NewRate(mustPair(t, "JPY", "KWD"), "0.0002", fx.SourceManual, now) // 1 JPY = 0,0002 KWD, menos de un fils
+
+	if _, err := fx.NewQuote(rate, money.MustParse("1", "JPY"), new(big.Rat), time.Minute, now); !errors.Is(err, fx.ErrAmountTooSmall) {
+		t.Errorf("Se esperaba ErrAmountTooSmall, obtenido %v", err)
+	}
+	if _, err := fx.NewQuote(rate, money.MustParse("1.00", "USD"), new(big.Rat), time.Minute, now); !errors.Is(err, fx.ErrQuoteMismatch) {
+		t.Errorf("Se esperaba ErrQuoteMismatch, obtenido %v", err)
+	}
+	if _, err := fx.NewQuote(rate, money.MustParse("1000", "JPY"), big.NewRat(1, 1), time.Minute, now); !errors.Is(err, fx.ErrInvalidSpread) {
+		t.Errorf("Se esperaba ErrInvalidSpread, obtenido %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package fx
+
+import (
+	"context" // Contexto de la operación: cancelación y plazos
+	"errors"  // Detección de ErrRateNotFound
+	"fmt"     // Paquete para formatear errores
+	"slices"  // Orden de los listados
+	"strings" // Comparación de los pares
+	"time"    // Paquete para manejar fechas y horas
+)
+
+// Provider obtiene el tipo de cambio vigente de un par de monedas.
+type Provider interface {
+	// Rate devuelve el tipo de cambio directo del par (sin invertir otros pares).
+	// Retorna ErrRateNotFound si el proveedor no conoce el par.
+	Rate(ctx context.Context, pair Pair) (Rate, error)
+
+	// Rates devuelve todos los tipos de cambio que conoce el proveedor.
+	Rates(ctx context.Context) ([]Rate, error)
+}
+
+// StaticProvider es un proveedor de sólo lectura con una tabla fija de tipos de cambio,
+// normalmente cargada desde un archivo al iniciar el servicio.
+type StaticProvider struct {
+	rates map[Pair]Rate // Tipos de cambio por par
+}
+
+// Aseguramos que StaticProvider implementa la interfaz Provider.
+var _ Provider = &StaticProvider{}
+
+// NewStaticProvider crea un proveedor con la tabla indicada, cuyas claves son pares "USD/EUR"
+// y cuyos valores son tipos de cambio decimales ("0.92"). Todos los tipos se fechan en loadedAt.
+// Retorna un error que indica el par inválido si alguna entrada no es válida.
+func NewStaticProvider(table map[string]string, loadedAt time.Time) (*StaticProvider, error) {
+	p := &StaticProvider{rates: make(map[Pair]Rate, len(table))}
+	for key, value := range table {
+		pair, err := ParsePair(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := p.rates[pair]; dup {
+			return nil, fmt.Errorf("el par %s está repetido", pair)
+		}
+		rate, err := NewRate(pair, value, SourceStatic, loadedAt)
+		if err != nil {
+			return nil, fmt.Errorf("par %s: %w", pair, err)
+		}
+		p.rates[pair] = rate
+	}
+	return p, nil
+}
+
+// Rate devuelve el tipo de cambio del par, o ErrRateNotFound si no está en la tabla.
+func (p *StaticProvider) Rate(_ context.Context, pair Pair) (Rate, error) {
+	rate, ok := p.rates[pair]
+	if !ok {
+		return Rate{}, ErrRateNotFound
+	}
+	return rate, nil
+}
+
+// Rates devuelve la tabla completa ordenada por par.
+func (p *StaticProvider) Rates(context.Context) ([]Rate, error) {
+	rates := make([]Rate, 0, len(p.rates))
+	for _, rate := range p.rates {
+		rates = append(rates, rate)
+	}
+	SortRates(rates)
+	return rates, nil
+}
+
+// Chain combina varios proveedores por orden de prioridad: el primero que conoce el par gana.
+// Si ningún proveedor conoce el par directo, se usa el inverso del primer proveedor que conozca
+// el par opuesto; así basta con publicar USD/EUR para cotizar también EUR/USD.
+type Chain []Provider
+
+// Aseguramos que Chain implementa la interfaz Provider.
+var _ Provider = Chain{}
+
+// Rate devuelve el tipo de cambio del par según la prioridad de los proveedores.
+// Retorna ErrRateNotFound si ningún proveedor conoce el par ni su opuesto.
+func (c Chain) Rate(ctx context.Context, pair Pair) (Rate, error) {
+	for _, p := range c {
+		rate, err := p.Rate(ctx, pair)
+		if !errors.Is(err, ErrRateNotFound) {
+			return rate, err
+		}
+	}
+	for _, p := range c {
+		rate, err := p.Rate(ctx, pair.Inverse())
+		if err == nil {
+			return rate.Inverse(), nil
+		}
+		if !errors.Is(err, ErrRateNotFound) {
+			return Rate{}, err
+		}
+	}
+	return Rate{}, fmt.Errorf("%w: %s", ErrRateNotFound, pair)
+}
+
+// Rates devuelve los tipos de cambio publicados por los proveedores, sin pares inversos derivados.
+// Si varios proveedores publican el mismo par sólo se incluye el de mayor prioridad.
+func (c Chain) Rates(ctx context.Context) ([]Rate, error) {
+	seen := map[Pair]bool{}
+	var rates []Rate
+	for _, p := range c {
+		found, err := p.Rates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, rate := range found {
+			if !seen[rate.Pair] {
+				seen[rate.Pair] = true
+				rates = append(rates, rate)
+			}
+		}
+	}
+	SortRates(rates)
+	return rates, nil
+}
+
+// SortRates ordena los tipos de cambio por moneda base y luego por moneda cotizada.
+func SortRates(rates []Rate) {
+	slices.SortFunc(rates, func(a, b Rate) int {
+		return strings.Compare(a.Pair.String(), b.Pair.String())
+	})
+}
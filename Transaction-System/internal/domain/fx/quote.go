@@ -0,0 +1,114 @@
+package fx
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"errors"                                   // Paquete para definir errores del dominio
+	"fmt"                                      // Paquete para formatear errores
+	"math/big"                                 // Tipos de cambio exactos
+	"time"                                     // Paquete para manejar fechas y horas
+
+	"github.com/google/uuid" // Generador del identificador de la cotización
+)
+
+// Errores de las cotizaciones.
+var (
+	// ErrQuoteNotFound indica que la cotización no existe.
+	ErrQuoteNotFound = errors.New("cotización no encontrada")
+	// ErrQuoteExpired indica que la cotización venció y ya no puede usarse.
+	ErrQuoteExpired = errors.New("la cotización venció")
+	// ErrQuoteMismatch indica que la operación no coincide con el monto o las monedas de la cotización.
+	ErrQuoteMismatch = errors.New("la operación no coincide con la cotización")
+	// ErrQuoteUsed indica que la cotización ya liquidó una transferencia; cada cotización se usa una sola vez.
+	ErrQuoteUsed = errors.New("la cotización ya fue utilizada")
+	// ErrAmountTooSmall indica que el monto convertido, una vez redondeado, es cero.
+	ErrAmountTooSmall = errors.New("el monto es demasiado pequeño para convertirlo")
+	// ErrInvalidSpread indica que el margen no está entre 0 y 1 (exclusivo).
+	ErrInvalidSpread = errors.New("margen de cambio inválido")
+)
+
+// Quote es una cotización de cambio: fija durante un plazo el tipo aplicado a la conversión de un monto.
+// El tipo aplicado es el tipo de mercado menos el margen del banco, redondeado hacia abajo, y el monto de
+// destino también se redondea hacia abajo: el cliente nunca recibe más de lo que indica la cotización.
+type Quote struct {
+	ID         string      // Identificador único de la cotización (UUID)
+	Source     money.Money // Monto que se debita, en la moneda de origen
+	Target     money.Money // Monto que se acredita, en la moneda de destino
+	MidRate    *big.Rat    // Tipo de cambio de mercado usado como referencia
+	Spread     *big.Rat    // Margen aplicado como fracción del tipo de mercado (por ejemplo 0.0025)
+	Rate       *big.Rat    // Tipo de cambio aplicado: MidRate * (1 - Spread)
+	RateSource string      // Origen del tipo de cambio de mercado (SourceStatic o SourceManual)
+	CreatedAt  time.Time   // Fecha de emisión de la cotización
+	ExpiresAt  time.Time   // Fecha a partir de la cual la cotización deja de ser válida
+}
+
+// NewQuote emite una cotización para convertir amount a la moneda cotizada del tipo de cambio.
+// Parámetros:
+//   - rate: tipo de cambio de mercado; su moneda base debe ser la del monto
+//   - amount: monto a convertir; debe ser positivo
+//   - spread: margen del banco como fracción del tipo de mercado, en el intervalo [0, 1)
+//   - ttl: plazo de validez de la cotización
+//   - now: instante de emisión
+//
+// Retorna ErrQuoteMismatch si el monto no está en la moneda base, ErrInvalidSpread si el margen no es válido
+// y ErrAmountTooSmall si el monto convertido es cero.
+func NewQuote(rate Rate, amount money.Money, spread *big.Rat, ttl time.Duration, now time.Time) (*Quote, error) {
+	if amount.Currency() != rate.Pair.Base {
+		return nil, fmt.Errorf("%w: el monto está en %s y el tipo de cambio es %s", ErrQuoteMismatch, amount.Currency(), rate.Pair)
+	}
+	if spread.Sign() < 0 || spread.Cmp(big.NewRat(1, 1)) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSpread, spread.FloatString(4))
+	}
+
+	// Aplicar el margen sobre el tipo de mercado y convertir el monto redondeando hacia abajo
+	applied := new(big.Rat).Sub(big.NewRat(1, 1), spread)
+	applied = truncateRate(applied.Mul(applied, rate.Value))
+	target, err := amount.Convert(rate.Pair.Quote, applied, money.RoundDown)
+	if err != nil {
+		return nil, err
+	}
+	if !target.IsPositive() {
+		return nil, ErrAmountTooSmall
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo generar el identificador de la cotización: %w", err)
+	}
+	return &Quote{
+		ID:         id.String(),
+		Source:     amount,
+		Target:     target,
+		MidRate:    rate.Value,
+		Spread:     spread,
+		Rate:       applied,
+		RateSource: rate.Source,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	}, nil
+}
+
+// Pair devuelve el par de monedas de la cotización.
+func (q *Quote) Pair() Pair {
+	return Pair{Base: q.Source.Currency(), Quote: q.Target.Currency()}
+}
+
+// Expired indica si la cotización ya venció en el instante indicado.
+func (q *Quote) Expired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// Check verifica que la cotización pueda usarse en el instante indicado para debitar amount
+// y acreditar en la moneda targetCurrency.
+// Retorna ErrQuoteExpired si venció y ErrQuoteMismatch si el monto o las monedas no coinciden.
+func (q *Quote) Check(amount money.Money, targetCurrency string, now time.Time) error {
+	if q.Expired(now) {
+		return fmt.Errorf("%w: la cotización %s venció el %s", ErrQuoteExpired, q.ID, q.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if amount != q.Source {
+		return fmt.Errorf("%w: la cotización %s es por %s y la operación por %s", ErrQuoteMismatch, q.ID, q.Source, amount)
+	}
+	if targetCurrency != q.Target.Currency() {
+		return fmt.Errorf("%w: la cotización %s acredita %s y la cuenta de destino opera en %s", ErrQuoteMismatch, q.ID, q.Target.Currency(), targetCurrency)
+	}
+	return nil
+}
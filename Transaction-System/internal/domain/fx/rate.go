@@ -0,0 +1,140 @@
+// Package fx modela el cambio de divisas: tipos de cambio, proveedores de tipos de cambio y cotizaciones.
+// Una transferencia entre cuentas de distinta moneda se liquida con una cotización previa, que fija el
+// tipo de cambio aplicado (con el margen del banco) durante un plazo limitado.
+package fx
+
+import (
+	"Transaction-System/internal/domain/money" // Validación de las monedas del par
+	"errors"                                   // Paquete para definir errores del dominio
+	"fmt"                                      // Paquete para formatear errores
+	"math/big"                                 // Tipos de cambio exactos
+	"strings"                                  // Interpretación de los pares "USD/EUR"
+	"time"                                     // Paquete para manejar fechas y horas
+)
+
+// RateDecimals es la cantidad máxima de decimales de un tipo de cambio.
+const RateDecimals = 10
+
+// Orígenes de un tipo de cambio.
+const (
+	SourceStatic = "static" // Tabla estática cargada desde un archivo al iniciar el servicio
+	SourceManual = "manual" // Tipo fijado manualmente por un administrador
+)
+
+// Errores del dominio de cambio de divisas.
+var (
+	// ErrInvalidRate indica que el tipo de cambio no es un decimal positivo válido.
+	ErrInvalidRate = errors.New("tipo de cambio inválido")
+	// ErrSameCurrency indica que el par de monedas no es válido porque ambas son iguales.
+	ErrSameCurrency = errors.New("las monedas del par deben ser distintas")
+	// ErrRateNotFound indica que no hay un tipo de cambio para el par de monedas.
+	ErrRateNotFound = errors.New("no hay tipo de cambio para el par de monedas")
+)
+
+// Pair es un par de monedas: Base es la moneda que se vende y Quote la que se recibe.
+type Pair struct {
+	Base  string // Moneda de origen (por ejemplo "USD")
+	Quote string // Moneda de destino (por ejemplo "EUR")
+}
+
+// NewPair valida y normaliza un par de monedas.
+// Retorna money.ErrUnknownCurrency si alguna moneda no está soportada y ErrSameCurrency si son iguales.
+func NewPair(base, quote string) (Pair, error) {
+	b, err := money.New(0, base)
+	if err != nil {
+		return Pair{}, err
+	}
+	q, err := money.New(0, quote)
+	if err != nil {
+		return Pair{}, err
+	}
+	if b.Currency() == q.Currency() {
+		return Pair{}, ErrSameCurrency
+	}
+	return Pair{Base: b.Currency(), Quote: q.Currency()}, nil
+}
+
+// ParsePair interpreta un par escrito como "USD/EUR".
+func ParsePair(s string) (Pair, error) {
+	base, quote, ok := strings.Cut(s, "/")
+	if !ok {
+		return Pair{}, fmt.Errorf("par de monedas inválido %q: se espera el formato BASE/COTIZADA", s)
+	}
+	return NewPair(strings.TrimSpace(base), strings.TrimSpace(quote))
+}
+
+// Inverse devuelve el par opuesto.
+func (p Pair) Inverse() Pair {
+	return Pair{Base: p.Quote, Quote: p.Base}
+}
+
+// String devuelve el par con el formato "USD/EUR".
+func (p Pair) String() string {
+	return p.Base + "/" + p.Quote
+}
+
+// Rate es el tipo de cambio de un par: cuántas unidades de Quote se obtienen por una unidad de Base.
+type Rate struct {
+	Pair      Pair      // Par de monedas
+	Value     *big.Rat  // Tipo de cambio exacto; siempre positivo
+	Source    string    // Origen del tipo de cambio (SourceStatic o SourceManual)
+	UpdatedAt time.Time // Fecha en que se fijó el tipo de cambio
+}
+
+// NewRate crea un tipo de cambio a partir de su representación decimal (por ejemplo "0.9215").
+// Retorna ErrInvalidRate si el valor no es un decimal positivo con a lo sumo RateDecimals decimales.
+func NewRate(pair Pair, value, source string, updatedAt time.Time) (Rate, error) {
+	v, err := ParseRateValue(value)
+	if err != nil {
+		return Rate{}, err
+	}
+	return Rate{Pair: pair, Value: v, Source: source, UpdatedAt: updatedAt}, nil
+}
+
+// ParseRateValue interpreta un tipo de cambio decimal.
+// Sólo se aceptan decimales simples (sin exponente ni fracciones) positivos con a lo sumo RateDecimals decimales.
+func ParseRateValue(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, "eE/+-") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	if _, frac, ok := strings.Cut(s, "."); ok && len(frac) > RateDecimals {
+		return nil, fmt.Errorf("%w: %q tiene más de %d decimales", ErrInvalidRate, s, RateDecimals)
+	}
+	v, ok := new(big.Rat).SetString(s)
+	if !ok || v.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	return v, nil
+}
+
+// Inverse devuelve el tipo de cambio del par opuesto, redondeado hacia abajo a RateDecimals decimales.
+func (r Rate) Inverse() Rate {
+	return Rate{
+		Pair:      r.Pair.Inverse(),
+		Value:     truncateRate(new(big.Rat).Inv(r.Value)),
+		Source:    r.Source,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Decimal devuelve el tipo de cambio como texto decimal sin ceros finales innecesarios (por ejemplo "0.92").
+func (r Rate) Decimal() string {
+	return FormatRate(r.Value)
+}
+
+// FormatRate formatea un tipo de cambio con a lo sumo RateDecimals decimales, sin ceros finales.
+func FormatRate(v *big.Rat) string {
+	s := v.FloatString(RateDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// truncateRate redondea un tipo de cambio hacia abajo a RateDecimals decimales.
+// Redondear hacia abajo garantiza que el banco nunca entregue más de lo que indica el tipo exacto.
+func truncateRate(v *big.Rat) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(RateDecimals), nil)
+	n := new(big.Int).Mul(v.Num(), scale)
+	n.Quo(n, v.Denom())
+	return new(big.Rat).SetFrac(n, scale)
+}
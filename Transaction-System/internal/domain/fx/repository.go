@@ -0,0 +1,28 @@
+package fx
+
+import "context" // Contexto de la operación: cancelación y plazos
+
+// RateStore es un proveedor cuyos tipos de cambio fija un administrador.
+// Sus tipos tienen prioridad sobre la tabla estática y se conservan entre reinicios.
+type RateStore interface {
+	Provider
+
+	// Set guarda el tipo de cambio del par, reemplazando el anterior si existía.
+	Set(ctx context.Context, rate Rate) error
+
+	// Delete elimina el tipo de cambio del par.
+	// Retorna ErrRateNotFound si el par no tenía un tipo fijado.
+	Delete(ctx context.Context, pair Pair) error
+}
+
+// QuoteRepository define las operaciones que un repositorio de cotizaciones debe implementar.
+// Las cotizaciones no se eliminan al vencer: las transacciones liquidadas con ellas las referencian
+// y se conservan como registro de auditoría del tipo de cambio aplicado.
+type QuoteRepository interface {
+	// Save guarda una cotización recién emitida.
+	Save(ctx context.Context, q *Quote) error
+
+	// FindByID busca una cotización por su identificador.
+	// Retorna ErrQuoteNotFound si no existe.
+	FindByID(ctx context.Context, id string) (*Quote, error)
+}
@@ -0,0 +1,140 @@
+package hold
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"errors"                                   // Paquete para definir errores del dominio
+	"fmt"                                      // Paquete para formatear errores
+	"time"                                     // Paquete para manejar fechas y horas
+
+	"github.com/google/uuid" // Generador del identificador de la retención
+)
+
+// Status representa el estado de una retención de fondos.
+type Status string
+
+const (
+	StatusActive   Status = "active"   // La retención reserva fondos a la espera de su captura
+	StatusCaptured Status = "captured" // La retención se capturó, total o parcialmente; el resto se liberó
+	StatusReleased Status = "released" // La retención se liberó sin capturarse
+	StatusExpired  Status = "expired"  // La retención venció sin capturarse y sus fondos se liberaron
+)
+
+// Errores de las retenciones de fondos.
+var (
+	// ErrNotFound indica que la retención no existe.
+	ErrNotFound = errors.New("retención no encontrada")
+	// ErrNotActive indica que la retención ya fue capturada, liberada o venció.
+	ErrNotActive = errors.New("la retención ya no está activa")
+	// ErrExpired indica que la retención venció y ya no puede capturarse.
+	ErrExpired = errors.New("la retención venció")
+	// ErrCaptureExceedsHold indica que el monto a capturar supera el monto retenido.
+	ErrCaptureExceedsHold = errors.New("el monto a capturar supera el monto retenido")
+)
+
+// Hold es una retención de fondos: una autorización que reserva un monto de la cuenta sin registrar
+// un movimiento. Mientras está activa reduce el disponible de la cuenta pero no su balance contable.
+// Se cierra de una de tres formas: se captura (total o parcialmente, una sola vez) y el monto capturado
+// se debita de la cuenta, se libera, o vence al llegar ExpiresAt sin capturarse.
+type Hold struct {
+	ID            string      // Identificador único de la retención (UUID)
+	AccountID     int         // Cuenta cuyos fondos se retienen
+	Amount        money.Money // Monto autorizado y retenido, en la moneda de la cuenta
+	Captured      money.Money // Monto capturado; cero si la retención no se capturó
+	Reference     string      // Referencia del comercio u origen de la autorización (opcional)
+	Status        Status      // Estado de la retención
+	TransactionID int         // Transacción "capture" con la que se debitó el monto capturado; cero si no aplica
+	CreatedAt     time.Time   // Fecha de la autorización
+	ExpiresAt     time.Time   // Fecha a partir de la cual la retención vence si no se capturó
+	ClosedAt      time.Time   // Fecha en que se capturó, liberó o venció; cero mientras está activa
+}
+
+// New autoriza una retención activa de amount sobre la cuenta, vigente durante ttl desde now.
+func New(accountID int, amount money.Money, reference string, ttl time.Duration, now time.Time) (*Hold, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo generar el identificador de la retención: %w", err)
+	}
+	return &Hold{
+		ID:        id.String(),
+		AccountID: accountID,
+		Amount:    amount,
+		Captured:  money.Zero(amount.Currency()),
+		Reference: reference,
+		Status:    StatusActive,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// Expired indica si la retención sigue activa pero ya venció en el instante now.
+func (h *Hold) Expired(now time.Time) bool {
+	return h.Status == StatusActive && !now.Before(h.ExpiresAt)
+}
+
+// Remaining devuelve el monto retenido que no se capturó: todo el monto si no se capturó nada.
+func (h *Hold) Remaining() money.Money {
+	remaining, err := h.Amount.Sub(h.Captured)
+	if err != nil {
+		return h.Amount
+	}
+	return remaining
+}
+
+// Capture captura amount, que puede ser menor o igual al monto retenido; el resto se libera.
+// Retorna ErrNotActive si la retención ya se cerró, ErrExpired si venció, ErrCaptureExceedsHold si el
+// monto supera el retenido y money.ErrCurrencyMismatch si está en otra moneda.
+func (h *Hold) Capture(amount money.Money, now time.Time) error {
+	if err := h.checkActive(); err != nil {
+		return err
+	}
+	if h.Expired(now) {
+		return fmt.Errorf("%w el %s", ErrExpired, h.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	cmp, err := amount.Cmp(h.Amount)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("%w: se capturan %s de %s retenidos", ErrCaptureExceedsHold, amount, h.Amount)
+	}
+	h.Captured = amount
+	h.close(StatusCaptured, now)
+	return nil
+}
+
+// Release libera la retención sin capturarla. Una retención vencida que aún no se procesó también
+// puede liberarse. Retorna ErrNotActive si la retención ya se cerró.
+func (h *Hold) Release(now time.Time) error {
+	if err := h.checkActive(); err != nil {
+		return err
+	}
+	h.close(StatusReleased, now)
+	return nil
+}
+
+// Expire marca como vencida una retención activa cuyo plazo terminó.
+// Retorna ErrNotActive si la retención ya se cerró o si todavía no venció.
+func (h *Hold) Expire(now time.Time) error {
+	if err := h.checkActive(); err != nil {
+		return err
+	}
+	if !h.Expired(now) {
+		return fmt.Errorf("%w: vence el %s", ErrNotActive, h.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	h.close(StatusExpired, now)
+	return nil
+}
+
+// checkActive verifica que la retención siga activa.
+func (h *Hold) checkActive() error {
+	if h.Status != StatusActive {
+		return fmt.Errorf("%w: está %s", ErrNotActive, h.Status)
+	}
+	return nil
+}
+
+// close cierra la retención con el estado indicado.
+func (h *Hold) close(status Status, now time.Time) {
+	h.Status = status
+	h.ClosedAt = now
+}
@@ -0,0 +1,29 @@
+package hold
+
+import (
+	"context" // Contexto de la operación: cancelación y plazos
+	"time"    // Instante de corte de las retenciones vencidas
+)
+
+// Repository define las operaciones que un repositorio de retenciones debe implementar.
+// Las retenciones cerradas no se eliminan: conservan el monto autorizado y el capturado como registro.
+type Repository interface {
+	// Save guarda una retención recién autorizada.
+	Save(ctx context.Context, h *Hold) error
+
+	// Update guarda el nuevo estado de una retención.
+	// Retorna ErrNotFound si no existe.
+	Update(ctx context.Context, h *Hold) error
+
+	// FindByID busca una retención por su identificador.
+	// Retorna ErrNotFound si no existe.
+	FindByID(ctx context.Context, id string) (*Hold, error)
+
+	// FindByIDForUpdate busca una retención como FindByID para modificarla dentro de la misma unidad de
+	// trabajo. Los motores que lo soportan la bloquean hasta confirmar.
+	FindByIDForUpdate(ctx context.Context, id string) (*Hold, error)
+
+	// FindExpired devuelve hasta limit retenciones activas cuyo plazo venció antes de now (inclusive),
+	// de la que venció primero a la última.
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*Hold, error)
+}
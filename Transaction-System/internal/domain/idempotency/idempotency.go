@@ -0,0 +1,48 @@
+package idempotency
+
+import (
+	"errors" // Paquete para definir errores del dominio
+	"time"   // Paquete para manejar fechas y horas
+)
+
+// Errores del repositorio de claves de idempotencia.
+var (
+	// ErrKeyExists indica que la clave ya fue registrada por otra solicitud.
+	ErrKeyExists = errors.New("la clave de idempotencia ya existe")
+	// ErrNotFound indica que la clave no está registrada.
+	ErrNotFound = errors.New("clave de idempotencia no encontrada")
+)
+
+// Record representa una solicitud registrada bajo una clave de idempotencia (cabecera Idempotency-Key).
+// Mientras la solicitud está en curso StatusCode vale cero; al completarse se guarda la respuesta
+// para devolverla tal cual ante cualquier reintento con la misma clave.
+type Record struct {
+	Key          string    // Valor de la cabecera Idempotency-Key
+	Fingerprint  string    // Huella (hash) del método, la ruta y el cuerpo de la solicitud original
+	StatusCode   int       // Código de estado HTTP de la respuesta guardada (0 si está en curso)
+	ContentType  string    // Tipo de contenido de la respuesta guardada
+	ResponseBody []byte    // Cuerpo de la respuesta guardada
+	CreatedAt    time.Time // Fecha en que se registró la clave
+	ExpiresAt    time.Time // Fecha a partir de la cual la clave deja de tener efecto
+}
+
+// NewRecord crea el registro de una solicitud en curso que expira tras el periodo de retención.
+func NewRecord(key, fingerprint string, retention time.Duration) *Record {
+	now := time.Now()
+	return &Record{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(retention),
+	}
+}
+
+// Completed indica si la solicitud original ya terminó y su respuesta está guardada.
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Expired indica si la clave ya superó su periodo de retención en el instante indicado.
+func (r *Record) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,27 @@
+package idempotency
+
+import (
+	"context" // Contexto de la operación: cancelación y plazos
+	"time"    // Paquete para manejar fechas
+)
+
+// Repository define las operaciones que un repositorio de claves de idempotencia debe implementar.
+type Repository interface {
+	// Reserve registra una clave para una solicitud en curso.
+	// Retorna ErrKeyExists si la clave ya está registrada.
+	Reserve(ctx context.Context, r *Record) error
+
+	// Find busca el registro de una clave.
+	// Retorna ErrNotFound si la clave no está registrada.
+	Find(ctx context.Context, key string) (*Record, error)
+
+	// Complete guarda la respuesta de la solicitud asociada a la clave.
+	Complete(ctx context.Context, r *Record) error
+
+	// Delete elimina una clave, permitiendo que una nueva solicitud la vuelva a usar.
+	Delete(ctx context.Context, key string) error
+
+	// DeleteExpired elimina las claves cuyo periodo de retención terminó antes del instante indicado.
+	// Retorna la cantidad de claves eliminadas.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,57 @@
+package ledger_test
+
+import (
+	"Transaction-System/internal/domain/ledger"
+	"Transaction-System/internal/domain/money"
+	"errors"
+	"testing"
+)
+
+// Prueba que un asiento con débitos y créditos iguales es válido
+func TestValidate_Balanced(t *testing.T) {
+	amount := money.MustParse("25.00", "USD")
+	entry := ledger.NewEntry("transaction:1", "Depósito").
+		Debit(ledger.Cash, amount).
+		Credit(ledger.CustomerAccount(1), amount)
+
+	if err := entry.Validate(); err != nil {
+		t.Errorf("El asiento debería ser válido: %v", err)
+	}
+}
+
+// Prueba que un asiento cuya suma no es cero es rechazado
+func TestValidate_Unbalanced(t *testing.T) {
+	entry := ledger.NewEntry("transaction:1", "Depósito").
+		Debit(ledger.Cash, money.MustParse("25.00", "USD")).
+		Credit(ledger.CustomerAccount(1), money.MustParse("20.00", "USD"))
+
+	if err := entry.Validate(); !errors.Is(err, ledger.ErrUnbalancedEntry) {
+		t.Errorf("Se esperaba ErrUnbalancedEntry, obtenido %v", err)
+	}
+}
+
+// Prueba que un asiento con un único movimiento o con monedas mezcladas es inválido
+func TestValidate_Invalid(t *testing.T) {
+	single := ledger.NewEntry("x", "Un solo movimiento").Debit(ledger.Cash, money.MustParse("1.00", "USD"))
+	if err := single.Validate(); !errors.Is(err, ledger.ErrInvalidEntry) {
+		t.Errorf("Se esperaba ErrInvalidEntry, obtenido %v", err)
+	}
+
+	mixed := ledger.NewEntry("x", "Monedas mezcladas").
+		Debit(ledger.Cash, money.MustParse("1.00", "USD")).
+		Credit(ledger.CustomerAccount(1), money.MustParse("1.00", "EUR"))
+	if err := mixed.Validate(); !errors.Is(err, ledger.ErrInvalidEntry) {
+		t.Errorf("Se esperaba ErrInvalidEntry, obtenido %v", err)
+	}
+}
+
+// Prueba que el saldo de una cuenta de cliente (acreedora) se deriva con el signo correcto
+func TestBalanceFromSum(t *testing.T) {
+	sum := money.MustParse("-40.00", "USD") // Créditos netos de 40.00
+	if got := ledger.CustomerAccount(1).BalanceFromSum(sum); got != money.MustParse("40.00", "USD") {
+		t.Errorf("Saldo de cliente incorrecto: obtenido %v, esperado 40.00 USD", got)
+	}
+	if got := ledger.Cash.BalanceFromSum(sum); got != sum {
+		t.Errorf("Saldo de caja incorrecto: obtenido %v, esperado %v", got, sum)
+	}
+}
@@ -0,0 +1,136 @@
+package ledger
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"errors"                                   // Paquete para definir errores del dominio
+	"fmt"                                      // Paquete para formatear errores y códigos
+	"time"                                     // Paquete para manejar fechas y horas
+)
+
+// Errores de validación de los asientos contables.
+var (
+	// ErrUnbalancedEntry indica que la suma de los movimientos de un asiento no es cero.
+	ErrUnbalancedEntry = errors.New("el asiento contable no está balanceado")
+	// ErrInvalidEntry indica que el asiento no tiene la estructura mínima requerida.
+	ErrInvalidEntry = errors.New("asiento contable inválido")
+)
+
+// NormalBalance indica el lado (débito o crédito) en el que crece el saldo de una cuenta contable.
+type NormalBalance string
+
+const (
+	Debit  NormalBalance = "debit"  // Cuentas de activo: crecen con débitos (por ejemplo, caja)
+	Credit NormalBalance = "credit" // Cuentas de pasivo e ingresos: crecen con créditos (por ejemplo, depósitos de clientes)
+)
+
+// Account representa una cuenta contable del libro mayor.
+// Las cuentas de clientes son pasivos del banco (saldo normal acreedor), mientras que
+// las cuentas de sistema representan la caja, las partidas transitorias y los ingresos por comisiones e intereses.
+type Account struct {
+	Code   string        // Código único de la cuenta contable (por ejemplo "system:cash" o "customer:42")
+	Name   string        // Nombre descriptivo de la cuenta contable
+	Normal NormalBalance // Lado en el que crece el saldo de la cuenta
+}
+
+// Cuentas contables de sistema.
+var (
+	// Cash representa el efectivo del banco; recibe los depósitos y entrega los retiros.
+	Cash = Account{Code: "system:cash", Name: "Caja", Normal: Debit}
+	// Suspense es la cuenta transitoria usada como contrapartida de saldos sin origen conocido
+	// (por ejemplo, los saldos iniciales de cuentas creadas antes del libro mayor).
+	Suspense = Account{Code: "system:suspense", Name: "Partidas transitorias", Normal: Debit}
+	// Fees acumula los ingresos por comisiones cobradas a los clientes.
+	Fees = Account{Code: "system:fees", Name: "Ingresos por comisiones", Normal: Credit}
+	// Interest acumula los ingresos por intereses cobrados a los clientes (por ejemplo, sobre el sobregiro).
+	Interest = Account{Code: "system:interest", Name: "Ingresos por intereses", Normal: Credit}
+	// FX es la posición de cambio del banco: recibe la moneda vendida por el cliente y entrega la comprada
+	// en las transferencias entre monedas. Su saldo en cada moneda refleja la posición abierta y el margen ganado.
+	FX = Account{Code: "system:fx", Name: "Posición de cambio", Normal: Debit}
+)
+
+// SystemAccounts devuelve todas las cuentas contables de sistema.
+func SystemAccounts() []Account {
+	return []Account{Cash, Suspense, Fees, Interest, FX}
+}
+
+// CustomerAccount devuelve la cuenta contable asociada a una cuenta bancaria de cliente.
+func CustomerAccount(accountID int) Account {
+	return Account{
+		Code:   fmt.Sprintf("customer:%d", accountID),
+		Name:   fmt.Sprintf("Depósitos del cliente (cuenta %d)", accountID),
+		Normal: Credit,
+	}
+}
+
+// BalanceFromSum convierte la suma de movimientos (débitos positivos, créditos negativos)
+// en el saldo de la cuenta según su lado normal.
+func (a Account) BalanceFromSum(sum money.Money) money.Money {
+	if a.Normal == Credit {
+		return sum.Neg()
+	}
+	return sum
+}
+
+// Posting es un movimiento de un asiento sobre una cuenta contable.
+// Por convención, los débitos tienen monto positivo y los créditos monto negativo.
+type Posting struct {
+	Account Account     // Cuenta contable afectada
+	Amount  money.Money // Monto del movimiento (positivo = débito, negativo = crédito)
+}
+
+// JournalEntry representa un asiento del libro diario compuesto por movimientos balanceados.
+type JournalEntry struct {
+	ID          int       // Identificador único del asiento (asignado por el repositorio)
+	Reference   string    // Referencia de la operación que originó el asiento (por ejemplo "transaction:15")
+	Description string    // Descripción del asiento
+	Postings    []Posting // Movimientos del asiento; su suma debe ser cero
+	CreatedAt   time.Time // Fecha de creación del asiento
+}
+
+// NewEntry crea un asiento vacío con la referencia y descripción indicadas.
+func NewEntry(reference, description string) *JournalEntry {
+	return &JournalEntry{
+		Reference:   reference,
+		Description: description,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Debit agrega un débito por el monto indicado sobre la cuenta contable.
+func (e *JournalEntry) Debit(account Account, amount money.Money) *JournalEntry {
+	e.Postings = append(e.Postings, Posting{Account: account, Amount: amount})
+	return e
+}
+
+// Credit agrega un crédito por el monto indicado sobre la cuenta contable.
+func (e *JournalEntry) Credit(account Account, amount money.Money) *JournalEntry {
+	e.Postings = append(e.Postings, Posting{Account: account, Amount: amount.Neg()})
+	return e
+}
+
+// Validate verifica que el asiento esté balanceado: al menos dos movimientos, ninguno en cero,
+// todos en la misma moneda y con suma igual a cero.
+func (e *JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return fmt.Errorf("%w: se requieren al menos dos movimientos", ErrInvalidEntry)
+	}
+
+	sum := money.Zero(e.Postings[0].Amount.Currency())
+	for _, p := range e.Postings {
+		if p.Account.Code == "" {
+			return fmt.Errorf("%w: movimiento sin cuenta contable", ErrInvalidEntry)
+		}
+		if p.Amount.IsZero() {
+			return fmt.Errorf("%w: movimiento en cero sobre %s", ErrInvalidEntry, p.Account.Code)
+		}
+		var err error
+		if sum, err = sum.Add(p.Amount); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+		}
+	}
+
+	if !sum.IsZero() {
+		return fmt.Errorf("%w: diferencia de %s", ErrUnbalancedEntry, sum)
+	}
+	return nil
+}
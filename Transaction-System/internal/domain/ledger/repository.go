@@ -0,0 +1,22 @@
+package ledger
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"context"                                  // Contexto de la operación: cancelación y plazos
+)
+
+// Repository define las operaciones que un repositorio del libro mayor debe implementar.
+// El libro mayor es de sólo anexado: los asientos nunca se modifican ni se eliminan.
+type Repository interface {
+	// Append valida y guarda un asiento contable junto con sus movimientos.
+	// Retorna un error si el asiento no está balanceado o si no se puede guardar.
+	Append(ctx context.Context, e *JournalEntry) error
+
+	// Sum devuelve la suma de los movimientos (débitos positivos, créditos negativos)
+	// de la cuenta contable en la moneda indicada, junto con la cantidad de movimientos.
+	Sum(ctx context.Context, accountCode string, currency string) (money.Money, int, error)
+
+	// TrialBalance devuelve, por moneda, la suma de todos los movimientos del libro.
+	// En un libro consistente todas las sumas son cero.
+	TrialBalance(ctx context.Context) (map[string]money.Money, error)
+}
@@ -0,0 +1,48 @@
+package money
+
+import (
+	"fmt"     // Paquete para formatear errores
+	"strings" // Paquete para normalizar los códigos de moneda
+)
+
+// DefaultCurrency es la moneda que se asume cuando un monto no indica su moneda
+// (por ejemplo, al leer balances de la base de datos o solicitudes sin moneda).
+const DefaultCurrency = "USD"
+
+// minorUnits contiene el número de decimales (unidades menores) de cada moneda soportada,
+// según la norma ISO 4217.
+var minorUnits = map[string]int{
+	"USD": 2, // Dólar estadounidense
+	"EUR": 2, // Euro
+	"GBP": 2, // Libra esterlina
+	"COP": 2, // Peso colombiano
+	"MXN": 2, // Peso mexicano
+	"BRL": 2, // Real brasileño
+	"CLP": 0, // Peso chileno
+	"JPY": 0, // Yen japonés
+	"KWD": 3, // Dinar kuwaití
+	"BHD": 3, // Dinar bareiní
+}
+
+// MinorUnits devuelve el número de decimales de la moneda indicada.
+// Retorna ErrUnknownCurrency si la moneda no está soportada.
+func MinorUnits(currency string) (int, error) {
+	units, ok := minorUnits[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return units, nil
+}
+
+// normalizeCurrency valida el código de moneda y lo devuelve en mayúsculas.
+// Una moneda vacía se interpreta como DefaultCurrency.
+func normalizeCurrency(currency string) (string, error) {
+	if currency == "" {
+		return DefaultCurrency, nil
+	}
+	code := strings.ToUpper(currency)
+	if _, ok := minorUnits[code]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return code, nil
+}
@@ -0,0 +1,88 @@
+package money
+
+import (
+	"bytes"               // Manipulación de los bytes JSON
+	"database/sql"        // Interfaz sql.Scanner
+	"database/sql/driver" // Interfaz driver.Valuer
+	"encoding/json"       // Interfaces de serialización JSON
+	"fmt"                 // Paquete para formatear errores
+	"strconv"             // Conversión de números a texto
+)
+
+// Asegurar que Money implementa las interfaces de serialización JSON y SQL.
+var (
+	_ json.Marshaler   = Money{}
+	_ json.Unmarshaler = &Money{}
+	_ driver.Valuer    = Money{}
+	_ sql.Scanner      = &Money{}
+)
+
+// MarshalJSON serializa el monto como un número JSON exacto con los decimales de la moneda
+// (por ejemplo 150.25). La moneda no se incluye: se informa en un campo aparte.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON interpreta un número JSON (o una cadena con un número) sin pasar por float64.
+// Si el monto ya tiene moneda se conserva; en caso contrario se usa DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	// Aceptar tanto 150.25 como "150.25"
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := Parse(text, m.currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value convierte el monto en el texto decimal que se guarda en columnas DECIMAL.
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
+}
+
+// Scan lee un monto desde una columna DECIMAL.
+// Los drivers suelen devolver DECIMAL como []byte o string; también se aceptan enteros y float64.
+// Si el monto ya tiene moneda se conserva; en caso contrario se usa DefaultCurrency.
+func (m *Money) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		// Un float64 puede traer ruido binario, por lo que se redondea a la precisión de la moneda
+		parsed, err := ParseRounded(strconv.FormatFloat(v, 'f', -1, 64), m.currency, RoundHalfEven)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case nil:
+		return fmt.Errorf("%w: valor NULL", ErrInvalidAmount)
+	default:
+		return fmt.Errorf("%w: tipo %T no soportado", ErrInvalidAmount, src)
+	}
+
+	// Las columnas DECIMAL pueden tener más decimales que la moneda (por ejemplo 10.5000)
+	parsed, err := ParseRounded(text, m.currency, RoundHalfEven)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,137 @@
+package money_test
+
+import (
+	"Transaction-System/internal/domain/money"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// Prueba que sumar repetidamente montos decimales no acumula errores de redondeo
+func TestAdd_NoDrift(t *testing.T) {
+	total := money.Zero("USD")
+	step := money.MustParse("0.10", "USD")
+
+	// Sumar 0.10 diez veces debe dar exactamente 1.00 (con float64 daría 0.9999999999999999)
+	for i := 0; i < 10; i++ {
+		var err error
+		if total, err = total.Add(step); err != nil {
+			t.Fatalf("Error inesperado al sumar: %v", err)
+		}
+	}
+
+	if total != money.MustParse("1.00", "USD") {
+		t.Errorf("Total incorrecto: obtenido %v, esperado 1.00 USD", total)
+	}
+}
+
+// Prueba que no se pueden operar montos de monedas distintas
+func TestAdd_CurrencyMismatch(t *testing.T) {
+	_, err := money.MustParse("1.00", "USD").Add(money.MustParse("1.00", "EUR"))
+	if !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Se esperaba ErrCurrencyMismatch, obtenido %v", err)
+	}
+}
+
+// Prueba que Parse rechaza montos con más decimales de los que admite la moneda
+func TestParse_Precision(t *testing.T) {
+	if _, err := money.Parse("10.005", "USD"); !errors.Is(err, money.ErrPrecision) {
+		t.Errorf("Se esperaba ErrPrecision, obtenido %v", err)
+	}
+	if m, err := money.Parse("10.005", "KWD"); err != nil || m.Amount() != 10005 {
+		t.Errorf("KWD admite tres decimales: obtenido %v, %v", m, err)
+	}
+	if _, err := money.Parse("NaN", "USD"); !errors.Is(err, money.ErrInvalidAmount) {
+		t.Errorf("Se esperaba ErrInvalidAmount, obtenido %v", err)
+	}
+}
+
+// Prueba los distintos modos de redondeo
+func TestParseRounded_Modes(t *testing.T) {
+	cases := []struct {
+		input string
+		mode  money.RoundingMode
+		want  string
+	}{
+		{"2.345", money.RoundHalfUp, "2.35"},
+		{"2.345", money.RoundHalfEven, "2.34"},
+		{"2.355", money.RoundHalfEven, "2.36"},
+		{"2.349", money.RoundDown, "2.34"},
+		{"2.341", money.RoundUp, "2.35"},
+		{"-2.345", money.RoundHalfUp, "-2.35"},
+		{"-2.341", money.RoundDown, "-2.34"},
+	}
+
+	for _, c := range cases {
+		m, err := money.ParseRounded(c.input, "USD", c.mode)
+		if err != nil {
+			t.Fatalf("Error inesperado con %s: %v", c.input, err)
+		}
+		if m.Decimal() != c.want {
+			t.Errorf("ParseRounded(%s, %d): obtenido %s, esperado %s", c.input, c.mode, m.Decimal(), c.want)
+		}
+	}
+}
+
+// Prueba la multiplicación por un factor exacto con redondeo
+func TestMulRat(t *testing.T) {
+	rate, _ := new(big.Rat).SetString("0.015")
+	m, err := money.MustParse("333.33", "USD").MulRat(rate, money.RoundHalfUp)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if m.Decimal() != "5.00" { // 333.33 * 0.015 = 4.99995 -> 5.00
+		t.Errorf("Resultado incorrecto: obtenido %s, esperado 5.00", m.Decimal())
+	}
+}
+
+// Prueba la conversión a otra moneda con sus propios decimales
+func TestConvert(t *testing.T) {
+	rate, _ := new(big.Rat).SetString("0.3075")
+	m, err := money.MustParse("10.99", "USD").Convert("kwd", rate, money.RoundDown)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if m.Currency() != "KWD" || m.Decimal() != "3.379" { // 10.99 * 0.3075 = 3.379425 -> 3.379
+		t.Errorf("Resultado incorrecto: obtenido %s %s, esperado 3.379 KWD", m.Decimal(), m.Currency())
+	}
+
+	if _, err := money.MustParse("1.00", "USD").Convert("XXX", rate, money.RoundDown); !errors.Is(err, money.ErrUnknownCurrency) {
+		t.Errorf("Se esperaba ErrUnknownCurrency, obtenido %v", err)
+	}
+}
+
+// Prueba la serialización JSON sin pasar por float64
+func TestJSON_RoundTrip(t *testing.T) {
+	var request struct {
+		Amount money.Money `json:"amount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 1234.56}`), &request); err != nil {
+		t.Fatalf("Error inesperado al decodificar: %v", err)
+	}
+	if request.Amount.Amount() != 123456 {
+		t.Errorf("Monto incorrecto: obtenido %d, esperado 123456", request.Amount.Amount())
+	}
+
+	out, _ := json.Marshal(request)
+	if string(out) != `{"amount":1234.56}` {
+		t.Errorf("JSON incorrecto: obtenido %s", out)
+	}
+}
+
+// Prueba la lectura de columnas DECIMAL
+func TestScan(t *testing.T) {
+	var m money.Money
+	if err := m.Scan([]byte("150.2500")); err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if m != money.MustParse("150.25", money.DefaultCurrency) {
+		t.Errorf("Monto incorrecto: obtenido %v", m)
+	}
+
+	value, _ := m.Value()
+	if value != "150.25" {
+		t.Errorf("Valor SQL incorrecto: obtenido %v", value)
+	}
+}
@@ -0,0 +1,301 @@
+package money
+
+import (
+	"errors"   // Paquete para definir errores del dominio
+	"fmt"      // Paquete para formatear errores
+	"math/big" // Aritmética exacta para interpretar decimales y redondear
+	"strconv"  // Conversión de enteros a texto
+	"strings"  // Manipulación de cadenas
+)
+
+// Errores del tipo Money.
+var (
+	// ErrUnknownCurrency indica que el código de moneda no está soportado.
+	ErrUnknownCurrency = errors.New("moneda desconocida")
+	// ErrCurrencyMismatch indica que se intentó operar montos de monedas distintas.
+	ErrCurrencyMismatch = errors.New("las monedas no coinciden")
+	// ErrInvalidAmount indica que el texto no representa un monto válido.
+	ErrInvalidAmount = errors.New("monto inválido")
+	// ErrPrecision indica que el monto tiene más decimales de los que admite la moneda.
+	ErrPrecision = errors.New("el monto tiene más decimales de los permitidos por la moneda")
+	// ErrOverflow indica que el resultado de una operación excede el rango representable.
+	ErrOverflow = errors.New("el monto excede el rango permitido")
+)
+
+// RoundingMode define cómo redondear un valor que no cabe exactamente en las unidades menores.
+type RoundingMode int
+
+const (
+	// RoundHalfUp redondea al más cercano; los empates se alejan de cero (0.125 -> 0.13).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven redondea al más cercano; los empates van al par (redondeo bancario).
+	RoundHalfEven
+	// RoundDown trunca hacia cero.
+	RoundDown
+	// RoundUp redondea alejándose de cero.
+	RoundUp
+)
+
+// Money representa un monto monetario exacto.
+// El monto se almacena como un entero de unidades menores (por ejemplo, centavos)
+// junto con el código ISO 4217 de la moneda, evitando los errores de redondeo de float64.
+// El valor cero de Money equivale a cero en DefaultCurrency.
+type Money struct {
+	amount   int64  // Monto expresado en unidades menores de la moneda
+	currency string // Código ISO 4217 de la moneda
+}
+
+// New crea un monto a partir de unidades menores (por ejemplo, New(1050, "USD") equivale a 10.50 USD).
+// Una moneda vacía se interpreta como DefaultCurrency; una moneda no soportada produce un error.
+func New(minor int64, currency string) (Money, error) {
+	code, err := normalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: code}, nil
+}
+
+// Zero devuelve el monto cero en la moneda indicada.
+func Zero(currency string) Money {
+	code, err := normalizeCurrency(currency)
+	if err != nil {
+		code = DefaultCurrency
+	}
+	return Money{currency: code}
+}
+
+// Parse interpreta un monto decimal (por ejemplo "150.25") en la moneda indicada.
+// Es estricto: si el valor tiene más decimales de los que admite la moneda devuelve ErrPrecision.
+func Parse(s string, currency string) (Money, error) {
+	return parse(s, currency, nil)
+}
+
+// ParseRounded interpreta un monto decimal y redondea los decimales sobrantes con el modo indicado.
+func ParseRounded(s string, currency string, mode RoundingMode) (Money, error) {
+	return parse(s, currency, &mode)
+}
+
+// MustParse es como Parse pero entra en pánico si el monto es inválido.
+// Está pensado para constantes y pruebas.
+func MustParse(s string, currency string) Money {
+	m, err := Parse(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// parse convierte el texto en unidades menores; si mode es nil no se permite redondear.
+func parse(s string, currency string, mode *RoundingMode) (Money, error) {
+	code, err := normalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// big.Rat interpreta el decimal de forma exacta; se rechazan fracciones como "1/3"
+	s = strings.TrimSpace(s)
+	r, ok := new(big.Rat).SetString(s)
+	if s == "" || strings.Contains(s, "/") || !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	// Escalar el valor a unidades menores
+	r.Mul(r, scale(code))
+	if !r.IsInt() && mode == nil {
+		return Money{}, fmt.Errorf("%w: %q admite %d decimales", ErrPrecision, code, minorUnits[code])
+	}
+	rounding := RoundHalfEven
+	if mode != nil {
+		rounding = *mode
+	}
+	minor, err := roundRat(r, rounding)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: code}, nil
+}
+
+// Amount devuelve el monto expresado en unidades menores.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency devuelve el código ISO 4217 de la moneda.
+func (m Money) Currency() string {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// IsZero indica si el monto es cero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive indica si el monto es mayor que cero.
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative indica si el monto es menor que cero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// SameCurrency indica si ambos montos están expresados en la misma moneda.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Currency() == o.Currency()
+}
+
+// Add suma dos montos de la misma moneda.
+func (m Money) Add(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, fmt.Errorf("%w: %s y %s", ErrCurrencyMismatch, m.Currency(), o.Currency())
+	}
+	sum := m.amount + o.amount
+	// Detectar desbordamiento: sumar dos valores del mismo signo no puede cambiar el signo
+	if (m.amount > 0 && o.amount > 0 && sum < 0) || (m.amount < 0 && o.amount < 0 && sum >= 0) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: sum, currency: m.Currency()}, nil
+}
+
+// Sub resta o de m; ambos montos deben estar en la misma moneda.
+func (m Money) Sub(o Money) (Money, error) {
+	if o.amount == -1<<63 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(o.Neg())
+}
+
+// Neg devuelve el monto con el signo invertido.
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.Currency()}
+}
+
+// Abs devuelve el valor absoluto del monto.
+func (m Money) Abs() Money {
+	if m.amount < 0 {
+		return m.Neg()
+	}
+	return Money{amount: m.amount, currency: m.Currency()}
+}
+
+// Cmp compara dos montos de la misma moneda.
+// Devuelve -1 si m < o, 0 si son iguales y +1 si m > o.
+func (m Money) Cmp(o Money) (int, error) {
+	if !m.SameCurrency(o) {
+		return 0, fmt.Errorf("%w: %s y %s", ErrCurrencyMismatch, m.Currency(), o.Currency())
+	}
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// MulRat multiplica el monto por un factor exacto (por ejemplo, una tasa) y redondea el
+// resultado a las unidades menores de la moneda con el modo indicado.
+func (m Money) MulRat(factor *big.Rat, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).SetInt64(m.amount)
+	r.Mul(r, factor)
+	minor, err := roundRat(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: m.Currency()}, nil
+}
+
+// Convert convierte el monto a otra moneda con el tipo de cambio indicado (unidades de la moneda de
+// destino por unidad de la moneda del monto) y redondea el resultado a los decimales de la moneda de destino.
+// Retorna ErrUnknownCurrency si la moneda de destino no está soportada.
+func (m Money) Convert(currency string, rate *big.Rat, mode RoundingMode) (Money, error) {
+	code, err := normalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	r := m.Rat()
+	r.Mul(r, rate)
+	r.Mul(r, scale(code))
+	minor, err := roundRat(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: code}, nil
+}
+
+// Rat devuelve el monto como número racional exacto en unidades mayores (por ejemplo, 10.50).
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).Quo(new(big.Rat).SetInt64(m.amount), scale(m.Currency()))
+}
+
+// Decimal devuelve el monto como texto decimal con los decimales de la moneda (por ejemplo "10.50").
+func (m Money) Decimal() string {
+	units := minorUnits[m.Currency()]
+
+	// Valor absoluto como uint64 para soportar también el mínimo de int64
+	abs := uint64(m.amount)
+	sign := ""
+	if m.amount < 0 {
+		abs = uint64(-(m.amount + 1)) + 1
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if units == 0 {
+		return sign + digits
+	}
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+// String devuelve el monto con su moneda, por ejemplo "10.50 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency()
+}
+
+// scale devuelve 10^decimales de la moneda como número racional.
+func scale(currency string) *big.Rat {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(minorUnits[currency])), nil)
+	return new(big.Rat).SetInt(factor)
+}
+
+// roundRat redondea un número racional a entero con el modo indicado y verifica que quepa en int64.
+func roundRat(r *big.Rat, mode RoundingMode) (int64, error) {
+	num, den := r.Num(), r.Denom()
+
+	// Cociente truncado hacia cero y resto con el signo del numerador
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		// Comparar 2*|resto| con el denominador para saber si está por encima de la mitad
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		half := twice.Cmp(den)
+
+		away := false
+		switch mode {
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && q.Bit(0) == 1)
+		case RoundDown:
+			away = false
+		case RoundUp:
+			away = true
+		}
+		if away {
+			q.Add(q, big.NewInt(int64(num.Sign())))
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
@@ -0,0 +1,107 @@
+package transaction
+
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"encoding/base64"                          // Codificación opaca del cursor
+	"errors"                                   // Paquete para definir errores
+	"fmt"                                      // Paquete para formatear el cursor
+	"slices"                                   // Utilidades sobre slices
+	"time"                                     // Paquete para manejar fechas y horas
+)
+
+// ErrInvalidCursor indica que el cursor de paginación recibido no es válido.
+var ErrInvalidCursor = errors.New("cursor de paginación inválido")
+
+// Cursor identifica la posición de una transacción en el historial de una cuenta.
+// El historial se ordena de la más reciente a la más antigua por (CreatedAt, ID);
+// el ID desempata las transacciones creadas en el mismo instante, lo que hace la paginación estable.
+type Cursor struct {
+	CreatedAt time.Time // Fecha de creación de la última transacción de la página anterior
+	ID        int       // ID de la última transacción de la página anterior
+}
+
+// CursorOf devuelve el cursor que apunta a la transacción indicada.
+func CursorOf(t *Transaction) Cursor {
+	return Cursor{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+// Encode codifica el cursor como una cadena opaca apta para una URL.
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%d:%d", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor decodifica un cursor generado por Encode.
+// Retorna ErrInvalidCursor si la cadena no corresponde a un cursor válido.
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var nanos int64
+	var id int
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &nanos, &id); err != nil || id <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// Filter define los criterios de búsqueda del historial de transacciones de una cuenta.
+// Los campos vacíos no filtran.
+type Filter struct {
+	Types     []string     // Tipos de transacción aceptados ("deposit", "withdrawal", ...)
+	MinAmount *money.Money // Monto mínimo (inclusive)
+	MaxAmount *money.Money // Monto máximo (inclusive)
+	From      time.Time    // Fecha de creación mínima (inclusive)
+	To        time.Time    // Fecha de creación máxima (exclusiva)
+	After     *Cursor      // Devuelve sólo las transacciones posteriores a este cursor en el orden del historial
+	Limit     int          // Cantidad máxima de transacciones a devolver
+}
+
+// Matches indica si la transacción cumple los criterios del filtro (sin considerar Limit).
+// Es la referencia que deben respetar las implementaciones de Repository.FindByAccount.
+func (f Filter) Matches(t *Transaction) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, t.TransactionType) {
+		return false
+	}
+	if f.MinAmount != nil {
+		if cmp, err := t.Amount.Cmp(*f.MinAmount); err != nil || cmp < 0 {
+			return false
+		}
+	}
+	if f.MaxAmount != nil {
+		if cmp, err := t.Amount.Cmp(*f.MaxAmount); err != nil || cmp > 0 {
+			return false
+		}
+	}
+	if !f.From.IsZero() && t.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !t.CreatedAt.Before(f.To) {
+		return false
+	}
+	if f.After != nil && !f.After.precedes(t) {
+		return false
+	}
+	return true
+}
+
+// precedes indica si la transacción t va después del cursor en el orden del historial,
+// es decir, si es más antigua o, con la misma fecha, tiene un ID menor.
+func (c Cursor) precedes(t *Transaction) bool {
+	if t.CreatedAt.Equal(c.CreatedAt) {
+		return t.ID < c.ID
+	}
+	return t.CreatedAt.Before(c.CreatedAt)
+}
+
+// SortHistory ordena las transacciones en el orden del historial: de la más reciente a la más antigua,
+// desempatando por ID descendente.
+func SortHistory(transactions []*Transaction) {
+	slices.SortFunc(transactions, func(a, b *Transaction) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return b.ID - a.ID
+	})
+}
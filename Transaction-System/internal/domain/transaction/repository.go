@@ -1,11 +1,32 @@
 package transaction
 
+import "context" // Contexto de la operación: cancelación y plazos
+
 // Repository define las operaciones que un repositorio de transacciones debe implementar.
 // Este patrón de diseño, conocido como "Repository Pattern", desacopla la lógica de negocio
 // de la capa de persistencia, facilitando la mantenibilidad y el testeo.
+// Todos los métodos reciben el contexto de la operación: si se cancela o vence su plazo,
+// la operación se interrumpe y retorna el error del contexto.
 type Repository interface {
 	// Save guarda una transacción en el repositorio.
 	// Recibe una transacción (Transaction) como argumento.
 	// Retorna un error si ocurre algún problema al guardar la transacción.
-	Save(t *Transaction) error
+	Save(ctx context.Context, t *Transaction) error
+
+	// FindByID busca una transacción por su identificador.
+	// Retorna ErrNotFound si no existe.
+	FindByID(ctx context.Context, id int) (*Transaction, error)
+
+	// FindReversal devuelve la transacción que revierte la transacción originalID.
+	// Retorna ErrNotFound si la transacción no fue revertida.
+	FindReversal(ctx context.Context, originalID int) (*Transaction, error)
+
+	// FindByQuote devuelve la pata de débito (transfer_out) de la transferencia liquidada con la cotización quoteID.
+	// Retorna ErrNotFound si la cotización no se utilizó.
+	FindByQuote(ctx context.Context, quoteID string) (*Transaction, error)
+
+	// FindByAccount devuelve el historial de transacciones de una cuenta que cumplen el filtro,
+	// ordenado de la más reciente a la más antigua por (CreatedAt, ID) y limitado a filter.Limit elementos.
+	// Si filter.After no es nil, la búsqueda continúa a partir de ese cursor.
+	FindByAccount(ctx context.Context, accountID int, filter Filter) ([]*Transaction, error)
 }
@@ -0,0 +1,47 @@
+package transaction
+
+import (
+	"errors" // Paquete para definir errores del dominio
+	"fmt"    // Paquete para formatear errores
+)
+
+// Errores de la consulta y la reversión de transacciones.
+var (
+	// ErrNotFound indica que la transacción no existe.
+	ErrNotFound = errors.New("transacción no encontrada")
+	// ErrAlreadyReversed indica que la transacción ya fue revertida.
+	ErrAlreadyReversed = errors.New("la transacción ya fue revertida")
+	// ErrNotReversible indica que el tipo de la transacción no admite reversión.
+	ErrNotReversible = errors.New("la transacción no admite reversión")
+)
+
+// CreditsAccount indica si la transacción acredita la cuenta (aumenta su balance).
+// Una reversión mueve el monto en sentido contrario al de la transacción que revierte.
+func (t *Transaction) CreditsAccount() bool {
+	return t.TransactionType == TypeDeposit || t.TransactionType == TypeTransferIn
+}
+
+// Reversible verifica que la transacción pueda revertirse. Se revierten los movimientos de una sola
+// cuenta: depósitos, retiros, capturas, cargos de intereses y comisiones de sobregiro. Las patas de una transferencia no se
+// revierten por separado (se devuelve con otra transferencia) y una reversión no puede revertirse.
+// Retorna ErrNotReversible si el tipo no admite reversión.
+func (t *Transaction) Reversible() error {
+	switch t.TransactionType {
+	case TypeDeposit, TypeWithdrawal, TypeCapture, TypeOverdraftInterest, TypeOverdraftFee:
+		return nil
+	default:
+		return fmt.Errorf("%w: transacción %d de tipo %q", ErrNotReversible, t.ID, t.TransactionType)
+	}
+}
+
+// NewReversal crea la transacción que revierte original: por el mismo monto, sobre la misma cuenta
+// y enlazada a la original mediante ReversalOf.
+// Retorna ErrNotReversible si el tipo de la original no admite reversión.
+func NewReversal(original *Transaction) (*Transaction, error) {
+	if err := original.Reversible(); err != nil {
+		return nil, err
+	}
+	t := New(original.AccountID, original.Amount, TypeReversal)
+	t.ReversalOf = original.ID
+	return t, nil
+}
@@ -1,14 +1,54 @@
 package transaction
 
-import "time"
+import (
+	"Transaction-System/internal/domain/money" // Tipo Money para montos exactos
+	"math/big"                                 // Tipo de cambio exacto de las conversiones
+	"time"                                     // Paquete para manejar fechas y horas
+)
+
+// Tipos de transacción soportados por el sistema.
+const (
+	TypeDeposit     = "deposit"      // Depósito en una cuenta
+	TypeWithdrawal  = "withdrawal"   // Retiro de una cuenta
+	TypeTransferOut = "transfer_out" // Pata de débito de una transferencia (cuenta origen)
+	TypeTransferIn  = "transfer_in"  // Pata de crédito de una transferencia (cuenta destino)
+
+	TypeOverdraftInterest = "overdraft_interest" // Cargo diario de intereses sobre el sobregiro en uso
+	TypeOverdraftFee      = "overdraft_fee"      // Comisión diaria fija de una cuenta sobregirada
+	TypeCapture           = "capture"            // Captura de una retención de fondos autorizada
+	TypeReversal          = "reversal"           // Reversión de otra transacción; mueve el monto en sentido contrario
+)
 
 // Transaction representa una transacción bancaria en el sistema.
 // Cada transacción contiene información sobre el ID de la cuenta, el monto,
 // el tipo de transacción (por ejemplo, depósito o retiro) y la fecha de creación.
 type Transaction struct {
-	ID              int       // Identificador único de la transacción (probablemente asignado por la base de datos)
-	AccountID       int       // ID de la cuenta a la que se aplica la transacción
-	Amount          float64   // Monto de la transacción (puede ser positivo para depósitos, negativo para retiros)
-	TransactionType string    // Tipo de transacción: puede ser "deposit" o "withdrawal"
-	CreatedAt       time.Time // Marca de tiempo que indica cuándo fue creada la transacción
+	ID              int         // Identificador único de la transacción (probablemente asignado por la base de datos)
+	AccountID       int         // ID de la cuenta a la que se aplica la transacción
+	Amount          money.Money // Monto de la transacción (puede ser positivo para depósitos, negativo para retiros)
+	TransactionType string      // Tipo de transacción: "deposit", "withdrawal", "transfer_out", "transfer_in", "overdraft_interest", "overdraft_fee", "capture" o "reversal"
+	TransferID      string      // Identificador compartido por las dos patas de una transferencia (vacío si no aplica)
+	Conversion      *Conversion // Cambio de divisas aplicado en una transferencia entre monedas (nil si no aplica)
+	ReversalOf      int         // Transacción que revierte una transacción "reversal" (cero si no aplica)
+	CreatedAt       time.Time   // Marca de tiempo que indica cuándo fue creada la transacción
+}
+
+// Conversion registra el cambio de divisas de una transferencia entre cuentas de distinta moneda.
+// Ambas patas guardan la misma cotización y el mismo tipo de cambio, de modo que cada una permite
+// reconstruir la conversión completa.
+type Conversion struct {
+	QuoteID       string      // Identificador de la cotización con la que se liquidó la transferencia
+	Rate          *big.Rat    // Tipo de cambio aplicado: unidades de la moneda de destino por unidad de la de origen
+	CounterAmount money.Money // Monto de la otra pata, en la moneda de la otra cuenta
+}
+
+// New crea una nueva transacción para la cuenta indicada.
+// La fecha de creación se establece con la fecha y hora actual; el ID lo asigna el repositorio.
+func New(accountID int, amount money.Money, transactionType string) *Transaction {
+	return &Transaction{
+		AccountID:       accountID,       // Cuenta a la que se aplica la transacción
+		Amount:          amount,          // Monto de la transacción
+		TransactionType: transactionType, // Tipo de transacción ("deposit" o "withdrawal")
+		CreatedAt:       time.Now(),      // Fecha de creación de la transacción
+	}
 }
@@ -0,0 +1,17 @@
+package transaction
+
+import (
+	"fmt" // Paquete para envolver el error del generador
+
+	"github.com/google/uuid" // Generador de UUID
+)
+
+// NewTransferID genera un identificador único (UUID versión 4) para enlazar
+// las dos patas de una transferencia entre cuentas.
+func NewTransferID() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("no se pudo generar el identificador de la transferencia: %w", err)
+	}
+	return id.String(), nil
+}
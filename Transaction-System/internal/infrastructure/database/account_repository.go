@@ -2,7 +2,11 @@ package database
 
 import (
 	"Transaction-System/internal/domain/account"
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -10,7 +14,9 @@ import (
 // Este repositorio se encarga de interactuar con la base de datos para las operaciones CRUD
 // relacionadas con las cuentas bancarias.
 type AccountRepository struct {
-	db *sql.DB // Conexión a la base de datos SQL.
+	db      dbtx          // Conexión a la base de datos SQL o transacción en curso.
+	driver  Driver        // Motor de la base de datos.
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
 }
 
 // Asegurar que AccountRepository implementa la interfaz account.Repository
@@ -21,51 +27,208 @@ var _ account.Repository = &AccountRepository{}
 // NewAccountRepository es un constructor que crea un nuevo repositorio de cuentas.
 // Parámetros:
 // - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
 // Retorna:
 // - Un puntero a AccountRepository que interactúa con la base de datos a través de db.
-func NewAccountRepository(db *sql.DB) *AccountRepository {
-	return &AccountRepository{db: db}
+func NewAccountRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *AccountRepository {
+	return &AccountRepository{db: driver.bind(db), driver: driver, timeout: queryTimeout}
 }
 
-// Save guarda una nueva cuenta en la base de datos.
+// accountColumns es la lista de columnas que se leen de la tabla 'accounts'.
+const accountColumns = "id, account_number, currency, balance, held, overdraft_limit, interest_accrued_on, status, version, created_at"
+
+// rowScanner abstrae *sql.Row y *sql.Rows para reutilizar la lectura de cuentas.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Save guarda una nueva cuenta en la base de datos y le asigna el ID generado.
 // Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
 // - a: un puntero a la estructura account.Account que contiene la información de la cuenta a guardar.
 // Retorna:
 // - error: retorna un error si la operación de guardado falla, de lo contrario, retorna nil.
-func (r *AccountRepository) Save(a *account.Account) error {
-	// La consulta INSERT inserta el número de cuenta, el balance y la fecha de creación en la tabla 'accounts'.
-	// Utilizamos 'Exec' ya que no necesitamos recuperar ningún valor de la base de datos.
-	_, err := r.db.Exec("INSERT INTO accounts (account_number, balance, created_at) VALUES (?, ?, ?)",
-		a.AccountNumber, a.Balance, a.CreatedAt)
+func (r *AccountRepository) Save(ctx context.Context, a *account.Account) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
+	// Las cuentas sin estado explícito se guardan como activas
+	if a.Status == "" {
+		a.Status = account.StatusActive
+	}
+
+	// La consulta INSERT inserta el número de cuenta, la moneda, el balance, el sobregiro, el estado, la versión y la fecha de creación en la tabla 'accounts'.
 	// Si ocurre un error durante la ejecución de la consulta, se retorna el error.
-	return err
+	id, err := r.driver.insert(ctx, r.db, "INSERT INTO accounts (account_number, currency, balance, held, overdraft_limit, interest_accrued_on, status, version, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		a.AccountNumber, a.Currency(), a.Balance, a.HeldAmount(), a.OverdraftLimit(), nullDate(a.InterestAccruedOn), string(a.Status), a.Version, a.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	// Asignar a la cuenta el ID generado por la base de datos
+	a.ID = int(id)
+	return nil
 }
 
-// FindByID busca una cuenta en la base de datos por su ID único.
+// Update actualiza el balance, el sobregiro, el último día de intereses cobrados y el estado de una cuenta
+// existente en la base de datos; la moneda no cambia.
+// La actualización es un compare-and-swap sobre la columna 'version': sólo se aplica si la
+// versión guardada sigue siendo la que se leyó, y en ese caso la incrementa.
 // Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
+// - a: un puntero a la estructura account.Account con los datos actualizados; al finalizar se incrementa su versión.
+// Retorna:
+// - error: retorna account.ErrVersionConflict (envuelto) si otra operación modificó la cuenta,
+// u otro error si la actualización falla; de lo contrario, retorna nil.
+func (r *AccountRepository) Update(ctx context.Context, a *account.Account) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// La consulta UPDATE modifica el balance, el sobregiro y el estado de la cuenta identificada por su ID y su versión.
+	result, err := r.db.ExecContext(ctx, "UPDATE accounts SET balance = ?, held = ?, overdraft_limit = ?, interest_accrued_on = ?, status = ?, version = version + 1 WHERE id = ? AND version = ?",
+		a.Balance, a.HeldAmount(), a.OverdraftLimit(), nullDate(a.InterestAccruedOn), string(a.Status), a.ID, a.Version)
+	if err != nil {
+		return err
+	}
+
+	// Si ninguna fila coincide, la cuenta cambió de versión (o dejó de existir) desde que se leyó.
+	// Como la versión siempre se incrementa, una fila coincidente siempre cuenta como afectada.
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("cuenta %d (versión %d): %w", a.ID, a.Version, account.ErrVersionConflict)
+	}
+	a.Version++
+	return nil
+}
+
+// FindByID busca una cuenta en la base de datos por su ID único, sin bloquearla.
+// Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
 // - id: el ID de la cuenta que se desea buscar.
 // Retorna:
 // - *account.Account: un puntero a la estructura account.Account si la cuenta existe.
-// - error: retorna un error si la cuenta no se encuentra o si ocurre algún problema durante la consulta.
-func (r *AccountRepository) FindByID(id int) (*account.Account, error) {
-	var a account.Account   // Estructura para almacenar los datos de la cuenta.
-	var createdAtStr string // Variable para almacenar temporalmente la fecha de creación como string.
+// - error: retorna account.ErrNotFound (envuelto) si la cuenta no existe, u otro error si la consulta falla.
+func (r *AccountRepository) FindByID(ctx context.Context, id int) (*account.Account, error) {
+	return r.find(ctx, id, "")
+}
+
+// FindByIDForUpdate busca una cuenta para modificarla dentro de la transacción.
+// En PostgreSQL la lectura bloquea la fila hasta el fin de la transacción (SELECT ... FOR UPDATE), de modo que
+// las operaciones concurrentes sobre la misma cuenta se esperan; en los demás motores no la bloquea y las
+// escrituras concurrentes se detectan en Update mediante la versión.
+// Retorna account.ErrNotFound (envuelto) si la cuenta no existe, u otro error si la consulta falla.
+func (r *AccountRepository) FindByIDForUpdate(ctx context.Context, id int) (*account.Account, error) {
+	return r.find(ctx, id, r.driver.forUpdate())
+}
+
+// find ejecuta la consulta de una cuenta por ID agregando la cláusula de bloqueo indicada (vacía para no bloquear).
+func (r *AccountRepository) find(ctx context.Context, id int, lock string) (*account.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
 	// Realiza una consulta SELECT a la base de datos para obtener la cuenta con el ID proporcionado.
 	// QueryRow se utiliza para ejecutar la consulta ya que esperamos un solo resultado (una sola fila).
-	// Scan asigna los valores retornados por la consulta a las variables de destino.
-	err := r.db.QueryRow("SELECT id, account_number, balance, created_at FROM accounts WHERE id = ?", id).
-		Scan(&a.ID, &a.AccountNumber, &a.Balance, &createdAtStr)
+	a, err := scanAccount(r.db.QueryRowContext(ctx, "SELECT "+accountColumns+" FROM accounts WHERE id = ?"+lock, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		// No exponer el error del driver: traducirlo al error del dominio
+		return nil, fmt.Errorf("cuenta %d: %w", id, account.ErrNotFound)
+	}
+	return a, err
+}
 
-	// Si ocurre algún error (como que no se encuentre la cuenta), se retorna el error.
+// List devuelve las cuentas que cumplen el filtro, ordenadas por ID, y el total sin paginar.
+// Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
+// - filter: criterios de búsqueda (estado y sobregiro) y paginación (límite y desplazamiento).
+// Retorna:
+// - []*account.Account: las cuentas de la página solicitada.
+// - int: el total de cuentas que cumplen el filtro.
+// - error: retorna un error si alguna de las consultas falla.
+func (r *AccountRepository) List(ctx context.Context, filter account.ListFilter) ([]*account.Account, int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Construir la condición WHERE según los filtros recibidos
+	var conditions []string
+	var args []any
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if filter.Overdrawn {
+		conditions = append(conditions, "balance < 0")
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Contar el total de cuentas que cumplen el filtro
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM accounts"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Obtener la página solicitada
+	rows, err := r.db.QueryContext(ctx, "SELECT "+accountColumns+" FROM accounts"+where+" ORDER BY id LIMIT ? OFFSET ?",
+		append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var accounts []*account.Account
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, total, rows.Err()
+}
+
+// scanAccount lee una fila de la tabla 'accounts' en una estructura account.Account.
+func scanAccount(row rowScanner) (*account.Account, error) {
+	var a account.Account        // Estructura para almacenar los datos de la cuenta.
+	var currency string          // Variable para almacenar temporalmente la moneda de la cuenta.
+	var balance any              // Balance sin convertir; se interpreta con los decimales de la moneda.
+	var held any                 // Fondos retenidos sin convertir, igual que el balance.
+	var overdraft any            // Sobregiro autorizado sin convertir, igual que el balance.
+	var accruedOn sql.NullString // Último día de intereses cobrados; NULL si nunca se cobraron.
+	var status string            // Variable para almacenar temporalmente el estado de la cuenta.
+	var createdAtStr string      // Variable para almacenar temporalmente la fecha de creación como string.
+
+	// Scan asigna los valores retornados por la consulta a las variables de destino.
+	// Si ocurre algún error (como que no se encuentre la cuenta), se retorna el error.
+	if err := row.Scan(&a.ID, &a.AccountNumber, &currency, &balance, &held, &overdraft, &accruedOn, &status, &a.Version, &createdAtStr); err != nil {
 		return nil, err
 	}
+	a.Status = account.Status(status)
+
+	// Interpretar el balance en la moneda de la cuenta
+	var err error
+	if a.Balance, err = scanMoney(balance, currency); err != nil {
+		return nil, fmt.Errorf("balance de la cuenta %d: %w", a.ID, err)
+	}
+	if a.Held, err = scanMoney(held, currency); err != nil {
+		return nil, fmt.Errorf("fondos retenidos de la cuenta %d: %w", a.ID, err)
+	}
+	if a.Overdraft, err = scanMoney(overdraft, currency); err != nil {
+		return nil, fmt.Errorf("sobregiro de la cuenta %d: %w", a.ID, err)
+	}
+	if accruedOn.Valid {
+		if a.InterestAccruedOn, err = parseDate(accruedOn.String); err != nil {
+			return nil, err
+		}
+	}
 
 	// Convertir el valor de la cadena createdAtStr en un valor de tipo time.Time.
-	// El formato "2006-01-02 15:04:05" es el formato estándar que utiliza Go para analizar fechas.
-	a.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAtStr)
+	a.CreatedAt, err = parseTimestamp(createdAtStr)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,222 @@
+package database_test
+
+import (
+	"Transaction-System/internal/infrastructure/database"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/go-sql-driver/mysql"
+)
+
+// Las pruebas de este paquete se ejecutan contra cada motor SQL soportado:
+//   - SQLite, sobre un archivo temporal por prueba.
+//   - PostgreSQL, sobre un esquema temporal por prueba en el servidor indicado en BANK_TEST_POSTGRES_DSN
+//     o, si no se indica, en un servidor embebido que se descarga y se inicia al comenzar las pruebas.
+//     Si no hay servidor disponible (por ejemplo sin acceso a la red o con -short) esas pruebas se omiten.
+//   - MySQL, sobre una base temporal por prueba en el servidor indicado en BANK_TEST_MYSQL_DSN (por ejemplo
+//     el contenedor de Docker-MySQL, con un usuario que pueda crear bases). No hay servidor embebido:
+//     si no se indica ninguno, esas pruebas se omiten.
+
+var (
+	postgresDSN string                             // DSN del servidor PostgreSQL de las pruebas; vacío si no hay ninguno disponible
+	postgresErr error                              // Motivo por el que no hay un servidor PostgreSQL disponible
+	mysqlDSN    = os.Getenv("BANK_TEST_MYSQL_DSN") // DSN del servidor MySQL de las pruebas; vacío para omitirlas
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	stop := startPostgres()
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// startPostgres prepara el servidor PostgreSQL de las pruebas y devuelve la función que lo detiene.
+func startPostgres() (stop func()) {
+	if dsn := os.Getenv("BANK_TEST_POSTGRES_DSN"); dsn != "" {
+		postgresDSN = dsn
+		return func() {}
+	}
+	if testing.Short() {
+		postgresErr = errors.New("servidor embebido omitido con -short")
+		return func() {}
+	}
+
+	dir, err := os.MkdirTemp("", "bank-postgres-")
+	if err != nil {
+		postgresErr = err
+		return func() {}
+	}
+	port, err := freePort()
+	if err != nil {
+		postgresErr = err
+		os.RemoveAll(dir)
+		return func() {}
+	}
+	cfg := embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V16).
+		Port(port).
+		RuntimePath(filepath.Join(dir, "runtime")).
+		DataPath(filepath.Join(dir, "data")).
+		Logger(io.Discard)
+	server := embeddedpostgres.NewDatabase(cfg)
+	if err := server.Start(); err != nil {
+		postgresErr = fmt.Errorf("no se pudo iniciar el servidor embebido: %w", err)
+		os.RemoveAll(dir)
+		return func() {}
+	}
+	postgresDSN = cfg.GetConnectionURL() + "?sslmode=disable"
+	return func() {
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+// freePort devuelve un puerto TCP libre para el servidor embebido.
+func freePort() (uint32, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return uint32(l.Addr().(*net.TCPAddr).Port), nil
+}
+
+// backend es un motor contra el que se ejecutan las pruebas.
+type backend struct {
+	driver  database.Driver
+	connect func(t *testing.T) *sql.DB // Abre una base vacía, sin migraciones aplicadas
+}
+
+// backends son los motores que se prueban.
+var backends = []backend{
+	{database.SQLite, connectSQLite},
+	{database.PostgreSQL, connectPostgres},
+	{database.MySQL, connectMySQL},
+}
+
+// forEachBackend ejecuta fn como subprueba sobre una base nueva de cada motor, con el esquema migrado.
+func forEachBackend(t *testing.T, fn func(t *testing.T, db *sql.DB, driver database.Driver)) {
+	forEachEmptyBackend(t, func(t *testing.T, db *sql.DB, driver database.Driver) {
+		migrateUp(t, db, driver)
+		fn(t, db, driver)
+	})
+}
+
+// forEachEmptyBackend ejecuta fn como subprueba sobre una base nueva de cada motor, sin migraciones aplicadas.
+func forEachEmptyBackend(t *testing.T, fn func(t *testing.T, db *sql.DB, driver database.Driver)) {
+	for _, b := range backends {
+		t.Run(string(b.driver), func(t *testing.T) {
+			fn(t, b.connect(t), b.driver)
+		})
+	}
+}
+
+// connectSQLite crea una base SQLite vacía en un archivo temporal.
+// Se usa un archivo y no ":memory:" porque cada conexión del pool abriría una base en memoria distinta.
+func connectSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := database.Open(database.SQLite, filepath.Join(t.TempDir(), "bank.db"))
+	if err != nil {
+		t.Fatalf("No se pudo abrir SQLite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// connectPostgres crea un esquema temporal vacío en el servidor PostgreSQL de las pruebas y abre una
+// conexión que lo usa como search_path. El esquema se elimina al terminar.
+func connectPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	if postgresDSN == "" {
+		t.Skipf("PostgreSQL no disponible (%v); indique un servidor con BANK_TEST_POSTGRES_DSN", postgresErr)
+	}
+	admin, err := database.Open(database.PostgreSQL, postgresDSN)
+	if err != nil {
+		t.Fatalf("No se pudo abrir PostgreSQL: %v", err)
+	}
+	schema := fmt.Sprintf("bank_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		t.Fatalf("No se pudo crear el esquema de la prueba: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	db, err := database.Open(database.PostgreSQL, withSearchPath(postgresDSN, schema))
+	if err != nil {
+		t.Fatalf("No se pudo abrir PostgreSQL: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// withSearchPath agrega el parámetro search_path a un DSN de PostgreSQL, sea una URL o una lista clave=valor.
+func withSearchPath(dsn, schema string) string {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&search_path=" + schema
+	}
+	return dsn + "?search_path=" + schema
+}
+
+// connectMySQL crea una base temporal vacía en el servidor MySQL de las pruebas y abre una conexión a ella.
+// La base se elimina al terminar.
+func connectMySQL(t *testing.T) *sql.DB {
+	t.Helper()
+	if mysqlDSN == "" {
+		t.Skip("MySQL no disponible; indique un servidor con BANK_TEST_MYSQL_DSN")
+	}
+	cfg, err := mysql.ParseDSN(mysqlDSN)
+	if err != nil {
+		t.Fatalf("BANK_TEST_MYSQL_DSN inválido: %v", err)
+	}
+	admin, err := database.Open(database.MySQL, mysqlDSN)
+	if err != nil {
+		t.Fatalf("No se pudo abrir MySQL: %v", err)
+	}
+	name := fmt.Sprintf("bank_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+		admin.Close()
+		t.Fatalf("No se pudo crear la base de la prueba: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP DATABASE " + name)
+		admin.Close()
+	})
+
+	cfg.DBName = name
+	db, err := database.Open(database.MySQL, cfg.FormatDSN())
+	if err != nil {
+		t.Fatalf("No se pudo abrir MySQL: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// migrateUp aplica todas las migraciones del motor.
+func migrateUp(t *testing.T, db *sql.DB, driver database.Driver) {
+	t.Helper()
+	migrator, err := database.NewMigrator(db, driver, 5*time.Second)
+	if err != nil {
+		t.Fatalf("No se pudo crear el Migrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("No se pudieron aplicar las migraciones: %v", err)
+	}
+}
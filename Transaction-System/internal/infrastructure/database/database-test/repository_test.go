@@ -0,0 +1,720 @@
+package database_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/hold"
+	"Transaction-System/internal/domain/idempotency"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/database"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// usd crea un monto en la moneda por defecto
+func usd(s string) money.Money {
+	return money.MustParse(s, money.DefaultCurrency)
+}
+
+// Prueba que las migraciones se aplican, se revierten y se vuelven a aplicar
+func TestBackend_Migrations(t *testing.T) {
+	forEachBackend(t, testMigrations)
+}
+
+func testMigrations(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	migrator, _ := database.NewMigrator(db, driver, time.Second)
+
+	if pending, err := migrator.Pending(ctx); err != nil || len(pending) != 0 {
+		t.Fatalf("No se esperaban migraciones pendientes: %v (%d)", err, len(pending))
+	}
+	reverted, err := migrator.Down(ctx, 3)
+	if err != nil || len(reverted) != 3 {
+		t.Fatalf("Se esperaban 3 migraciones revertidas: %v (%d)", err, len(reverted))
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("No se pudieron volver a aplicar las migraciones: %v", err)
+	}
+	if err := database.SchemaCheck(migrator).Check(ctx); err != nil {
+		t.Errorf("El esquema debería estar al día: %v", err)
+	}
+}
+
+// Prueba que dos instancias que migran a la vez se turnan mediante el bloqueo de migraciones
+func TestBackend_ConcurrentMigrations(t *testing.T) {
+	forEachBackend(t, testConcurrentMigrations)
+}
+
+func testConcurrentMigrations(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	migrator, _ := database.NewMigrator(db, driver, 5*time.Second)
+	if _, err := migrator.Down(ctx, 3); err != nil {
+		t.Fatalf("No se pudieron revertir las migraciones: %v", err)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	applied := 0
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instance, _ := database.NewMigrator(db, driver, 5*time.Second)
+			migrations, err := instance.Up(ctx)
+			if err != nil {
+				t.Errorf("Error al aplicar las migraciones: %v", err)
+			}
+			mu.Lock()
+			applied += len(migrations)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if applied != 3 {
+		t.Errorf("Se esperaban 3 migraciones aplicadas entre ambas instancias, obtenidas %d", applied)
+	}
+}
+
+// baselineSchema es el esquema original del antiguo Docker-MySQL/init.sql, anterior a las migraciones,
+// expresado en el dialecto de cada motor probado.
+var baselineSchema = map[database.Driver][]string{
+	database.SQLite: {
+		`CREATE TABLE accounts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			account_number VARCHAR(20) NOT NULL,
+			balance NUMERIC NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE transactions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			account_id INTEGER NOT NULL REFERENCES accounts(id),
+			amount NUMERIC NOT NULL,
+			transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal')),
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+	},
+	database.MySQL: {
+		`CREATE TABLE accounts (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			account_number VARCHAR(20) NOT NULL,
+			balance DECIMAL(15, 2) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE transactions (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			account_id INT NOT NULL,
+			amount DECIMAL(15, 2) NOT NULL,
+			transaction_type ENUM('deposit', 'withdrawal') NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (account_id) REFERENCES accounts(id)
+		)`,
+	},
+	database.PostgreSQL: {
+		`CREATE TABLE accounts (
+			id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			account_number VARCHAR(20) NOT NULL,
+			balance NUMERIC(15, 2) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE transactions (
+			id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			account_id INTEGER NOT NULL REFERENCES accounts(id),
+			amount NUMERIC(15, 2) NOT NULL,
+			transaction_type VARCHAR(20) NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal')),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+	},
+}
+
+// Prueba que una base con el esquema original y datos se migra hasta la última versión: conserva los datos,
+// termina con las mismas columnas que una base migrada desde cero y sus cuentas operan con normalidad
+func TestBackend_MigrateFromBaseline(t *testing.T) {
+	forEachEmptyBackend(t, testMigrateFromBaseline)
+}
+
+func testMigrateFromBaseline(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	statements := slices.Concat(baselineSchema[driver], []string{
+		"INSERT INTO accounts (account_number, balance) VALUES ('ACC0000001', 150.25)",
+		"INSERT INTO transactions (account_id, amount, transaction_type) VALUES (1, 200.25, 'deposit')",
+		"INSERT INTO transactions (account_id, amount, transaction_type) VALUES (1, 50.00, 'withdrawal')",
+	})
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("No se pudo preparar el esquema original: %v", err)
+		}
+	}
+	migrateUp(t, db, driver)
+
+	// El esquema resultante tiene las mismas columnas que el de una base migrada desde cero
+	for _, b := range backends {
+		if b.driver != driver {
+			continue
+		}
+		fresh := b.connect(t)
+		migrateUp(t, fresh, driver)
+		for _, table := range []string{"accounts", "transactions"} {
+			if got, want := tableColumns(t, db, table), tableColumns(t, fresh, table); !slices.Equal(got, want) {
+				t.Errorf("Columnas de %s tras migrar el esquema original: %v, esperadas %v", table, got, want)
+			}
+		}
+	}
+
+	// La cuenta existente queda activa, en la moneda por defecto y con su historial
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow)
+	legacy, err := accounts.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Error al leer la cuenta existente: %v", err)
+	}
+	if legacy.Status != account.StatusActive || legacy.Version != 0 || legacy.Balance != usd("150.25") {
+		t.Errorf("Cuenta existente incorrecta tras migrar: %+v", legacy)
+	}
+	page, err := transactions.History(ctx, 1, transaction.Filter{Limit: 10})
+	if err != nil || len(page.Transactions) != 2 || page.Transactions[1].Amount != usd("200.25") || page.Transactions[1].TransferID != "" {
+		t.Fatalf("Historial existente incorrecto: %+v (err: %v)", page, err)
+	}
+
+	// Las cuentas nuevas reciben otro número y la cuenta existente transfiere y cuadra con el libro mayor
+	opened, err := accounts.Open(ctx, money.Zero(money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al abrir una cuenta: %v", err)
+	}
+	if _, err := transactions.Transfer(ctx, legacy.ID, opened.ID, usd("100.00")); err != nil {
+		t.Fatalf("Error al transferir desde la cuenta existente: %v", err)
+	}
+	if v, err := application.NewLedgerService(uow).VerifyAccount(ctx, legacy.ID); err != nil || !v.Balanced {
+		t.Errorf("La cuenta existente debería cuadrar con el libro mayor: %v %+v", err, v)
+	}
+}
+
+// tableColumns devuelve los nombres de las columnas de la tabla, en orden.
+func tableColumns(t *testing.T, db *sql.DB, table string) []string {
+	t.Helper()
+	rows, err := db.Query("SELECT * FROM " + table + " WHERE 1 = 0")
+	if err != nil {
+		t.Fatalf("No se pudieron leer las columnas de %s: %v", table, err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatalf("No se pudieron leer las columnas de %s: %v", table, err)
+	}
+	return columns
+}
+
+// Prueba el ciclo completo de los servicios: cuentas, movimientos, historial y libro mayor
+func TestBackend_Services(t *testing.T) {
+	forEachBackend(t, testServices)
+}
+
+func testServices(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow)
+
+	from, err := accounts.Open(ctx, usd("100.10"))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta: %v", err)
+	}
+	to, err := accounts.Open(ctx, money.Zero(money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta: %v", err)
+	}
+	if err := transactions.ProcessTransaction(ctx, from.ID, usd("0.20"), "deposit"); err != nil {
+		t.Fatalf("Error en el depósito: %v", err)
+	}
+	if _, err := transactions.Transfer(ctx, from.ID, to.ID, usd("50.05")); err != nil {
+		t.Fatalf("Error en la transferencia: %v", err)
+	}
+	err = transactions.ProcessTransaction(ctx, to.ID, usd("60.00"), "withdrawal")
+	if !errors.Is(err, account.ErrInsufficientFunds) {
+		t.Fatalf("Se esperaba ErrInsufficientFunds, obtenido %v", err)
+	}
+
+	// Los montos decimales se conservan exactos
+	if acc, _ := accounts.Get(ctx, from.ID); acc.Balance != usd("50.25") {
+		t.Errorf("Balance de origen incorrecto, esperado 50.25, obtenido %v", acc.Balance)
+	}
+	if acc, _ := accounts.Get(ctx, to.ID); acc.Balance != usd("50.05") {
+		t.Errorf("Balance de destino incorrecto, esperado 50.05, obtenido %v", acc.Balance)
+	}
+
+	// El historial se pagina con el cursor y se filtra por monto
+	page, err := transactions.History(ctx, from.ID, transaction.Filter{Limit: 2})
+	if err != nil {
+		t.Fatalf("Error al consultar el historial: %v", err)
+	}
+	if len(page.Transactions) != 2 || page.Transactions[0].TransactionType != "transfer_out" || page.NextCursor == nil {
+		t.Fatalf("Primera página incorrecta: %+v", page)
+	}
+	next, err := transactions.History(ctx, from.ID, transaction.Filter{Limit: 2, After: page.NextCursor})
+	if err != nil {
+		t.Fatalf("Error al consultar la segunda página: %v", err)
+	}
+	if len(next.Transactions) != 1 || next.Transactions[0].Amount != usd("100.10") || next.NextCursor != nil {
+		t.Errorf("Segunda página incorrecta: %+v", next)
+	}
+	minimum := usd("1.00")
+	filtered, _ := transactions.History(ctx, from.ID, transaction.Filter{MinAmount: &minimum, Limit: 10})
+	if len(filtered.Transactions) != 2 {
+		t.Errorf("Se esperaban 2 transacciones de al menos 1.00, obtenidas %d", len(filtered.Transactions))
+	}
+
+	// El libro mayor coincide con los balances y está balanceado
+	ledger := application.NewLedgerService(uow)
+	if v, err := ledger.VerifyAccount(ctx, from.ID); err != nil || !v.Balanced {
+		t.Errorf("La cuenta debería coincidir con el libro mayor: %v %+v", err, v)
+	}
+	if totals, balanced, err := ledger.VerifyJournal(ctx); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado: %v %v", err, totals)
+	}
+}
+
+// Prueba que cada cuenta conserva su moneda y los decimales propios de ella
+func TestBackend_Currencies(t *testing.T) {
+	forEachBackend(t, testCurrencies)
+}
+
+func testCurrencies(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow)
+
+	kwd, err := accounts.Open(ctx, money.MustParse("10.125", "KWD"))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta en KWD: %v", err)
+	}
+	jpy, err := accounts.Open(ctx, money.MustParse("1500", "JPY"))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta en JPY: %v", err)
+	}
+	if err := transactions.ProcessTransaction(ctx, kwd.ID, money.MustParse("0.001", "KWD"), "deposit"); err != nil {
+		t.Fatalf("Error en el depósito: %v", err)
+	}
+
+	// Los balances se leen con la moneda de la cuenta y sin perder el tercer decimal
+	if acc, _ := accounts.Get(ctx, kwd.ID); acc.Balance != money.MustParse("10.126", "KWD") {
+		t.Errorf("Balance en KWD incorrecto, esperado 10.126 KWD, obtenido %v", acc.Balance)
+	}
+	if acc, _ := accounts.Get(ctx, jpy.ID); acc.Balance != money.MustParse("1500", "JPY") {
+		t.Errorf("Balance en JPY incorrecto, esperado 1500 JPY, obtenido %v", acc.Balance)
+	}
+
+	// Un movimiento en otra moneda se rechaza sin modificar la cuenta
+	err = transactions.ProcessTransaction(ctx, jpy.ID, usd("1.00"), "withdrawal")
+	var mismatch *account.CurrencyMismatchError
+	if !errors.As(err, &mismatch) || mismatch.AccountCurrency != "JPY" {
+		t.Fatalf("Se esperaba un CurrencyMismatchError, obtenido %v", err)
+	}
+
+	// El historial devuelve cada transacción en su moneda
+	page, err := transactions.History(ctx, kwd.ID, transaction.Filter{Limit: 10})
+	if err != nil {
+		t.Fatalf("Error al consultar el historial: %v", err)
+	}
+	if len(page.Transactions) != 2 || page.Transactions[0].Amount != money.MustParse("0.001", "KWD") {
+		t.Errorf("Historial incorrecto: %+v", page.Transactions)
+	}
+	minimum := usd("1.00")
+	if _, err := transactions.History(ctx, kwd.ID, transaction.Filter{MinAmount: &minimum, Limit: 10}); !errors.As(err, &mismatch) {
+		t.Errorf("Un filtro en otra moneda debería rechazarse, obtenido %v", err)
+	}
+
+	// El libro mayor lleva cada moneda por separado
+	ledger := application.NewLedgerService(uow)
+	if v, err := ledger.VerifyAccount(ctx, kwd.ID); err != nil || !v.Balanced {
+		t.Errorf("La cuenta debería coincidir con el libro mayor: %v %+v", err, v)
+	}
+	if totals, balanced, err := ledger.VerifyJournal(ctx); err != nil || !balanced || len(totals) != 2 {
+		t.Errorf("El libro mayor debería estar balanceado en dos monedas: %v %v", err, totals)
+	}
+}
+
+// Prueba que las transferencias concurrentes se serializan sin perder actualizaciones
+func TestBackend_ConcurrentTransfers(t *testing.T) {
+	forEachBackend(t, testConcurrentTransfers)
+}
+
+func testConcurrentTransfers(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow, application.WithRetryPolicy(application.RetryPolicy{MaxAttempts: 5}))
+
+	from, _ := accounts.Open(ctx, usd("20.00"))
+	to, _ := accounts.Open(ctx, money.Zero(money.DefaultCurrency))
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := transactions.Transfer(ctx, from.ID, to.ID, usd("1.00")); err != nil {
+				t.Errorf("Error en la transferencia: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if acc, _ := accounts.Get(ctx, from.ID); !acc.Balance.IsZero() {
+		t.Errorf("La cuenta de origen debería quedar en cero, obtenido %v", acc.Balance)
+	}
+	if acc, _ := accounts.Get(ctx, to.ID); acc.Balance != usd("20.00") {
+		t.Errorf("Balance de destino incorrecto, esperado 20.00, obtenido %v", acc.Balance)
+	}
+}
+
+// Prueba que las consultas no esperan a una operación que tiene la cuenta bloqueada para modificarla
+func TestBackend_ReadsDoNotLock(t *testing.T) {
+	forEachBackend(t, testReadsDoNotLock)
+}
+
+func testReadsDoNotLock(t *testing.T, db *sql.DB, driver database.Driver) {
+	if driver == database.SQLite {
+		t.Skip("SQLite serializa todas las transacciones (BEGIN IMMEDIATE); no hay bloqueo por fila")
+	}
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{})
+	accounts := application.NewAccountService(uow)
+	acc, err := accounts.Open(ctx, usd("10.00"))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta: %v", err)
+	}
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- uow.Execute(ctx, func(repos application.Repositories) error {
+			if _, err := repos.Accounts.FindByIDForUpdate(ctx, acc.ID); err != nil {
+				return err
+			}
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	readCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if _, err := accounts.Get(readCtx, acc.ID); err != nil {
+		t.Errorf("La consulta no debería esperar al bloqueo de la cuenta: %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Error en la operación que bloquea la cuenta: %v", err)
+	}
+}
+
+// Prueba las claves de idempotencia: reserva única, respuesta guardada y purga
+func TestBackend_Idempotency(t *testing.T) {
+	forEachBackend(t, testIdempotency)
+}
+
+func testIdempotency(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	repo := database.NewIdempotencyRepository(db, driver, time.Second)
+
+	rec := idempotency.NewRecord("clave-1", "huella", time.Hour)
+	if err := repo.Reserve(ctx, rec); err != nil {
+		t.Fatalf("Error al reservar la clave: %v", err)
+	}
+	if err := repo.Reserve(ctx, idempotency.NewRecord("clave-1", "otra", time.Hour)); !errors.Is(err, idempotency.ErrKeyExists) {
+		t.Fatalf("Se esperaba ErrKeyExists, obtenido %v", err)
+	}
+
+	rec.StatusCode, rec.ContentType, rec.ResponseBody = 200, "text/plain", []byte("ok")
+	if err := repo.Complete(ctx, rec); err != nil {
+		t.Fatalf("Error al completar la clave: %v", err)
+	}
+	found, err := repo.Find(ctx, "clave-1")
+	if err != nil || found.StatusCode != 200 || string(found.ResponseBody) != "ok" {
+		t.Fatalf("Registro incorrecto: %+v (%v)", found, err)
+	}
+	if found.ExpiresAt.Sub(rec.ExpiresAt).Abs() > time.Second {
+		t.Errorf("Vencimiento incorrecto: %v, esperado %v", found.ExpiresAt, rec.ExpiresAt)
+	}
+
+	if deleted, err := repo.DeleteExpired(ctx, time.Now().Add(2*time.Hour)); err != nil || deleted != 1 {
+		t.Errorf("Se esperaba 1 clave eliminada, obtenidas %d (%v)", deleted, err)
+	}
+	if _, err := repo.Find(ctx, "clave-1"); !errors.Is(err, idempotency.ErrNotFound) {
+		t.Errorf("Se esperaba ErrNotFound, obtenido %v", err)
+	}
+}
+
+// Prueba los tipos de cambio fijados, las cotizaciones y una transferencia entre monedas sobre cada motor
+func TestBackend_FX(t *testing.T) {
+	forEachBackend(t, testFX)
+}
+
+func testFX(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	rates := database.NewRateRepository(db, driver, time.Second)
+	quotes := database.NewQuoteRepository(db, driver, time.Second)
+	pair, _ := fx.NewPair("USD", "EUR")
+
+	// Fijar un tipo dos veces lo reemplaza y conserva los diez decimales
+	first, _ := fx.NewRate(pair, "0.9", fx.SourceManual, time.Now())
+	second, _ := fx.NewRate(pair, "0.9215000001", fx.SourceManual, time.Now())
+	for _, rate := range []fx.Rate{first, second} {
+		if err := rates.Set(ctx, rate); err != nil {
+			t.Fatalf("Error al fijar el tipo: %v", err)
+		}
+	}
+	if listed, err := rates.Rates(ctx); err != nil || len(listed) != 1 || listed[0].Decimal() != "0.9215000001" {
+		t.Fatalf("Listado de tipos incorrecto: %+v (err: %v)", listed, err)
+	}
+
+	// La cotización se guarda y se lee con sus montos, tipos y fechas (cada motor redondea la hora a su precisión)
+	rate, err := rates.Rate(ctx, pair)
+	if err != nil {
+		t.Fatalf("Error al leer el tipo: %v", err)
+	}
+	quote, err := fx.NewQuote(rate, usd("100.00"), big.NewRat(25, 10000), time.Minute, time.Now())
+	if err != nil {
+		t.Fatalf("Error al cotizar: %v", err)
+	}
+	if err := quotes.Save(ctx, quote); err != nil {
+		t.Fatalf("Error al guardar la cotización: %v", err)
+	}
+	found, err := quotes.FindByID(ctx, quote.ID)
+	if err != nil {
+		t.Fatalf("Error al leer la cotización: %v", err)
+	}
+	if found.Source != quote.Source || found.Target != quote.Target || found.Rate.Cmp(quote.Rate) != 0 ||
+		found.MidRate.Cmp(quote.MidRate) != 0 || found.Spread.Cmp(quote.Spread) != 0 || found.ExpiresAt.Sub(quote.ExpiresAt).Abs() >= time.Second {
+		t.Errorf("Cotización leída incorrecta: %+v, esperada %+v", found, quote)
+	}
+	if _, err := quotes.FindByID(ctx, "no-existe"); !errors.Is(err, fx.ErrQuoteNotFound) {
+		t.Errorf("Se esperaba ErrQuoteNotFound, obtenido %v", err)
+	}
+
+	// La transferencia guarda la conversión en ambas patas y el libro mayor queda balanceado por moneda
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow, application.WithQuotes(quotes))
+	from, _ := accounts.Open(ctx, usd("500.00"))
+	to, _ := accounts.Open(ctx, money.Zero("EUR"))
+	if _, err := transactions.TransferWithQuote(ctx, from.ID, to.ID, usd("100.00"), quote.ID); err != nil {
+		t.Fatalf("Error al transferir: %v", err)
+	}
+	page, err := transactions.History(ctx, to.ID, transaction.Filter{Limit: 10})
+	if err != nil || len(page.Transactions) != 1 {
+		t.Fatalf("Historial incorrecto: %+v (err: %v)", page, err)
+	}
+	leg := page.Transactions[0]
+	if leg.Amount != quote.Target || leg.Conversion == nil || leg.Conversion.QuoteID != quote.ID ||
+		leg.Conversion.Rate.Cmp(quote.Rate) != 0 || leg.Conversion.CounterAmount != usd("100.00") {
+		t.Errorf("Pata de destino incorrecta: %+v %+v", leg, leg.Conversion)
+	}
+	if _, balanced, err := application.NewLedgerService(uow).VerifyJournal(ctx); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado: %v", err)
+	}
+
+	// La cotización ya se usó: el servicio rechaza otra transferencia y, si dos solicitudes la encontraran
+	// sin usar a la vez, el índice único rechaza la segunda pata de débito
+	if _, err := transactions.TransferWithQuote(ctx, from.ID, to.ID, usd("100.00"), quote.ID); !errors.Is(err, fx.ErrQuoteUsed) {
+		t.Errorf("Se esperaba ErrQuoteUsed al reutilizar la cotización, obtenido %v", err)
+	}
+	debit := transaction.New(from.ID, usd("100.00"), transaction.TypeTransferOut)
+	debit.Conversion = &transaction.Conversion{QuoteID: quote.ID, Rate: quote.Rate, CounterAmount: quote.Target}
+	if err := database.NewTransactionRepository(db, driver, time.Second).Save(ctx, debit); !errors.Is(err, fx.ErrQuoteUsed) {
+		t.Errorf("Se esperaba ErrQuoteUsed del índice único, obtenido %v", err)
+	}
+
+	// Eliminar un tipo inexistente se informa
+	if err := rates.Delete(ctx, pair); err != nil {
+		t.Fatalf("Error al eliminar el tipo: %v", err)
+	}
+	if err := rates.Delete(ctx, pair); !errors.Is(err, fx.ErrRateNotFound) {
+		t.Errorf("Se esperaba ErrRateNotFound, obtenido %v", err)
+	}
+	if _, err := rates.Rate(ctx, pair); !errors.Is(err, fx.ErrRateNotFound) {
+		t.Errorf("Se esperaba ErrRateNotFound, obtenido %v", err)
+	}
+}
+
+// Prueba sobre cada motor que el sobregiro y el día de intereses cobrado se guardan, que el listado
+// filtra las cuentas sobregiradas y que el cobro de intereses registra su transacción una sola vez
+func TestBackend_Overdraft(t *testing.T) {
+	forEachBackend(t, testOverdraft)
+}
+
+func testOverdraft(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow)
+	interest := application.NewInterestService(uow, application.InterestPolicy{AnnualRate: big.NewRat(18, 100), DaysPerYear: 360, DailyFee: big.NewRat(1, 4)})
+
+	overdrawn, _ := accounts.Open(ctx, usd("100.00"))
+	if _, err := accounts.Open(ctx, usd("100.00")); err != nil {
+		t.Fatalf("Error al abrir la cuenta: %v", err)
+	}
+	if _, err := accounts.SetOverdraftLimit(ctx, overdrawn.ID, usd("1000.50")); err != nil {
+		t.Fatalf("Error al fijar el sobregiro: %v", err)
+	}
+	if err := transactions.ProcessTransaction(ctx, overdrawn.ID, usd("1100.00"), "withdrawal"); err != nil {
+		t.Fatalf("Error en el retiro con sobregiro: %v", err)
+	}
+	err := transactions.ProcessTransaction(ctx, overdrawn.ID, usd("0.51"), "withdrawal")
+	if !errors.Is(err, account.ErrOverdraftLimitExceeded) {
+		t.Fatalf("Se esperaba ErrOverdraftLimitExceeded, obtenido %v", err)
+	}
+
+	// Sólo la cuenta sobregirada aparece en el listado filtrado
+	listed, err := accounts.List(ctx, account.ListFilter{Overdrawn: true, Limit: 10})
+	if err != nil || listed.Total != 1 || listed.Accounts[0].ID != overdrawn.ID || listed.Accounts[0].OverdraftLimit() != usd("1000.50") {
+		t.Fatalf("Listado de cuentas sobregiradas incorrecto: %+v (err: %v)", listed, err)
+	}
+
+	// 1000.00 × 0.18 / 360 = 0.50 de intereses más 0.25 de comisión, cobrados una sola vez por día
+	day := time.Date(2024, time.September, 10, 0, 0, 0, 0, time.UTC)
+	for range 2 {
+		if _, err := interest.AccrueOverdraftInterest(ctx, day); err != nil {
+			t.Fatalf("Error al cobrar los intereses: %v", err)
+		}
+	}
+	acc, _ := accounts.Get(ctx, overdrawn.ID)
+	if acc.Balance != usd("-1000.75") || !acc.InterestAccruedOn.Equal(day) {
+		t.Errorf("Cuenta incorrecta tras el cobro: balance %v, día cobrado %v", acc.Balance, acc.InterestAccruedOn)
+	}
+	page, err := transactions.History(ctx, overdrawn.ID, transaction.Filter{Types: []string{transaction.TypeOverdraftInterest}, Limit: 10})
+	if err != nil || len(page.Transactions) != 1 || page.Transactions[0].Amount != usd("0.50") {
+		t.Errorf("Se esperaba una transacción de intereses de 0.50: %+v (err: %v)", page, err)
+	}
+	page, err = transactions.History(ctx, overdrawn.ID, transaction.Filter{Types: []string{transaction.TypeOverdraftFee}, Limit: 10})
+	if err != nil || len(page.Transactions) != 1 || page.Transactions[0].Amount != usd("0.25") {
+		t.Errorf("Se esperaba una transacción de comisión de 0.25: %+v (err: %v)", page, err)
+	}
+	if v, err := application.NewLedgerService(uow).VerifyAccount(ctx, overdrawn.ID); err != nil || !v.Balanced {
+		t.Errorf("La cuenta debería coincidir con el libro mayor: %v %+v", err, v)
+	}
+}
+
+// Prueba las retenciones de fondos sobre cada motor: los fondos retenidos se guardan con la cuenta,
+// la captura parcial registra una transacción "capture" y las retenciones vencidas se liberan
+func TestBackend_Holds(t *testing.T) {
+	forEachBackend(t, testHolds)
+}
+
+func testHolds(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow)
+	holds := application.NewHoldService(uow, application.HoldPolicy{})
+
+	acc, _ := accounts.Open(ctx, usd("100.00"))
+	authorized, err := holds.Authorize(ctx, acc.ID, usd("60.00"), "pedido-981", time.Hour)
+	if err != nil {
+		t.Fatalf("Error al autorizar la retención: %v", err)
+	}
+	stale, err := holds.Authorize(ctx, acc.ID, usd("25.00"), "", time.Minute)
+	if err != nil {
+		t.Fatalf("Error al autorizar la retención: %v", err)
+	}
+	stored, _ := accounts.Get(ctx, acc.ID)
+	if stored.Balance != usd("100.00") || stored.HeldAmount() != usd("85.00") || stored.Available() != usd("15.00") {
+		t.Errorf("Balance %v, retenido %v, disponible %v", stored.Balance, stored.HeldAmount(), stored.Available())
+	}
+	// Un retiro no puede usar los fondos retenidos
+	if err := transactions.ProcessTransaction(ctx, acc.ID, usd("20.00"), "withdrawal"); !errors.Is(err, account.ErrInsufficientFunds) {
+		t.Errorf("Se esperaba ErrInsufficientFunds, obtenido %v", err)
+	}
+
+	captured, err := holds.Capture(ctx, authorized.ID, usd("40.00"))
+	if err != nil {
+		t.Fatalf("Error al capturar la retención: %v", err)
+	}
+	reloaded, err := holds.Get(ctx, authorized.ID)
+	if err != nil || reloaded.Status != hold.StatusCaptured || reloaded.Captured != usd("40.00") ||
+		reloaded.TransactionID != captured.TransactionID || reloaded.Reference != "pedido-981" || reloaded.ClosedAt.IsZero() {
+		t.Errorf("Retención capturada incorrecta: %+v (err: %v)", reloaded, err)
+	}
+	page, err := transactions.History(ctx, acc.ID, transaction.Filter{Types: []string{transaction.TypeCapture}, Limit: 10})
+	if err != nil || len(page.Transactions) != 1 || page.Transactions[0].Amount != usd("40.00") {
+		t.Errorf("Se esperaba una transacción de captura de 40.00: %+v (err: %v)", page, err)
+	}
+
+	expired, err := holds.ExpireHolds(ctx, time.Now().Add(10*time.Minute))
+	if err != nil || expired != 1 {
+		t.Fatalf("Se esperaba una retención vencida, obtenidas %d (err: %v)", expired, err)
+	}
+	if got, _ := holds.Get(ctx, stale.ID); got.Status != hold.StatusExpired {
+		t.Errorf("La retención debería estar vencida, está %s", got.Status)
+	}
+	stored, _ = accounts.Get(ctx, acc.ID)
+	if stored.Balance != usd("60.00") || !stored.HeldAmount().IsZero() || stored.Available() != usd("60.00") {
+		t.Errorf("Balance %v, retenido %v, disponible %v", stored.Balance, stored.HeldAmount(), stored.Available())
+	}
+	if _, err := holds.Get(ctx, "00000000-0000-4000-8000-000000000000"); !errors.Is(err, hold.ErrNotFound) {
+		t.Errorf("Se esperaba hold.ErrNotFound, obtenido %v", err)
+	}
+	if v, err := application.NewLedgerService(uow).VerifyAccount(ctx, acc.ID); err != nil || !v.Balanced {
+		t.Errorf("La cuenta debería coincidir con el libro mayor: %v %+v", err, v)
+	}
+}
+
+// Prueba la reversión de transacciones sobre cada motor: la reversión se guarda enlazada a la original,
+// se encuentra por ese enlace y no puede repetirse
+func TestBackend_Reversals(t *testing.T) {
+	forEachBackend(t, testReversals)
+}
+
+func testReversals(t *testing.T, db *sql.DB, driver database.Driver) {
+	ctx := context.Background()
+	uow := database.NewUnitOfWork(db, driver, database.Timeouts{Query: time.Second, Transaction: 5 * time.Second})
+	accounts := application.NewAccountService(uow)
+	transactions := application.NewTransactionService(uow)
+
+	acc, _ := accounts.Open(ctx, usd("100.00"))
+	if err := transactions.ProcessTransaction(ctx, acc.ID, usd("25.50"), transaction.TypeWithdrawal); err != nil {
+		t.Fatalf("Error en el retiro: %v", err)
+	}
+	page, err := transactions.History(ctx, acc.ID, transaction.Filter{Types: []string{transaction.TypeWithdrawal}, Limit: 1})
+	if err != nil || len(page.Transactions) != 1 {
+		t.Fatalf("No se encontró el retiro: %+v (err: %v)", page, err)
+	}
+	withdrawal := page.Transactions[0]
+
+	reversal, err := transactions.Reverse(ctx, withdrawal.ID)
+	if err != nil {
+		t.Fatalf("Error al revertir el retiro: %v", err)
+	}
+	if _, err := transactions.Reverse(ctx, withdrawal.ID); !errors.Is(err, transaction.ErrAlreadyReversed) {
+		t.Errorf("Se esperaba ErrAlreadyReversed, obtenido %v", err)
+	}
+	if _, err := transactions.Reverse(ctx, 999999); !errors.Is(err, transaction.ErrNotFound) {
+		t.Errorf("Se esperaba ErrNotFound, obtenido %v", err)
+	}
+
+	// La reversión se lee de vuelta con su enlace a la original
+	page, err = transactions.History(ctx, acc.ID, transaction.Filter{Types: []string{transaction.TypeReversal}, Limit: 10})
+	if err != nil || len(page.Transactions) != 1 || page.Transactions[0].ID != reversal.ID ||
+		page.Transactions[0].ReversalOf != withdrawal.ID || page.Transactions[0].Amount != usd("25.50") {
+		t.Errorf("Reversión guardada incorrecta: %+v (err: %v)", page, err)
+	}
+	stored, _ := accounts.Get(ctx, acc.ID)
+	if stored.Balance != usd("100.00") {
+		t.Errorf("Balance incorrecto tras la reversión, esperado 100.00, obtenido %v", stored.Balance)
+	}
+	if v, err := application.NewLedgerService(uow).VerifyAccount(ctx, acc.ID); err != nil || !v.Balanced {
+		t.Errorf("La cuenta debería coincidir con el libro mayor: %v %+v", err, v)
+	}
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registra el driver "pgx" de PostgreSQL en database/sql
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// dbtx abstrae las operaciones comunes entre *sql.DB y *sql.Tx.
+// Gracias a esta interfaz, los repositorios pueden trabajar tanto con la conexión directa
+// como dentro de una transacción abierta por la unidad de trabajo.
+// Se usan las variantes *Context para que una solicitud cancelada o vencida interrumpa sus consultas.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Timeouts agrupa los plazos máximos de las operaciones contra la base de datos.
+// Un plazo cero no impone límite más allá del que ya tenga el contexto recibido.
+type Timeouts struct {
+	Query       time.Duration // Plazo de cada operación de un repositorio (una consulta o un grupo de ellas)
+	Transaction time.Duration // Plazo de una unidad de trabajo completa, desde BeginTx hasta Commit
+}
+
+// withTimeout deriva de ctx un contexto que vence tras d; si d es cero devuelve ctx sin cambios.
+// La función de cancelación devuelta siempre debe invocarse al terminar la operación.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// timestampLayout es el formato en que MySQL devuelve las columnas TIMESTAMP cuando
+// la conexión no usa parseTime.
+const timestampLayout = "2006-01-02 15:04:05"
+
+// parseTimestamp convierte el texto de una columna TIMESTAMP en un valor time.Time.
+// Además del formato de MySQL acepta RFC 3339, que es el texto que database/sql produce
+// al leer en un string las fechas que los drivers de SQLite y PostgreSQL ya devuelven como time.Time.
+// Estos últimos pueden traer la zona horaria de la sesión, por lo que el resultado se lleva a UTC.
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(timestampLayout, value)
+}
+
+// dateLayout es el formato con que se guardan y se leen las columnas DATE.
+const dateLayout = "2006-01-02"
+
+// parseDate convierte el texto de una columna DATE en un valor time.Time a medianoche UTC.
+// MySQL devuelve la fecha sola; SQLite y PostgreSQL la devuelven como time.Time, que database/sql
+// convierte a RFC 3339, por lo que sólo se interpretan los primeros diez caracteres.
+func parseDate(value string) (time.Time, error) {
+	if len(value) > len(dateLayout) {
+		value = value[:len(dateLayout)]
+	}
+	return time.Parse(dateLayout, value)
+}
+
+// nullDate devuelve el día de t en el formato de las columnas DATE, o nil (NULL) si t es cero.
+func nullDate(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(dateLayout)
+}
+
+// nullTimestamp devuelve t en UTC para una columna TIMESTAMP, o nil (NULL) si t es cero.
+func nullTimestamp(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
+
+// scanMoney interpreta el valor de una columna de montos en la moneda indicada, redondeando a sus decimales.
+// El valor se recibe sin convertir: MySQL y PostgreSQL devuelven los DECIMAL como texto y SQLite como número.
+// Retorna un error si la moneda guardada no está soportada.
+func scanMoney(value any, currency string) (money.Money, error) {
+	m, err := money.New(0, currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if err := m.Scan(value); err != nil {
+		return money.Money{}, err
+	}
+	return m, nil
+}
+
+// scanRate interpreta el valor de una columna de tipos de cambio.
+// MySQL y PostgreSQL devuelven los DECIMAL como texto; SQLite guarda los tipos de cambio como TEXT.
+func scanRate(value any) (*big.Rat, error) {
+	var text string
+	switch v := value.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	default:
+		return nil, fmt.Errorf("%w: tipo %T no soportado", fx.ErrInvalidRate, value)
+	}
+	rate, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", fx.ErrInvalidRate, text)
+	}
+	return rate, nil
+}
+
+// isDuplicateKey indica si el error corresponde a una violación de clave única en cualquiera de los motores.
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062 // ER_DUP_ENTRY
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505" // unique_violation
+	}
+	return false
+}
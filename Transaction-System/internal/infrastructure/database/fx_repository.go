@@ -0,0 +1,199 @@
+package database
+
+import (
+	"Transaction-System/internal/domain/fx"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RateRepository es la implementación de fx.RateStore sobre una base de datos SQL.
+// Los tipos de cambio fijados por un administrador se almacenan en la tabla 'fx_rates'.
+type RateRepository struct {
+	db      dbtx          // Conexión a la base de datos SQL
+	driver  Driver        // Motor de la base de datos
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
+}
+
+// Aseguramos que RateRepository implementa la interfaz fx.RateStore.
+var _ fx.RateStore = &RateRepository{}
+
+// NewRateRepository crea una nueva instancia de RateRepository.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
+// Retorna:
+// - Un puntero a RateRepository que puede usarse para fijar y consultar tipos de cambio.
+func NewRateRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *RateRepository {
+	return &RateRepository{db: driver.bind(db), driver: driver, timeout: queryTimeout}
+}
+
+// Rate devuelve el tipo de cambio fijado para el par.
+// Retorna:
+// - fx.Rate: el tipo de cambio, con origen fx.SourceManual.
+// - error: fx.ErrRateNotFound si el par no tiene un tipo fijado, u otro error si la consulta falla.
+func (r *RateRepository) Rate(ctx context.Context, pair fx.Pair) (fx.Rate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, "SELECT base_currency, quote_currency, rate, updated_at FROM fx_rates WHERE base_currency = ? AND quote_currency = ?",
+		pair.Base, pair.Quote)
+	rate, err := scanFXRate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fx.Rate{}, fx.ErrRateNotFound
+	}
+	return rate, err
+}
+
+// Rates devuelve todos los tipos de cambio fijados, ordenados por par.
+func (r *RateRepository) Rates(ctx context.Context) ([]fx.Rate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT base_currency, quote_currency, rate, updated_at FROM fx_rates ORDER BY base_currency, quote_currency")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []fx.Rate
+	for rows.Next() {
+		rate, err := scanFXRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+// Set guarda el tipo de cambio del par, reemplazando el anterior si existía.
+func (r *RateRepository) Set(ctx context.Context, rate fx.Rate) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := r.driver.upsert("INSERT INTO fx_rates (base_currency, quote_currency, rate, updated_at) VALUES (?, ?, ?, ?)",
+		[]string{"base_currency", "quote_currency"}, []string{"rate", "updated_at"})
+	_, err := r.db.ExecContext(ctx, query, rate.Pair.Base, rate.Pair.Quote, fx.FormatRate(rate.Value), rate.UpdatedAt.UTC())
+	return err
+}
+
+// Delete elimina el tipo de cambio fijado para el par.
+// Retorna:
+// - error: fx.ErrRateNotFound si el par no tenía un tipo fijado, u otro error si la eliminación falla.
+func (r *RateRepository) Delete(ctx context.Context, pair fx.Pair) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM fx_rates WHERE base_currency = ? AND quote_currency = ?", pair.Base, pair.Quote)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fx.ErrRateNotFound
+	}
+	return nil
+}
+
+// scanFXRate lee una fila de 'fx_rates'.
+func scanFXRate(row rowScanner) (fx.Rate, error) {
+	var rate fx.Rate
+	var value any // Tipo de cambio sin convertir
+	var updatedAt string
+	if err := row.Scan(&rate.Pair.Base, &rate.Pair.Quote, &value, &updatedAt); err != nil {
+		return fx.Rate{}, err
+	}
+	var err error
+	if rate.Value, err = scanRate(value); err != nil {
+		return fx.Rate{}, fmt.Errorf("tipo de cambio %s: %w", rate.Pair, err)
+	}
+	if rate.UpdatedAt, err = parseTimestamp(updatedAt); err != nil {
+		return fx.Rate{}, err
+	}
+	rate.Source = fx.SourceManual
+	return rate, nil
+}
+
+// QuoteRepository es la implementación de fx.QuoteRepository sobre una base de datos SQL.
+// Las cotizaciones emitidas se almacenan en la tabla 'fx_quotes'.
+type QuoteRepository struct {
+	db      dbtx          // Conexión a la base de datos SQL
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
+}
+
+// Aseguramos que QuoteRepository implementa la interfaz fx.QuoteRepository.
+var _ fx.QuoteRepository = &QuoteRepository{}
+
+// NewQuoteRepository crea una nueva instancia de QuoteRepository.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
+// Retorna:
+// - Un puntero a QuoteRepository que puede usarse para guardar y consultar cotizaciones.
+func NewQuoteRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *QuoteRepository {
+	return &QuoteRepository{db: driver.bind(db), timeout: queryTimeout}
+}
+
+// Save guarda una cotización recién emitida.
+func (r *QuoteRepository) Save(ctx context.Context, q *fx.Quote) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO fx_quotes (id, source_amount, source_currency, target_amount, target_currency, mid_rate, spread, rate, rate_source, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		q.ID, q.Source, q.Source.Currency(), q.Target, q.Target.Currency(),
+		fx.FormatRate(q.MidRate), fx.FormatRate(q.Spread), fx.FormatRate(q.Rate), q.RateSource, q.CreatedAt.UTC(), q.ExpiresAt.UTC())
+	return err
+}
+
+// FindByID busca una cotización por su identificador.
+// Retorna:
+// - *fx.Quote: la cotización encontrada.
+// - error: fx.ErrQuoteNotFound si no existe, u otro error si la consulta falla.
+func (r *QuoteRepository) FindByID(ctx context.Context, id string) (*fx.Quote, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var q fx.Quote
+	var sourceAmount, targetAmount, midRate, spread, rate any // Valores sin convertir
+	var sourceCurrency, targetCurrency, createdAt, expiresAt string
+	err := r.db.QueryRowContext(ctx, "SELECT id, source_amount, source_currency, target_amount, target_currency, mid_rate, spread, rate, rate_source, created_at, expires_at FROM fx_quotes WHERE id = ?", id).
+		Scan(&q.ID, &sourceAmount, &sourceCurrency, &targetAmount, &targetCurrency, &midRate, &spread, &rate, &q.RateSource, &createdAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fx.ErrQuoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Convertir los montos, los tipos de cambio y las fechas
+	if q.Source, err = scanMoney(sourceAmount, sourceCurrency); err != nil {
+		return nil, fmt.Errorf("monto de origen de la cotización %s: %w", q.ID, err)
+	}
+	if q.Target, err = scanMoney(targetAmount, targetCurrency); err != nil {
+		return nil, fmt.Errorf("monto de destino de la cotización %s: %w", q.ID, err)
+	}
+	if q.MidRate, err = scanRate(midRate); err != nil {
+		return nil, err
+	}
+	if q.Spread, err = scanRate(spread); err != nil {
+		return nil, err
+	}
+	if q.Rate, err = scanRate(rate); err != nil {
+		return nil, err
+	}
+	if q.CreatedAt, err = parseTimestamp(createdAt); err != nil {
+		return nil, err
+	}
+	if q.ExpiresAt, err = parseTimestamp(expiresAt); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"Transaction-System/internal/infrastructure/health"
+	"Transaction-System/internal/infrastructure/migrate"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// PingCheck devuelve una comprobación de estado que verifica la conexión con la base de datos.
+func PingCheck(db *sql.DB) health.Checker {
+	return health.CheckerFunc(db.PingContext)
+}
+
+// SchemaCheck devuelve una comprobación de estado que verifica que el esquema esté al día,
+// es decir, que todas las migraciones embebidas estén aplicadas y ninguna haya quedado incompleta.
+func SchemaCheck(migrator *migrate.Migrator) health.Checker {
+	return health.CheckerFunc(func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return fmt.Errorf("no se pudo consultar el esquema: %w", err)
+		}
+		if len(pending) > 0 {
+			names := make([]string, len(pending))
+			for i, m := range pending {
+				names[i] = fmt.Sprintf("%04d_%s", m.Version, m.Name)
+			}
+			return fmt.Errorf("faltan migraciones del esquema: %s", strings.Join(names, ", "))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"Transaction-System/internal/domain/hold"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// HoldRepository es la implementación de hold.Repository sobre una base de datos SQL.
+// Las retenciones de fondos se almacenan en la tabla 'holds'.
+type HoldRepository struct {
+	db      dbtx          // Conexión a la base de datos SQL o transacción en curso
+	driver  Driver        // Motor de la base de datos
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
+}
+
+// Aseguramos que HoldRepository implementa la interfaz hold.Repository.
+var _ hold.Repository = &HoldRepository{}
+
+// NewHoldRepository crea una nueva instancia de HoldRepository.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
+// Retorna:
+// - Un puntero a HoldRepository que puede usarse para guardar y consultar retenciones.
+func NewHoldRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *HoldRepository {
+	return &HoldRepository{db: driver.bind(db), driver: driver, timeout: queryTimeout}
+}
+
+// holdColumns es la lista de columnas que se leen de la tabla 'holds'.
+const holdColumns = "id, account_id, amount, captured_amount, currency, reference, status, transaction_id, created_at, expires_at, closed_at"
+
+// Save guarda una retención recién autorizada.
+func (r *HoldRepository) Save(ctx context.Context, h *hold.Hold) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO holds ("+holdColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		h.ID, h.AccountID, h.Amount, h.Captured, h.Amount.Currency(), sql.NullString{String: h.Reference, Valid: h.Reference != ""},
+		string(h.Status), sql.NullInt64{Int64: int64(h.TransactionID), Valid: h.TransactionID != 0},
+		h.CreatedAt.UTC(), h.ExpiresAt.UTC(), nullTimestamp(h.ClosedAt))
+	return err
+}
+
+// Update guarda el estado, el monto capturado, la transacción y la fecha de cierre de una retención.
+// Retorna:
+// - error: hold.ErrNotFound si la retención no existe, u otro error si la actualización falla.
+func (r *HoldRepository) Update(ctx context.Context, h *hold.Hold) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, "UPDATE holds SET captured_amount = ?, status = ?, transaction_id = ?, closed_at = ? WHERE id = ?",
+		h.Captured, string(h.Status), sql.NullInt64{Int64: int64(h.TransactionID), Valid: h.TransactionID != 0}, nullTimestamp(h.ClosedAt), h.ID)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return hold.ErrNotFound
+	}
+	return nil
+}
+
+// FindByID busca una retención por su identificador, sin bloquearla.
+// Retorna:
+// - *hold.Hold: la retención encontrada.
+// - error: hold.ErrNotFound si no existe, u otro error si la consulta falla.
+func (r *HoldRepository) FindByID(ctx context.Context, id string) (*hold.Hold, error) {
+	return r.find(ctx, id, "")
+}
+
+// FindByIDForUpdate busca una retención para modificarla dentro de la transacción.
+// En PostgreSQL la fila queda bloqueada hasta confirmar.
+// Retorna hold.ErrNotFound si no existe, u otro error si la consulta falla.
+func (r *HoldRepository) FindByIDForUpdate(ctx context.Context, id string) (*hold.Hold, error) {
+	return r.find(ctx, id, r.driver.forUpdate())
+}
+
+// find ejecuta la consulta de una retención por ID agregando la cláusula de bloqueo indicada.
+func (r *HoldRepository) find(ctx context.Context, id, lock string) (*hold.Hold, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	h, err := scanHold(r.db.QueryRowContext(ctx, "SELECT "+holdColumns+" FROM holds WHERE id = ?"+lock, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, hold.ErrNotFound
+	}
+	return h, err
+}
+
+// FindExpired devuelve hasta limit retenciones activas vencidas en now, de la que venció primero a la última.
+// La consulta usa el índice (status, expires_at).
+func (r *HoldRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*hold.Hold, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+holdColumns+" FROM holds WHERE status = ? AND expires_at <= ? ORDER BY expires_at, id LIMIT ?",
+		string(hold.StatusActive), now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []*hold.Hold
+	for rows.Next() {
+		h, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, h)
+	}
+	return holds, rows.Err()
+}
+
+// scanHold lee una fila de 'holds' con las columnas de holdColumns.
+func scanHold(row rowScanner) (*hold.Hold, error) {
+	var h hold.Hold
+	var amount, captured any // Montos sin convertir; se interpretan con los decimales de su moneda
+	var currency, status, createdAt, expiresAt string
+	var reference, closedAt sql.NullString
+	var transactionID sql.NullInt64
+	if err := row.Scan(&h.ID, &h.AccountID, &amount, &captured, &currency, &reference, &status, &transactionID,
+		&createdAt, &expiresAt, &closedAt); err != nil {
+		return nil, err
+	}
+	h.Reference = reference.String
+	h.Status = hold.Status(status)
+	h.TransactionID = int(transactionID.Int64)
+
+	var err error
+	if h.Amount, err = scanMoney(amount, currency); err != nil {
+		return nil, fmt.Errorf("monto de la retención %s: %w", h.ID, err)
+	}
+	if h.Captured, err = scanMoney(captured, currency); err != nil {
+		return nil, fmt.Errorf("monto capturado de la retención %s: %w", h.ID, err)
+	}
+	if h.CreatedAt, err = parseTimestamp(createdAt); err != nil {
+		return nil, err
+	}
+	if h.ExpiresAt, err = parseTimestamp(expiresAt); err != nil {
+		return nil, err
+	}
+	if closedAt.Valid {
+		if h.ClosedAt, err = parseTimestamp(closedAt.String); err != nil {
+			return nil, err
+		}
+	}
+	return &h, nil
+}
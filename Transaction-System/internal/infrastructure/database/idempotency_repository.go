@@ -0,0 +1,109 @@
+package database
+
+import (
+	"Transaction-System/internal/domain/idempotency"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// IdempotencyRepository es la implementación de idempotency.Repository sobre una base de datos SQL.
+// Las claves y las respuestas guardadas se almacenan en la tabla 'idempotency_keys'.
+type IdempotencyRepository struct {
+	db      dbtx          // Conexión a la base de datos SQL
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
+}
+
+// Aseguramos que IdempotencyRepository implementa la interfaz idempotency.Repository.
+var _ idempotency.Repository = &IdempotencyRepository{}
+
+// NewIdempotencyRepository crea una nueva instancia de IdempotencyRepository.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
+// Retorna:
+// - Un puntero a IdempotencyRepository que puede usarse para registrar claves de idempotencia.
+func NewIdempotencyRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *IdempotencyRepository {
+	return &IdempotencyRepository{db: driver.bind(db), timeout: queryTimeout}
+}
+
+// Reserve registra una clave para una solicitud en curso.
+// La clave primaria de la tabla garantiza que sólo una solicitud concurrente pueda reservarla.
+// Retorna:
+// - error: idempotency.ErrKeyExists si la clave ya existe, u otro error si la inserción falla.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, rec *idempotency.Record) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO idempotency_keys (idempotency_key, fingerprint, created_at, expires_at) VALUES (?, ?, ?, ?)",
+		rec.Key, rec.Fingerprint, rec.CreatedAt.UTC(), rec.ExpiresAt.UTC())
+	if isDuplicateKey(err) {
+		return idempotency.ErrKeyExists
+	}
+	return err
+}
+
+// Find busca el registro asociado a una clave de idempotencia.
+// Retorna:
+// - *idempotency.Record: el registro encontrado.
+// - error: idempotency.ErrNotFound si la clave no existe, u otro error si la consulta falla.
+func (r *IdempotencyRepository) Find(ctx context.Context, key string) (*idempotency.Record, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var rec idempotency.Record
+	var createdAt, expiresAt string
+	err := r.db.QueryRowContext(ctx, "SELECT idempotency_key, fingerprint, status_code, content_type, response_body, created_at, expires_at FROM idempotency_keys WHERE idempotency_key = ?", key).
+		Scan(&rec.Key, &rec.Fingerprint, &rec.StatusCode, &rec.ContentType, &rec.ResponseBody, &createdAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, idempotency.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Convertir las fechas devueltas como texto
+	if rec.CreatedAt, err = parseTimestamp(createdAt); err != nil {
+		return nil, err
+	}
+	if rec.ExpiresAt, err = parseTimestamp(expiresAt); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Complete guarda el código de estado, el tipo de contenido y el cuerpo de la respuesta.
+func (r *IdempotencyRepository) Complete(ctx context.Context, rec *idempotency.Record) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, "UPDATE idempotency_keys SET status_code = ?, content_type = ?, response_body = ? WHERE idempotency_key = ?",
+		rec.StatusCode, rec.ContentType, rec.ResponseBody, rec.Key)
+	return err
+}
+
+// Delete elimina una clave de idempotencia.
+func (r *IdempotencyRepository) Delete(ctx context.Context, key string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE idempotency_key = ?", key)
+	return err
+}
+
+// DeleteExpired elimina las claves cuyo periodo de retención terminó antes del instante indicado.
+// Retorna:
+// - int64: la cantidad de claves eliminadas.
+// - error: retorna un error si la eliminación falla.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ?", now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
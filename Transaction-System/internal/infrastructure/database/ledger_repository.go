@@ -0,0 +1,123 @@
+package database
+
+import (
+	"Transaction-System/internal/domain/ledger"
+	"Transaction-System/internal/domain/money"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// LedgerRepository es la implementación de ledger.Repository sobre una base de datos SQL.
+// Los asientos se guardan en la tabla 'journal_entries' y sus movimientos en la tabla 'postings'.
+type LedgerRepository struct {
+	db      dbtx          // Conexión a la base de datos SQL o transacción en curso
+	driver  Driver        // Motor de la base de datos
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
+}
+
+// Aseguramos que LedgerRepository implementa la interfaz ledger.Repository.
+var _ ledger.Repository = &LedgerRepository{}
+
+// NewLedgerRepository crea una nueva instancia de LedgerRepository.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
+// Retorna:
+// - Un puntero a LedgerRepository que puede usarse para interactuar con el libro mayor.
+func NewLedgerRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *LedgerRepository {
+	return &LedgerRepository{db: driver.bind(db), driver: driver, timeout: queryTimeout}
+}
+
+// Append valida y guarda un asiento contable con todos sus movimientos.
+// Para que el asiento quede completo debe invocarse dentro de una unidad de trabajo.
+// Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
+// - e: el asiento contable a guardar; al finalizar se le asigna el ID generado.
+// Retorna:
+// - error: retorna un error si el asiento no está balanceado o si falla alguna inserción.
+func (r *LedgerRepository) Append(ctx context.Context, e *ledger.JournalEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Rechazar asientos desbalanceados antes de tocar la base de datos
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	// Insertar la cabecera del asiento
+	id, err := r.driver.insert(ctx, r.db, "INSERT INTO journal_entries (reference, description, created_at) VALUES (?, ?, ?)",
+		e.Reference, e.Description, e.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+	e.ID = int(id)
+
+	// Insertar cada movimiento, registrando la cuenta contable si aún no existe
+	for _, p := range e.Postings {
+		if _, err := r.db.ExecContext(ctx, r.driver.insertIgnore("INSERT INTO ledger_accounts (code, name, normal_balance) VALUES (?, ?, ?)"),
+			p.Account.Code, p.Account.Name, string(p.Account.Normal)); err != nil {
+			return err
+		}
+		if _, err := r.db.ExecContext(ctx, "INSERT INTO postings (entry_id, ledger_account, amount, currency) VALUES (?, ?, ?, ?)",
+			e.ID, p.Account.Code, p.Amount, p.Amount.Currency()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sum devuelve la suma de los movimientos de una cuenta contable en la moneda indicada.
+// Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
+// - accountCode: código de la cuenta contable.
+// - currency: moneda de los movimientos a sumar.
+// Retorna:
+// - money.Money: la suma de los movimientos (débitos positivos, créditos negativos).
+// - int: la cantidad de movimientos encontrados.
+// - error: retorna un error si la consulta falla.
+func (r *LedgerRepository) Sum(ctx context.Context, accountCode string, currency string) (money.Money, int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	sum := money.Zero(currency) // Se escanea sobre un monto con moneda para conservarla
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount), 0), COUNT(*) FROM postings WHERE ledger_account = ? AND currency = ?",
+		accountCode, sum.Currency()).Scan(&sum, &count)
+	if err != nil {
+		return money.Money{}, 0, err
+	}
+	return sum, count, nil
+}
+
+// TrialBalance devuelve la suma de todos los movimientos del libro agrupada por moneda.
+// Retorna:
+// - map[string]money.Money: la suma por moneda; en un libro consistente todas son cero.
+// - error: retorna un error si la consulta falla.
+func (r *LedgerRepository) TrialBalance(ctx context.Context) (map[string]money.Money, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT currency, SUM(amount) FROM postings GROUP BY currency")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[string]money.Money)
+	for rows.Next() {
+		// La suma se lee sin convertir: MySQL y PostgreSQL la devuelven como texto decimal y SQLite como número
+		var currency string
+		var raw any
+		if err := rows.Scan(&currency, &raw); err != nil {
+			return nil, err
+		}
+		total, err := scanMoney(raw, currency)
+		if err != nil {
+			return nil, err
+		}
+		totals[total.Currency()] = total
+	}
+	return totals, rows.Err()
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"Transaction-System/internal/infrastructure/migrate"
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// embeddedMigrations contiene las migraciones del esquema de cada motor, embebidas en el binario.
+// Cada motor tiene su directorio con las mismas versiones y nombres; sólo cambia el dialecto SQL.
+//
+//go:embed migrations/mysql/*.sql migrations/sqlite/*.sql migrations/postgres/*.sql
+var embeddedMigrations embed.FS
+
+// migrationLockName es el nombre del bloqueo que serializa las migraciones: un bloqueo con nombre
+// en MySQL y un bloqueo consultivo en PostgreSQL, cuya clave se deriva del nombre con hashtext.
+const migrationLockName = "bankservice_schema_migrations"
+
+// Migrations devuelve las migraciones del esquema del motor indicado, ordenadas por versión.
+func Migrations(driver Driver) ([]migrate.Migration, error) {
+	if driver != MySQL && driver != SQLite && driver != PostgreSQL {
+		return nil, fmt.Errorf("driver de base de datos no soportado: %q", driver)
+	}
+	sub, err := fs.Sub(embeddedMigrations, "migrations/"+string(driver))
+	if err != nil {
+		return nil, err
+	}
+	return migrate.Load(sub)
+}
+
+// NewMigrator crea el Migrator del esquema del motor indicado.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - lockTimeout: tiempo máximo de espera del bloqueo mientras otra instancia aplica migraciones.
+// Retorna:
+// - Un puntero a migrate.Migrator, o un error si las migraciones embebidas son inválidas.
+func NewMigrator(db *sql.DB, driver Driver, lockTimeout time.Duration) (*migrate.Migrator, error) {
+	migrations, err := Migrations(driver)
+	if err != nil {
+		return nil, err
+	}
+	var dialect migrate.Dialect
+	switch driver {
+	case SQLite:
+		dialect = sqliteDialect{}
+	case PostgreSQL:
+		dialect = postgresDialect{lockTimeout: lockTimeout}
+	default:
+		dialect = mysqlDialect{lockTimeout: lockTimeout}
+	}
+	return migrate.New(db, dialect, migrations), nil
+}
+
+// mysqlDialect implementa migrate.Dialect para MySQL.
+type mysqlDialect struct {
+	lockTimeout time.Duration // Espera máxima del bloqueo con nombre
+}
+
+// CreateTable devuelve la sentencia que crea la tabla schema_migrations.
+func (mysqlDialect) CreateTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+}
+
+// Rebind devuelve la consulta sin cambios: MySQL usa '?' como marcador.
+func (mysqlDialect) Rebind(query string) string {
+	return query
+}
+
+// Lock toma el bloqueo con nombre de MySQL (GET_LOCK), que pertenece a la sesión de conn:
+// se libera con RELEASE_LOCK o, si el proceso termina, al cerrarse la conexión.
+func (d mysqlDialect) Lock(ctx context.Context, conn *sql.Conn) (func() error, error) {
+	var acquired sql.NullInt64
+	seconds := int(math.Ceil(d.lockTimeout.Seconds()))
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLockName, seconds).Scan(&acquired); err != nil {
+		return nil, fmt.Errorf("no se pudo tomar el bloqueo de migraciones: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return nil, fmt.Errorf("bloqueo %s tras %s: %w", migrationLockName, d.lockTimeout, migrate.ErrLocked)
+	}
+	return func() error {
+		var released sql.NullInt64
+		return conn.QueryRowContext(context.Background(), "SELECT RELEASE_LOCK(?)", migrationLockName).Scan(&released)
+	}, nil
+}
+
+// sqliteDialect implementa migrate.Dialect para SQLite.
+type sqliteDialect struct{}
+
+// CreateTable devuelve la sentencia que crea la tabla schema_migrations.
+func (sqliteDialect) CreateTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+}
+
+// Rebind devuelve la consulta sin cambios: SQLite acepta '?' como marcador.
+func (sqliteDialect) Rebind(query string) string {
+	return query
+}
+
+// Lock abre sobre conn una transacción que toma el bloqueo de escritura del archivo (BEGIN IMMEDIATE),
+// de modo que las migraciones de otro proceso esperan hasta que ésta se confirme; la espera la limita
+// el busy_timeout de la conexión. Como SQLite admite DDL dentro de una transacción, las migraciones
+// se confirman todas juntas al liberar el bloqueo.
+func (sqliteDialect) Lock(ctx context.Context, conn *sql.Conn) (func() error, error) {
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY {
+			return nil, fmt.Errorf("archivo de la base de datos ocupado: %w", migrate.ErrLocked)
+		}
+		return nil, fmt.Errorf("no se pudo tomar el bloqueo de migraciones: %w", err)
+	}
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), "COMMIT")
+		return err
+	}, nil
+}
+
+// postgresDialect implementa migrate.Dialect para PostgreSQL.
+type postgresDialect struct {
+	lockTimeout time.Duration // Espera máxima del bloqueo consultivo
+}
+
+// postgresLockInterval es la pausa entre intentos de tomar el bloqueo consultivo.
+const postgresLockInterval = 250 * time.Millisecond
+
+// CreateTable devuelve la sentencia que crea la tabla schema_migrations.
+func (postgresDialect) CreateTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		dirty BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+}
+
+// Rebind traduce los marcadores '?' a los marcadores numerados de PostgreSQL.
+func (postgresDialect) Rebind(query string) string {
+	return rebind(query)
+}
+
+// Lock toma un bloqueo consultivo de sesión (pg_try_advisory_lock), que pertenece a la sesión de conn:
+// se libera con pg_advisory_unlock o, si el proceso termina, al cerrarse la conexión.
+// Como pg_advisory_lock no admite un plazo, se reintenta pg_try_advisory_lock hasta agotar lockTimeout.
+func (d postgresDialect) Lock(ctx context.Context, conn *sql.Conn) (func() error, error) {
+	deadline := time.Now().Add(d.lockTimeout)
+	for {
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", migrationLockName).Scan(&acquired); err != nil {
+			return nil, fmt.Errorf("no se pudo tomar el bloqueo de migraciones: %w", err)
+		}
+		if acquired {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("bloqueo %s tras %s: %w", migrationLockName, d.lockTimeout, migrate.ErrLocked)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(postgresLockInterval):
+		}
+	}
+	return func() error {
+		var released bool
+		return conn.QueryRowContext(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", migrationLockName).Scan(&released)
+	}, nil
+}
@@ -0,0 +1,18 @@
+-- Cuentas bancarias y su historial de depósitos y retiros: el esquema original del antiguo Docker-MySQL/init.sql.
+-- Las tablas se crean sólo si no existen para adoptar las bases creadas con ese script; las columnas
+-- e índices posteriores se agregan en las migraciones siguientes.
+CREATE TABLE IF NOT EXISTS accounts (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    account_number VARCHAR(20) NOT NULL,
+    balance DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    account_id INT NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    transaction_type ENUM('deposit', 'withdrawal') NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id)
+);
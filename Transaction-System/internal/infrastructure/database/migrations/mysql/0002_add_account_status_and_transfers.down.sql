@@ -0,0 +1,15 @@
+-- Falla si ya se registraron transferencias: esas transacciones no se eliminan.
+-- El índice (account_id, created_at, id) respalda la clave foránea, así que se reemplaza en la misma
+-- sentencia por el índice sobre account_id que MySQL crea con el esquema original.
+ALTER TABLE transactions
+    ADD INDEX account_id (account_id),
+    DROP INDEX idx_transactions_account_created,
+    DROP INDEX idx_transactions_transfer_id,
+    DROP COLUMN transfer_id,
+    MODIFY transaction_type ENUM('deposit', 'withdrawal') NOT NULL;
+
+ALTER TABLE accounts
+    DROP INDEX idx_accounts_status,
+    DROP INDEX uq_accounts_account_number,
+    DROP COLUMN version,
+    DROP COLUMN status;
@@ -0,0 +1,13 @@
+-- Estado y versión de las cuentas, número de cuenta único, transferencias entre cuentas e índices del historial.
+-- Falla si una base creada con el esquema original tiene números de cuenta repetidos.
+ALTER TABLE accounts
+    ADD COLUMN status ENUM('active', 'frozen', 'closed') NOT NULL DEFAULT 'active' AFTER balance,
+    ADD COLUMN version INT NOT NULL DEFAULT 0 AFTER status,
+    ADD UNIQUE KEY uq_accounts_account_number (account_number),
+    ADD INDEX idx_accounts_status (status);
+
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in') NOT NULL,
+    ADD COLUMN transfer_id CHAR(36) NULL AFTER transaction_type,
+    ADD INDEX idx_transactions_transfer_id (transfer_id),
+    ADD INDEX idx_transactions_account_created (account_id, created_at, id);
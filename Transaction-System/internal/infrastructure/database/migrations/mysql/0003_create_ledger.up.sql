@@ -0,0 +1,30 @@
+-- Libro mayor de partida doble: cuentas contables, asientos y sus movimientos.
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    code VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    normal_balance ENUM('debit', 'credit') NOT NULL
+);
+
+INSERT IGNORE INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:cash', 'Caja', 'debit'),
+    ('system:suspense', 'Partidas transitorias', 'debit'),
+    ('system:fees', 'Ingresos por comisiones', 'credit');
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(64) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_journal_entries_reference (reference)
+);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    entry_id INT NOT NULL,
+    ledger_account VARCHAR(64) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    FOREIGN KEY (entry_id) REFERENCES journal_entries(id),
+    FOREIGN KEY (ledger_account) REFERENCES ledger_accounts(code),
+    INDEX idx_postings_account_currency (ledger_account, currency)
+);
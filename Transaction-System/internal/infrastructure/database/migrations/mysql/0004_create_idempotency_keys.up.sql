@@ -0,0 +1,11 @@
+-- Claves de idempotencia de las solicitudes que mueven dinero y sus respuestas guardadas.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    response_body BLOB NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+);
@@ -0,0 +1,10 @@
+-- Los montos con tres decimales se redondean a dos al revertir.
+ALTER TABLE postings MODIFY amount DECIMAL(15, 2) NOT NULL;
+
+ALTER TABLE transactions
+    DROP COLUMN currency,
+    MODIFY amount DECIMAL(15, 2) NOT NULL;
+
+ALTER TABLE accounts
+    DROP COLUMN currency,
+    MODIFY balance DECIMAL(15, 2) NOT NULL;
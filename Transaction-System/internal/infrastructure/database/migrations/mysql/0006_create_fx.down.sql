@@ -0,0 +1,9 @@
+-- La cuenta contable system:fx se conserva: sus movimientos forman parte del libro mayor.
+ALTER TABLE transactions
+    DROP COLUMN counter_currency,
+    DROP COLUMN counter_amount,
+    DROP COLUMN fx_rate,
+    DROP COLUMN fx_quote_id;
+
+DROP TABLE IF EXISTS fx_quotes;
+DROP TABLE IF EXISTS fx_rates;
@@ -0,0 +1,34 @@
+-- Cambio de divisas: tipos de cambio fijados por un administrador, cotizaciones emitidas
+-- y registro del cambio aplicado en cada pata de una transferencia entre monedas.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate DECIMAL(24, 10) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id CHAR(36) PRIMARY KEY,
+    source_amount DECIMAL(18, 3) NOT NULL,
+    source_currency CHAR(3) NOT NULL,
+    target_amount DECIMAL(18, 3) NOT NULL,
+    target_currency CHAR(3) NOT NULL,
+    mid_rate DECIMAL(24, 10) NOT NULL,
+    spread DECIMAL(12, 10) NOT NULL,
+    rate DECIMAL(24, 10) NOT NULL,
+    rate_source VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Las transacciones guardan la cotización, el tipo aplicado y el monto de la otra pata.
+-- fx_quote_id no es una clave foránea: las cotizaciones nunca se eliminan.
+ALTER TABLE transactions
+    ADD COLUMN fx_quote_id CHAR(36) NULL AFTER transfer_id,
+    ADD COLUMN fx_rate DECIMAL(24, 10) NULL AFTER fx_quote_id,
+    ADD COLUMN counter_amount DECIMAL(18, 3) NULL AFTER fx_rate,
+    ADD COLUMN counter_currency CHAR(3) NULL AFTER counter_amount;
+
+INSERT IGNORE INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:fx', 'Posición de cambio', 'debit');
@@ -0,0 +1,8 @@
+-- Falla si ya se cobraron intereses de sobregiro: esas transacciones no se eliminan.
+-- La cuenta contable system:interest se conserva: sus movimientos forman parte del libro mayor.
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in') NOT NULL;
+
+ALTER TABLE accounts
+    DROP COLUMN interest_accrued_on,
+    DROP COLUMN overdraft_limit;
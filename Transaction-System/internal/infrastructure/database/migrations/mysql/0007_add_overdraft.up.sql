@@ -0,0 +1,11 @@
+-- Sobregiro autorizado por cuenta e intereses diarios sobre el sobregiro en uso.
+-- interest_accrued_on es el último día por el que se cobraron intereses y evita cobrar dos veces el mismo día.
+ALTER TABLE accounts
+    ADD COLUMN overdraft_limit DECIMAL(18, 3) NOT NULL DEFAULT 0 AFTER balance,
+    ADD COLUMN interest_accrued_on DATE NULL AFTER overdraft_limit;
+
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest') NOT NULL;
+
+INSERT IGNORE INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:interest', 'Ingresos por intereses', 'credit');
@@ -0,0 +1,8 @@
+-- Falla si ya se capturaron retenciones: esas transacciones no se eliminan.
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest') NOT NULL;
+
+DROP TABLE IF EXISTS holds;
+
+ALTER TABLE accounts
+    DROP COLUMN held;
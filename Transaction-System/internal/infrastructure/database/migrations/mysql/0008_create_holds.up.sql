@@ -0,0 +1,24 @@
+-- Retenciones de fondos: autorizaciones que reservan parte del disponible de una cuenta hasta su
+-- captura, su liberación o su vencimiento. accounts.held es la suma de las retenciones activas y se
+-- actualiza junto con el balance, bajo el mismo control de versión.
+ALTER TABLE accounts
+    ADD COLUMN held DECIMAL(18, 3) NOT NULL DEFAULT 0 AFTER balance;
+
+CREATE TABLE IF NOT EXISTS holds (
+    id CHAR(36) PRIMARY KEY,
+    account_id INT NOT NULL,
+    amount DECIMAL(18, 3) NOT NULL,
+    captured_amount DECIMAL(18, 3) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    reference VARCHAR(64) NULL,
+    status ENUM('active', 'captured', 'released', 'expired') NOT NULL,
+    transaction_id INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts(id),
+    INDEX idx_holds_status_expires (status, expires_at)
+);
+
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture') NOT NULL;
@@ -0,0 +1,8 @@
+-- Falla si ya se revirtieron transacciones: esas reversiones no se eliminan.
+ALTER TABLE transactions
+    DROP FOREIGN KEY fk_transactions_reversal_of,
+    DROP INDEX idx_transactions_reversal_of,
+    DROP COLUMN reversal_of;
+
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture') NOT NULL;
@@ -0,0 +1,9 @@
+-- Reversiones: una transacción "reversal" mueve el monto de otra en sentido contrario y la referencia
+-- en reversal_of. El índice único impide revertir dos veces la misma transacción.
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture', 'reversal') NOT NULL;
+
+ALTER TABLE transactions
+    ADD COLUMN reversal_of INT NULL AFTER counter_currency,
+    ADD CONSTRAINT fk_transactions_reversal_of FOREIGN KEY (reversal_of) REFERENCES transactions(id),
+    ADD UNIQUE INDEX idx_transactions_reversal_of (reversal_of);
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP INDEX idx_transactions_fx_quote;
@@ -0,0 +1,4 @@
+-- Cada cotización liquida una sola transferencia: el índice único admite una pata de débito y una de
+-- crédito por cotización y rechaza una segunda transferencia aunque las solicitudes sean concurrentes.
+ALTER TABLE transactions
+    ADD UNIQUE INDEX idx_transactions_fx_quote (fx_quote_id, transaction_type);
@@ -0,0 +1,3 @@
+-- Falla si ya se cobraron comisiones de sobregiro: esas transacciones no se eliminan.
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture', 'reversal') NOT NULL;
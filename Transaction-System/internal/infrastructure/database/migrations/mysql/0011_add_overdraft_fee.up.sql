@@ -0,0 +1,4 @@
+-- Comisión de sobregiro: una transacción "overdraft_fee" cobra la comisión diaria fija de una cuenta
+-- sobregirada contra los ingresos por comisiones.
+ALTER TABLE transactions
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture', 'reversal', 'overdraft_fee') NOT NULL;
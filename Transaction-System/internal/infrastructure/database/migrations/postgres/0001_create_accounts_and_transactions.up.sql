@@ -0,0 +1,16 @@
+-- Cuentas bancarias y su historial de depósitos y retiros: el esquema original del antiguo Docker-MySQL/init.sql.
+-- Mismo esquema que en MySQL: los montos usan NUMERIC, los ENUM se expresan con CHECK y las fechas con zona horaria.
+CREATE TABLE IF NOT EXISTS accounts (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    account_number VARCHAR(20) NOT NULL,
+    balance NUMERIC(15, 2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(15, 2) NOT NULL,
+    transaction_type VARCHAR(20) NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
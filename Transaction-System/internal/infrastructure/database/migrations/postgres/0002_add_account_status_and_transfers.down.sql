@@ -0,0 +1,18 @@
+-- Falla si ya se registraron transferencias: esas transacciones no se eliminan.
+DROP INDEX IF EXISTS idx_transactions_account_created;
+
+DROP INDEX IF EXISTS idx_transactions_transfer_id;
+
+ALTER TABLE transactions DROP COLUMN transfer_id;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal'));
+
+DROP INDEX IF EXISTS idx_accounts_status;
+
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_account_number_key,
+    DROP COLUMN version,
+    DROP COLUMN status;
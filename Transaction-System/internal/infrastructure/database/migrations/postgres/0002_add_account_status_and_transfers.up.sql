@@ -0,0 +1,19 @@
+-- Estado y versión de las cuentas, número de cuenta único, transferencias entre cuentas e índices del historial.
+ALTER TABLE accounts
+    ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'frozen', 'closed')),
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT accounts_account_number_key UNIQUE (account_number);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_status ON accounts (status);
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in'));
+
+ALTER TABLE transactions
+    ADD COLUMN transfer_id CHAR(36) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_created ON transactions (account_id, created_at, id);
@@ -0,0 +1,31 @@
+-- Libro mayor de partida doble: cuentas contables, asientos y sus movimientos.
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    code VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    normal_balance VARCHAR(10) NOT NULL CHECK (normal_balance IN ('debit', 'credit'))
+);
+
+INSERT INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:cash', 'Caja', 'debit'),
+    ('system:suspense', 'Partidas transitorias', 'debit'),
+    ('system:fees', 'Ingresos por comisiones', 'credit')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    reference VARCHAR(64) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reference ON journal_entries (reference);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    entry_id INTEGER NOT NULL REFERENCES journal_entries(id),
+    ledger_account VARCHAR(64) NOT NULL REFERENCES ledger_accounts(code),
+    amount NUMERIC(15, 2) NOT NULL,
+    currency CHAR(3) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_postings_account_currency ON postings (ledger_account, currency);
@@ -0,0 +1,10 @@
+-- Los montos con tres decimales se redondean a dos al revertir.
+ALTER TABLE postings ALTER COLUMN amount TYPE NUMERIC(15, 2);
+
+ALTER TABLE transactions
+    DROP COLUMN currency,
+    ALTER COLUMN amount TYPE NUMERIC(15, 2);
+
+ALTER TABLE accounts
+    DROP COLUMN currency,
+    ALTER COLUMN balance TYPE NUMERIC(15, 2);
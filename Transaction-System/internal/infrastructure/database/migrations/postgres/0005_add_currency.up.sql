@@ -0,0 +1,12 @@
+-- Moneda ISO 4217 de cada cuenta y de cada transacción.
+-- Las cuentas y transacciones existentes se asumen en USD. Los montos admiten tres decimales
+-- para las monedas que los usan (por ejemplo KWD); cada moneda redondea a sus propios decimales.
+ALTER TABLE accounts
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ALTER COLUMN balance TYPE NUMERIC(18, 3);
+
+ALTER TABLE transactions
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ALTER COLUMN amount TYPE NUMERIC(18, 3);
+
+ALTER TABLE postings ALTER COLUMN amount TYPE NUMERIC(18, 3);
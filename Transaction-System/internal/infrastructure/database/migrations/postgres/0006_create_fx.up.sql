@@ -0,0 +1,35 @@
+-- Cambio de divisas: tipos de cambio fijados por un administrador, cotizaciones emitidas
+-- y registro del cambio aplicado en cada pata de una transferencia entre monedas.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 10) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id CHAR(36) PRIMARY KEY,
+    source_amount NUMERIC(18, 3) NOT NULL,
+    source_currency CHAR(3) NOT NULL,
+    target_amount NUMERIC(18, 3) NOT NULL,
+    target_currency CHAR(3) NOT NULL,
+    mid_rate NUMERIC(24, 10) NOT NULL,
+    spread NUMERIC(12, 10) NOT NULL,
+    rate NUMERIC(24, 10) NOT NULL,
+    rate_source VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- Las transacciones guardan la cotización, el tipo aplicado y el monto de la otra pata.
+-- fx_quote_id no es una clave foránea: las cotizaciones nunca se eliminan.
+ALTER TABLE transactions
+    ADD COLUMN fx_quote_id CHAR(36) NULL,
+    ADD COLUMN fx_rate NUMERIC(24, 10) NULL,
+    ADD COLUMN counter_amount NUMERIC(18, 3) NULL,
+    ADD COLUMN counter_currency CHAR(3) NULL;
+
+INSERT INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:fx', 'Posición de cambio', 'debit')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,10 @@
+-- Falla si ya se cobraron intereses de sobregiro: esas transacciones no se eliminan.
+-- La cuenta contable system:interest se conserva: sus movimientos forman parte del libro mayor.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in'));
+
+ALTER TABLE accounts
+    DROP COLUMN interest_accrued_on,
+    DROP COLUMN overdraft_limit;
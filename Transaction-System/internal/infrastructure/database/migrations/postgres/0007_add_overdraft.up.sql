@@ -0,0 +1,14 @@
+-- Sobregiro autorizado por cuenta e intereses diarios sobre el sobregiro en uso.
+-- interest_accrued_on es el último día por el que se cobraron intereses y evita cobrar dos veces el mismo día.
+ALTER TABLE accounts
+    ADD COLUMN overdraft_limit NUMERIC(18, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN interest_accrued_on DATE NULL;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest'));
+
+INSERT INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:interest', 'Ingresos por intereses', 'credit')
+ON CONFLICT DO NOTHING;
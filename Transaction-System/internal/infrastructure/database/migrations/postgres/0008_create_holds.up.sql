@@ -0,0 +1,26 @@
+-- Retenciones de fondos: autorizaciones que reservan parte del disponible de una cuenta hasta su
+-- captura, su liberación o su vencimiento. accounts.held es la suma de las retenciones activas y se
+-- actualiza junto con el balance, bajo el mismo control de versión.
+ALTER TABLE accounts
+    ADD COLUMN held NUMERIC(18, 3) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS holds (
+    id CHAR(36) PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(18, 3) NOT NULL,
+    captured_amount NUMERIC(18, 3) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    reference VARCHAR(64) NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('active', 'captured', 'released', 'expired')),
+    transaction_id INTEGER NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_holds_status_expires ON holds (status, expires_at);
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture'));
@@ -0,0 +1,9 @@
+-- Falla si ya se revirtieron transacciones: esas reversiones no se eliminan.
+DROP INDEX IF EXISTS idx_transactions_reversal_of;
+
+ALTER TABLE transactions DROP COLUMN reversal_of;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture'));
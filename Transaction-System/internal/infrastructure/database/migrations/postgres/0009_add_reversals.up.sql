@@ -0,0 +1,11 @@
+-- Reversiones: una transacción "reversal" mueve el monto de otra en sentido contrario y la referencia
+-- en reversal_of. El índice único impide revertir dos veces la misma transacción.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture', 'reversal'));
+
+ALTER TABLE transactions
+    ADD COLUMN reversal_of INTEGER NULL REFERENCES transactions(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_reversal_of ON transactions (reversal_of);
@@ -0,0 +1,3 @@
+-- Cada cotización liquida una sola transferencia: el índice único admite una pata de débito y una de
+-- crédito por cotización y rechaza una segunda transferencia aunque las solicitudes sean concurrentes.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_fx_quote ON transactions (fx_quote_id, transaction_type);
@@ -0,0 +1,5 @@
+-- Falla si ya se cobraron comisiones de sobregiro: esas transacciones no se eliminan.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture', 'reversal'));
@@ -0,0 +1,6 @@
+-- Comisión de sobregiro: una transacción "overdraft_fee" cobra la comisión diaria fija de una cuenta
+-- sobregirada contra los ingresos por comisiones.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture', 'reversal', 'overdraft_fee'));
@@ -0,0 +1,16 @@
+-- Cuentas bancarias y su historial de depósitos y retiros: el esquema original del antiguo Docker-MySQL/init.sql.
+-- Mismo esquema que en MySQL: los montos usan afinidad NUMERIC y los ENUM se expresan con CHECK.
+CREATE TABLE IF NOT EXISTS accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_number VARCHAR(20) NOT NULL,
+    balance NUMERIC NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
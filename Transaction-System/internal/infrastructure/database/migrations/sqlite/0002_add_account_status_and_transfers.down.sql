@@ -0,0 +1,23 @@
+-- Falla si ya se registraron transferencias: esas transacciones no se eliminan.
+CREATE TABLE transactions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO transactions_new (id, account_id, amount, transaction_type, created_at)
+    SELECT id, account_id, amount, transaction_type, created_at FROM transactions;
+
+DROP TABLE transactions;
+
+ALTER TABLE transactions_new RENAME TO transactions;
+
+DROP INDEX IF EXISTS idx_accounts_status;
+
+DROP INDEX IF EXISTS uq_accounts_account_number;
+
+ALTER TABLE accounts DROP COLUMN version;
+
+ALTER TABLE accounts DROP COLUMN status;
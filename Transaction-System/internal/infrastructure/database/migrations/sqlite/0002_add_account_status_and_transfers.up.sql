@@ -0,0 +1,30 @@
+-- Estado y versión de las cuentas, número de cuenta único, transferencias entre cuentas e índices del historial.
+ALTER TABLE accounts ADD COLUMN status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'frozen', 'closed'));
+
+ALTER TABLE accounts ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_accounts_account_number ON accounts (account_number);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_status ON accounts (status);
+
+-- SQLite no permite modificar una restricción CHECK: la tabla de transacciones se reconstruye
+-- con los tipos de transferencia y la nueva columna, conservando los IDs.
+CREATE TABLE transactions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in')),
+    transfer_id CHAR(36) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO transactions_new (id, account_id, amount, transaction_type, created_at)
+    SELECT id, account_id, amount, transaction_type, created_at FROM transactions;
+
+DROP TABLE transactions;
+
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_created ON transactions (account_id, created_at, id);
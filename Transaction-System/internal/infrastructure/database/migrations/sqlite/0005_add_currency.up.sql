@@ -0,0 +1,6 @@
+-- Moneda ISO 4217 de cada cuenta y de cada transacción.
+-- Las cuentas y transacciones existentes se asumen en USD. Las columnas NUMERIC de SQLite no limitan
+-- los decimales, por lo que los montos no cambian de tipo.
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
@@ -0,0 +1,38 @@
+-- Cambio de divisas: tipos de cambio fijados por un administrador, cotizaciones emitidas
+-- y registro del cambio aplicado en cada pata de una transferencia entre monedas.
+-- Los tipos de cambio se guardan como texto: una columna NUMERIC de SQLite los convertiría
+-- en números de punto flotante y perderían exactitud.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id CHAR(36) PRIMARY KEY,
+    source_amount NUMERIC NOT NULL,
+    source_currency CHAR(3) NOT NULL,
+    target_amount NUMERIC NOT NULL,
+    target_currency CHAR(3) NOT NULL,
+    mid_rate TEXT NOT NULL,
+    spread TEXT NOT NULL,
+    rate TEXT NOT NULL,
+    rate_source VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Las transacciones guardan la cotización, el tipo aplicado y el monto de la otra pata.
+-- fx_quote_id no es una clave foránea: las cotizaciones nunca se eliminan.
+ALTER TABLE transactions ADD COLUMN fx_quote_id CHAR(36) NULL;
+
+ALTER TABLE transactions ADD COLUMN fx_rate TEXT NULL;
+
+ALTER TABLE transactions ADD COLUMN counter_amount NUMERIC NULL;
+
+ALTER TABLE transactions ADD COLUMN counter_currency CHAR(3) NULL;
+
+INSERT OR IGNORE INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:fx', 'Posición de cambio', 'debit');
@@ -0,0 +1,35 @@
+-- Sobregiro autorizado por cuenta e intereses diarios sobre el sobregiro en uso.
+-- interest_accrued_on es el último día por el que se cobraron intereses y evita cobrar dos veces el mismo día.
+ALTER TABLE accounts ADD COLUMN overdraft_limit NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE accounts ADD COLUMN interest_accrued_on DATE NULL;
+
+-- SQLite no permite modificar una restricción CHECK: la tabla de transacciones se reconstruye
+-- con el nuevo tipo, conservando los IDs y los índices.
+CREATE TABLE transactions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest')),
+    transfer_id CHAR(36) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    fx_quote_id CHAR(36) NULL,
+    fx_rate TEXT NULL,
+    counter_amount NUMERIC NULL,
+    counter_currency CHAR(3) NULL
+);
+
+INSERT INTO transactions_new (id, account_id, amount, transaction_type, transfer_id, created_at, currency, fx_quote_id, fx_rate, counter_amount, counter_currency)
+    SELECT id, account_id, amount, transaction_type, transfer_id, created_at, currency, fx_quote_id, fx_rate, counter_amount, counter_currency FROM transactions;
+
+DROP TABLE transactions;
+
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_created ON transactions (account_id, created_at, id);
+
+INSERT OR IGNORE INTO ledger_accounts (code, name, normal_balance) VALUES
+    ('system:interest', 'Ingresos por intereses', 'credit');
@@ -0,0 +1,29 @@
+-- Falla si ya se capturaron retenciones: esas transacciones no se eliminan.
+CREATE TABLE transactions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest')),
+    transfer_id CHAR(36) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    fx_quote_id CHAR(36) NULL,
+    fx_rate TEXT NULL,
+    counter_amount NUMERIC NULL,
+    counter_currency CHAR(3) NULL
+);
+
+INSERT INTO transactions_new (id, account_id, amount, transaction_type, transfer_id, created_at, currency, fx_quote_id, fx_rate, counter_amount, counter_currency)
+    SELECT id, account_id, amount, transaction_type, transfer_id, created_at, currency, fx_quote_id, fx_rate, counter_amount, counter_currency FROM transactions;
+
+DROP TABLE transactions;
+
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_created ON transactions (account_id, created_at, id);
+
+DROP TABLE IF EXISTS holds;
+
+ALTER TABLE accounts DROP COLUMN held;
@@ -0,0 +1,47 @@
+-- Retenciones de fondos: autorizaciones que reservan parte del disponible de una cuenta hasta su
+-- captura, su liberación o su vencimiento. accounts.held es la suma de las retenciones activas y se
+-- actualiza junto con el balance, bajo el mismo control de versión.
+ALTER TABLE accounts ADD COLUMN held NUMERIC NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS holds (
+    id CHAR(36) PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    captured_amount NUMERIC NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    reference VARCHAR(64) NULL,
+    status TEXT NOT NULL CHECK (status IN ('active', 'captured', 'released', 'expired')),
+    transaction_id INTEGER NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_holds_status_expires ON holds (status, expires_at);
+
+-- SQLite no permite modificar una restricción CHECK: la tabla de transacciones se reconstruye
+-- con el nuevo tipo, conservando los IDs y los índices.
+CREATE TABLE transactions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal', 'transfer_out', 'transfer_in', 'overdraft_interest', 'capture')),
+    transfer_id CHAR(36) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    fx_quote_id CHAR(36) NULL,
+    fx_rate TEXT NULL,
+    counter_amount NUMERIC NULL,
+    counter_currency CHAR(3) NULL
+);
+
+INSERT INTO transactions_new (id, account_id, amount, transaction_type, transfer_id, created_at, currency, fx_quote_id, fx_rate, counter_amount, counter_currency)
+    SELECT id, account_id, amount, transaction_type, transfer_id, created_at, currency, fx_quote_id, fx_rate, counter_amount, counter_currency FROM transactions;
+
+DROP TABLE transactions;
+
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_created ON transactions (account_id, created_at, id);
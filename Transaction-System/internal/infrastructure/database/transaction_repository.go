@@ -1,14 +1,22 @@
 package database
 
 import (
+	"Transaction-System/internal/domain/fx"
 	"Transaction-System/internal/domain/transaction"
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // TransactionRepository es un repositorio para interactuar con las transacciones en la base de datos.
-// Implementa las operaciones CRUD (en este caso, sólo guardado) para las transacciones en la base de datos.
+// Implementa el guardado de transacciones y la consulta del historial de una cuenta.
 type TransactionRepository struct {
-	db *sql.DB // Conexión a la base de datos SQL
+	db      dbtx          // Conexión a la base de datos SQL o transacción en curso
+	driver  Driver        // Motor de la base de datos
+	timeout time.Duration // Plazo máximo de cada operación; cero para no imponer límite
 }
 
 // Aseguramos que TransactionRepository implementa la interfaz transaction.Repository.
@@ -20,29 +28,200 @@ var _ transaction.Repository = &TransactionRepository{}
 // NewTransactionRepository crea una nueva instancia de TransactionRepository.
 // Parámetros:
 // - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - queryTimeout: plazo máximo de cada operación; cero para no imponer límite.
 // Retorna:
 // - Un puntero a TransactionRepository que puede usarse para interactuar con la base de datos.
-func NewTransactionRepository(db *sql.DB) *TransactionRepository {
-	return &TransactionRepository{db: db}
+func NewTransactionRepository(db *sql.DB, driver Driver, queryTimeout time.Duration) *TransactionRepository {
+	return &TransactionRepository{db: driver.bind(db), driver: driver, timeout: queryTimeout}
 }
 
+// transactionColumns es la lista de columnas que se leen de la tabla 'transactions'.
+const transactionColumns = "id, account_id, amount, currency, transaction_type, transfer_id, fx_quote_id, fx_rate, counter_amount, counter_currency, reversal_of, created_at"
+
 // Save guarda una transacción en la base de datos.
 // Este método realiza una consulta SQL de tipo INSERT para almacenar los detalles de una transacción bancaria.
 // Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
 // - t: un puntero a una estructura transaction.Transaction que contiene los detalles de la transacción a guardar.
 // Retorna:
 // - error: retorna un error si la operación de guardado falla, de lo contrario retorna nil.
-func (r *TransactionRepository) Save(t *transaction.Transaction) error {
+func (r *TransactionRepository) Save(ctx context.Context, t *transaction.Transaction) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	// La consulta INSERT inserta los detalles de la transacción en la tabla 'transactions'.
-	// Los valores de account_id, amount, transaction_type y created_at se insertan en la tabla.
-	_, err := r.db.Exec("INSERT INTO transactions (account_id, amount, transaction_type, created_at) VALUES (?, ?, ?, ?)",
-		t.AccountID, t.Amount, t.TransactionType, t.CreatedAt)
+	// transfer_id se guarda como NULL cuando la transacción no forma parte de una transferencia,
+	// y las columnas del cambio de divisas (fx_quote_id, fx_rate, counter_amount, counter_currency)
+	// cuando la transferencia no convierte monedas; reversal_of, cuando la transacción no es una reversión.
+	var quoteID, rate, counterAmount, counterCurrency any
+	if c := t.Conversion; c != nil {
+		quoteID, rate, counterAmount, counterCurrency = c.QuoteID, fx.FormatRate(c.Rate), c.CounterAmount, c.CounterAmount.Currency()
+	}
+	id, err := r.driver.insert(ctx, r.db, "INSERT INTO transactions (account_id, amount, currency, transaction_type, transfer_id, fx_quote_id, fx_rate, counter_amount, counter_currency, reversal_of, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		t.AccountID, t.Amount, t.Amount.Currency(), t.TransactionType, sql.NullString{String: t.TransferID, Valid: t.TransferID != ""},
+		quoteID, rate, counterAmount, counterCurrency, sql.NullInt64{Int64: int64(t.ReversalOf), Valid: t.ReversalOf != 0}, t.CreatedAt.UTC())
 
 	// Si ocurre algún error durante la inserción, lo retornamos para que pueda ser manejado por la lógica de la aplicación.
+	// El índice único sobre (fx_quote_id, transaction_type) rechaza una segunda transferencia con la misma
+	// cotización aunque dos solicitudes concurrentes la hayan encontrado sin usar.
+	if t.Conversion != nil && isDuplicateKey(err) {
+		return fmt.Errorf("%w: %s", fx.ErrQuoteUsed, t.Conversion.QuoteID)
+	}
 	if err != nil {
 		return err
 	}
 
+	// Asignar a la transacción el ID generado por la base de datos
+	t.ID = int(id)
+
 	// Si la transacción se guarda correctamente, no hay errores que devolver.
 	return nil
 }
+
+// FindByID busca una transacción por su identificador.
+// Retorna:
+// - *transaction.Transaction: la transacción encontrada.
+// - error: transaction.ErrNotFound si no existe, u otro error si la consulta falla.
+func (r *TransactionRepository) FindByID(ctx context.Context, id int) (*transaction.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	t, err := scanTransaction(r.db.QueryRowContext(ctx, "SELECT "+transactionColumns+" FROM transactions WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, transaction.ErrNotFound
+	}
+	return t, err
+}
+
+// FindReversal devuelve la transacción que revierte la transacción originalID.
+// La consulta usa el índice único sobre reversal_of.
+// Retorna:
+// - *transaction.Transaction: la reversión encontrada.
+// - error: transaction.ErrNotFound si la transacción no fue revertida, u otro error si la consulta falla.
+func (r *TransactionRepository) FindReversal(ctx context.Context, originalID int) (*transaction.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	t, err := scanTransaction(r.db.QueryRowContext(ctx, "SELECT "+transactionColumns+" FROM transactions WHERE reversal_of = ?", originalID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, transaction.ErrNotFound
+	}
+	return t, err
+}
+
+// FindByQuote devuelve la pata de débito de la transferencia liquidada con la cotización quoteID.
+// La consulta usa el índice único sobre (fx_quote_id, transaction_type).
+// Retorna:
+// - *transaction.Transaction: la pata de débito encontrada.
+// - error: transaction.ErrNotFound si la cotización no se utilizó, u otro error si la consulta falla.
+func (r *TransactionRepository) FindByQuote(ctx context.Context, quoteID string) (*transaction.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	t, err := scanTransaction(r.db.QueryRowContext(ctx, "SELECT "+transactionColumns+" FROM transactions WHERE fx_quote_id = ? AND transaction_type = ?", quoteID, transaction.TypeTransferOut))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, transaction.ErrNotFound
+	}
+	return t, err
+}
+
+// FindByAccount devuelve el historial de transacciones de una cuenta que cumplen el filtro.
+// La consulta usa el índice (account_id, created_at, id): el orden y el cursor se expresan sobre
+// esas mismas columnas, por lo que cada página se resuelve sin ordenar ni recorrer las páginas anteriores.
+// Parámetros:
+// - ctx: contexto de la operación; se cancela al vencer el plazo de consulta del repositorio.
+// - accountID: ID de la cuenta cuyo historial se consulta.
+// - filter: criterios de búsqueda, cursor y límite de la página.
+// Retorna:
+// - []*transaction.Transaction: las transacciones encontradas, de la más reciente a la más antigua.
+// - error: retorna un error si la consulta falla.
+func (r *TransactionRepository) FindByAccount(ctx context.Context, accountID int, filter transaction.Filter) ([]*transaction.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Construir la condición WHERE según los filtros recibidos
+	// Las fechas se comparan en UTC, la zona en que se guardan
+	conditions := []string{"account_id = ?"}
+	args := []any{accountID}
+	if len(filter.Types) > 0 {
+		conditions = append(conditions, "transaction_type IN (?"+strings.Repeat(", ?", len(filter.Types)-1)+")")
+		for _, t := range filter.Types {
+			args = append(args, t)
+		}
+	}
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "amount >= ?")
+		args = append(args, *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "amount <= ?")
+		args = append(args, *filter.MaxAmount)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To.UTC())
+	}
+	if filter.After != nil {
+		// Continuar después del cursor: transacciones más antiguas o, en el mismo instante, con ID menor
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, filter.After.CreatedAt.UTC(), filter.After.CreatedAt.UTC(), filter.After.ID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+transactionColumns+" FROM transactions WHERE "+
+		strings.Join(conditions, " AND ")+" ORDER BY created_at DESC, id DESC LIMIT ?", append(args, filter.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, rows.Err()
+}
+
+// scanTransaction lee una fila de 'transactions' con las columnas de transactionColumns.
+func scanTransaction(row rowScanner) (*transaction.Transaction, error) {
+	var t transaction.Transaction
+	var amount any // Monto sin convertir; se interpreta con los decimales de su moneda
+	var currency string
+	var transferID, quoteID, counterCurrency sql.NullString
+	var rate, counterAmount any // Columnas del cambio de divisas; NULL si la transacción no convierte monedas
+	var reversalOf sql.NullInt64
+	var createdAtStr string
+	if err := row.Scan(&t.ID, &t.AccountID, &amount, &currency, &t.TransactionType, &transferID,
+		&quoteID, &rate, &counterAmount, &counterCurrency, &reversalOf, &createdAtStr); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if t.Amount, err = scanMoney(amount, currency); err != nil {
+		return nil, fmt.Errorf("monto de la transacción %d: %w", t.ID, err)
+	}
+	t.TransferID = transferID.String
+	t.ReversalOf = int(reversalOf.Int64)
+	if quoteID.Valid {
+		c := &transaction.Conversion{QuoteID: quoteID.String}
+		if c.Rate, err = scanRate(rate); err != nil {
+			return nil, fmt.Errorf("tipo de cambio de la transacción %d: %w", t.ID, err)
+		}
+		if c.CounterAmount, err = scanMoney(counterAmount, counterCurrency.String); err != nil {
+			return nil, fmt.Errorf("contravalor de la transacción %d: %w", t.ID, err)
+		}
+		t.Conversion = c
+	}
+	if t.CreatedAt, err = parseTimestamp(createdAtStr); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
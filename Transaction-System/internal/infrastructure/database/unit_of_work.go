@@ -0,0 +1,91 @@
+package database
+
+import (
+	"Transaction-System/internal/application"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// UnitOfWork es la implementación de application.UnitOfWork sobre una base de datos SQL (MySQL, SQLite o PostgreSQL).
+// Cada ejecución abre una transacción de base de datos y entrega repositorios ligados a ella,
+// de modo que el balance de la cuenta, el registro de la transacción y las retenciones de fondos se confirman juntos.
+// La concurrencia se controla de forma optimista con la versión de cada cuenta, y un conflicto revierte
+// la transacción completa. En PostgreSQL, además, las cuentas leídas para modificarlas (FindByIDForUpdate) quedan
+// bloqueadas (SELECT ... FOR UPDATE) hasta confirmar, por lo que las operaciones concurrentes sobre una cuenta se
+// esperan en lugar de reintentarse; las consultas (FindByID) no bloquean.
+type UnitOfWork struct {
+	db       *sql.DB  // Conexión a la base de datos SQL.
+	driver   Driver   // Motor de la base de datos.
+	timeouts Timeouts // Plazos de la transacción completa y de cada operación de los repositorios.
+}
+
+// Asegurar que UnitOfWork implementa la interfaz application.UnitOfWork.
+var _ application.UnitOfWork = &UnitOfWork{}
+
+// NewUnitOfWork crea una nueva unidad de trabajo sobre la base de datos.
+// Parámetros:
+// - db: una instancia de *sql.DB que representa la conexión a la base de datos.
+// - driver: motor de la base de datos.
+// - timeouts: plazos de cada transacción y de cada operación de los repositorios; cero para no imponer límite.
+// Retorna:
+// - Un puntero a UnitOfWork que abre una transacción por cada ejecución.
+func NewUnitOfWork(db *sql.DB, driver Driver, timeouts Timeouts) *UnitOfWork {
+	return &UnitOfWork{db: db, driver: driver, timeouts: timeouts}
+}
+
+// Execute ejecuta fn dentro de una transacción de base de datos.
+// Si fn devuelve un error (o entra en pánico) la transacción se revierte; en caso contrario se confirma.
+// Parámetros:
+// - ctx: contexto de la solicitud; si se cancela o vence el plazo de la transacción, ésta se revierte.
+// - fn: función que recibe los repositorios ligados a la transacción.
+// Retorna:
+// - error: el error devuelto por fn, o el error producido al iniciar o confirmar la transacción.
+func (u *UnitOfWork) Execute(ctx context.Context, fn func(repos application.Repositories) error) error {
+	// Limitar la duración de la transacción completa: al vencer el plazo, database/sql la revierte
+	// y las operaciones pendientes de fn fallan con context.DeadlineExceeded
+	txCtx, cancel := withTimeout(ctx, u.timeouts.Transaction)
+	defer cancel()
+
+	// Iniciar la transacción en la base de datos
+	tx, err := u.db.BeginTx(txCtx, nil)
+	if err != nil {
+		return fmt.Errorf("no se pudo iniciar la transacción: %w", err)
+	}
+
+	// Revertir la transacción si fn entra en pánico, y propagar el pánico
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// Repositorios que operan sobre la transacción abierta
+	db := u.driver.bind(tx)
+	repos := application.Repositories{
+		Accounts:     &AccountRepository{db: db, driver: u.driver, timeout: u.timeouts.Query},
+		Transactions: &TransactionRepository{db: db, driver: u.driver, timeout: u.timeouts.Query},
+		Ledger:       &LedgerRepository{db: db, driver: u.driver, timeout: u.timeouts.Query},
+		Holds:        &HoldRepository{db: db, driver: u.driver, timeout: u.timeouts.Query},
+	}
+
+	// Ejecutar la lógica de negocio; ante cualquier error se revierten todos los cambios
+	if err := fn(repos); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			slog.ErrorContext(ctx, "no se pudo revertir la transacción", "error", rbErr, "cause", err)
+			return fmt.Errorf("%w (error al revertir la transacción: %v)", err, rbErr)
+		}
+		slog.DebugContext(ctx, "transacción revertida", "cause", err)
+		return err
+	}
+
+	// Confirmar todos los cambios de forma atómica
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "no se pudo confirmar la transacción", "error", err)
+		return fmt.Errorf("no se pudo confirmar la transacción: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package health_test
+
+import (
+	"Transaction-System/internal/infrastructure/health"
+	http_conection "Transaction-System/internal/infrastructure/http-conection"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// up es una comprobación que siempre tiene éxito
+var up = health.CheckerFunc(func(context.Context) error { return nil })
+
+// Prueba que el informe está disponible sólo si todos los componentes lo están
+func TestRegistry_Check(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	registry.Register("database", up)
+	if report := registry.Check(context.Background()); !report.Up() {
+		t.Fatalf("Se esperaba el servicio disponible, obtenido %+v", report)
+	}
+
+	registry.Register("cache", health.CheckerFunc(func(context.Context) error { return errors.New("sin conexión") }))
+	report := registry.Check(context.Background())
+	if report.Up() {
+		t.Fatal("Se esperaba el servicio no disponible")
+	}
+	if got := report.Components["cache"]; got.Status != health.StatusDown || got.Error != "sin conexión" {
+		t.Errorf("Resultado inesperado para cache: %+v", got)
+	}
+	if got := report.Components["database"]; got.Status != health.StatusUp {
+		t.Errorf("Resultado inesperado para database: %+v", got)
+	}
+}
+
+// Prueba que una comprobación que no respeta el plazo se informa como caída sin bloquear el informe
+func TestRegistry_Timeout(t *testing.T) {
+	registry := health.NewRegistry(20 * time.Millisecond)
+	block := make(chan struct{})
+	defer close(block)
+	registry.Register("lenta", health.CheckerFunc(func(context.Context) error { <-block; return nil }))
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+	if report.Up() {
+		t.Fatal("Se esperaba el servicio no disponible")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("La comprobación debería cortarse en el plazo, tardó %v", elapsed)
+	}
+}
+
+// Prueba que al comenzar el apagado la comprobación Shutdown falla
+func TestShutdown(t *testing.T) {
+	var shutdown health.Shutdown
+	if err := shutdown.Check(context.Background()); err != nil {
+		t.Fatalf("No se esperaba error antes del apagado: %v", err)
+	}
+	shutdown.Begin()
+	if err := shutdown.Check(context.Background()); !errors.Is(err, health.ErrShuttingDown) {
+		t.Errorf("Se esperaba ErrShuttingDown, obtenido %v", err)
+	}
+}
+
+// Prueba que /readyz responde 200 o 503 según el estado de los componentes, y /healthz siempre 200
+func TestHealthHandler(t *testing.T) {
+	var shutdown health.Shutdown
+	registry := health.NewRegistry(time.Second)
+	registry.Register("database", up)
+	registry.Register("shutdown", &shutdown)
+	handler := http_conection.NewHealthHandler(registry)
+
+	ready := func() (int, health.Report) {
+		rr := httptest.NewRecorder()
+		handler.ReadinessHandler(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var report health.Report
+		if err := json.NewDecoder(rr.Body).Decode(&report); err != nil {
+			t.Fatalf("Respuesta JSON inválida: %v", err)
+		}
+		return rr.Code, report
+	}
+
+	if code, report := ready(); code != http.StatusOK || len(report.Components) != 2 {
+		t.Errorf("Se esperaba 200 con dos componentes, obtenido %d %+v", code, report)
+	}
+
+	shutdown.Begin()
+	if code, report := ready(); code != http.StatusServiceUnavailable || report.Components["shutdown"].Status != health.StatusDown {
+		t.Errorf("Se esperaba 503 durante el apagado, obtenido %d %+v", code, report)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.LivenessHandler(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("Se esperaba 200 en /healthz, obtenido %d", rr.Code)
+	}
+}
@@ -0,0 +1,165 @@
+// Package health reúne las comprobaciones de estado del servicio.
+// Cada dependencia (base de datos, esquema, apagado en curso, ...) registra su propia comprobación
+// en un Registry, que las ejecuta en paralelo y entrega un informe por componente.
+package health
+
+import (
+	"context"     // Plazo de cada comprobación
+	"errors"      // Paquete para definir errores
+	"sync"        // Ejecución concurrente y acceso seguro al registro
+	"sync/atomic" // Indicador de apagado seguro para uso concurrente
+	"time"        // Paquete para medir duraciones
+)
+
+// Estados posibles de un componente y del servicio.
+const (
+	StatusUp   = "up"   // El componente funciona correctamente
+	StatusDown = "down" // El componente no está disponible
+)
+
+// DefaultTimeout es el plazo de cada comprobación si no se indica otro.
+const DefaultTimeout = 2 * time.Second
+
+// ErrShuttingDown indica que el servicio está terminando y no debe recibir tráfico nuevo.
+var ErrShuttingDown = errors.New("el servicio se está apagando")
+
+// Checker comprueba el estado de una dependencia.
+// Check debe respetar el plazo de ctx y devolver nil si la dependencia está disponible.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc permite usar una función como Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check implementa la interfaz Checker.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ComponentResult es el resultado de la comprobación de un componente.
+type ComponentResult struct {
+	Status   string `json:"status"`          // "up" o "down"
+	Error    string `json:"error,omitempty"` // Motivo de la falla, si la hubo
+	Duration string `json:"duration"`        // Tiempo que tomó la comprobación
+}
+
+// Report es el resultado de ejecutar todas las comprobaciones registradas.
+type Report struct {
+	Status     string                     `json:"status"`     // "up" sólo si todos los componentes lo están
+	Components map[string]ComponentResult `json:"components"` // Resultado de cada componente por nombre
+}
+
+// Up indica si todos los componentes están disponibles.
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+// namedChecker asocia una comprobación con el nombre del componente.
+type namedChecker struct {
+	name    string
+	checker Checker
+}
+
+// Registry agrupa las comprobaciones de estado del servicio.
+// Es seguro para uso concurrente: pueden registrarse comprobaciones mientras se atienden solicitudes.
+type Registry struct {
+	mu       sync.RWMutex   // Protege checkers
+	checkers []namedChecker // Comprobaciones en el orden de registro
+	timeout  time.Duration  // Plazo de cada comprobación
+}
+
+// NewRegistry crea un registro vacío cuyas comprobaciones tienen el plazo indicado.
+// Si timeout no es positivo se usa DefaultTimeout.
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{timeout: timeout}
+}
+
+// Register agrega la comprobación de un componente.
+// Registrar dos veces el mismo nombre reemplaza la comprobación anterior.
+func (r *Registry) Register(name string, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.checkers {
+		if r.checkers[i].name == name {
+			r.checkers[i].checker = checker
+			return
+		}
+	}
+	r.checkers = append(r.checkers, namedChecker{name: name, checker: checker})
+}
+
+// Check ejecuta en paralelo todas las comprobaciones, cada una con el plazo del registro,
+// y devuelve el informe por componente. Un registro vacío se informa como disponible.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checkers := append([]namedChecker(nil), r.checkers...)
+	r.mu.RUnlock()
+
+	results := make([]ComponentResult, len(checkers))
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, c.checker)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Components: make(map[string]ComponentResult, len(checkers))}
+	for i, c := range checkers {
+		report.Components[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// run ejecuta una comprobación con el plazo del registro.
+// Si la comprobación no respeta el plazo, se informa como caída sin esperarla.
+func (r *Registry) run(ctx context.Context, checker Checker) ComponentResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := ComponentResult{Status: StatusUp, Duration: time.Since(start).Round(time.Microsecond).String()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Shutdown es una comprobación que falla a partir del momento en que comienza el apagado,
+// para que el orquestador deje de enviar tráfico mientras se terminan las solicitudes en curso.
+type Shutdown struct {
+	started atomic.Bool // Verdadero una vez que comenzó el apagado
+}
+
+// Begin marca el inicio del apagado.
+func (s *Shutdown) Begin() {
+	s.started.Store(true)
+}
+
+// Check implementa la interfaz Checker.
+func (s *Shutdown) Check(context.Context) error {
+	if s.started.Load() {
+		return ErrShuttingDown
+	}
+	return nil
+}
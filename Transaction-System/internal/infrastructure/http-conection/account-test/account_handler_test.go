@@ -3,66 +3,49 @@ package account_test
 import (
 	"Transaction-System/internal/application"
 	"Transaction-System/internal/domain/account"
-	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/domain/money"
 	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/memory"
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
-// mockAccountRepository es una implementación simulada (mock) del repositorio de cuentas.
-// Este mock actúa como una base de datos en memoria utilizando un mapa (map[int]*account.Account)
-// para almacenar las cuentas. Se utiliza para realizar pruebas unitarias sin necesidad de interactuar
-// con una base de datos real.
-type mockAccountRepository struct {
-	accounts map[int]*account.Account // Mapa que simula la base de datos de cuentas, donde la clave es el ID.
-}
-
-// Save simula el guardado de una cuenta en el repositorio.
-// El método agrega la cuenta al mapa de cuentas usando su ID como clave.
-func (m *mockAccountRepository) Save(a *account.Account) error {
-	// Simula el guardado de la cuenta en el repositorio (almacena en el mapa).
-	m.accounts[a.ID] = a
-	return nil
-}
-
-func (m *mockAccountRepository) FindByID(id int) (*account.Account, error) {
-	if account, exists := m.accounts[id]; exists {
-		return account, nil
+// newAccountRepository crea un repositorio de cuentas en memoria precargado con las cuentas indicadas.
+func newAccountRepository(t testing.TB, accounts ...*account.Account) *memory.AccountRepository {
+	t.Helper()
+	repo := memory.NewAccountRepository()
+	for _, a := range accounts {
+		if err := repo.Save(context.Background(), a); err != nil {
+			t.Fatalf("No se pudo precargar la cuenta %d: %v", a.ID, err)
+		}
 	}
-	return nil, errors.New("cuenta no encontrada")
-}
-
-// Mock del repositorio de transacciones
-type mockTransactionRepository struct{}
-
-func (m *mockTransactionRepository) Save(t *transaction.Transaction) error {
-	return nil
+	return repo
 }
 
 // Prueba del endpoint /deposit
 func TestDepositHandler(t *testing.T) {
-	// Crear mocks de los repositorios
-	accountRepo := &mockAccountRepository{
-		accounts: map[int]*account.Account{
-			100: {ID: 100, AccountNumber: "ACC0100", Balance: 5000.0},
-		},
-	}
-	transactionRepo := &mockTransactionRepository{}
+	// Crear los repositorios en memoria
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("5000.00", money.DefaultCurrency)},
+	)
+	transactionRepo := memory.NewTransactionRepository()
 
-	// Crear el servicio de transacciones usando los mocks
-	service := application.NewTransactionService(accountRepo, transactionRepo)
+	// Crear el servicio de transacciones sobre los repositorios en memoria
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo))
 
-	// Crear el handler usando el servicio mockeado
+	// Crear el handler usando el servicio
 	handler := http_conection.NewAccountHandler(service)
 
 	// Crear una solicitud de prueba para un depósito
 	body := map[string]interface{}{
 		"account_id": 100,
 		"amount":     100.0,
+		"currency":   "USD",
 	}
 	bodyBytes, _ := json.Marshal(body)
 	req, err := http.NewRequest("POST", "/deposit", bytes.NewBuffer(bodyBytes))
@@ -91,24 +74,23 @@ func TestDepositHandler(t *testing.T) {
 
 // Prueba del endpoint /withdraw
 func TestWithdrawHandler(t *testing.T) {
-	// Crear mocks de los repositorios
-	accountRepo := &mockAccountRepository{
-		accounts: map[int]*account.Account{
-			100: {ID: 100, AccountNumber: "ACC0100", Balance: 5000.0},
-		},
-	}
-	transactionRepo := &mockTransactionRepository{}
+	// Crear los repositorios en memoria
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("5000.00", money.DefaultCurrency)},
+	)
+	transactionRepo := memory.NewTransactionRepository()
 
-	// Crear el servicio de transacciones usando los mocks
-	service := application.NewTransactionService(accountRepo, transactionRepo)
+	// Crear el servicio de transacciones sobre los repositorios en memoria
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo))
 
-	// Crear el handler usando el servicio mockeado
+	// Crear el handler usando el servicio
 	handler := http_conection.NewAccountHandler(service)
 
 	// Crear una solicitud de prueba para un retiro
 	body := map[string]interface{}{
 		"account_id": 100,
 		"amount":     200.0,
+		"currency":   "USD",
 	}
 	bodyBytes, _ := json.Marshal(body)
 	req, err := http.NewRequest("POST", "/withdraw", bytes.NewBuffer(bodyBytes))
@@ -137,24 +119,23 @@ func TestWithdrawHandler(t *testing.T) {
 
 // Prueba del endpoint /withdraw con fondos insuficientes
 func TestWithdrawHandler_InsufficientFunds(t *testing.T) {
-	// Crear mocks de los repositorios
-	accountRepo := &mockAccountRepository{
-		accounts: map[int]*account.Account{
-			100: {ID: 100, AccountNumber: "ACC0100", Balance: 100.0},
-		},
-	}
-	transactionRepo := &mockTransactionRepository{}
+	// Crear los repositorios en memoria
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+	)
+	transactionRepo := memory.NewTransactionRepository()
 
-	// Crear el servicio de transacciones usando los mocks
-	service := application.NewTransactionService(accountRepo, transactionRepo)
+	// Crear el servicio de transacciones sobre los repositorios en memoria
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, transactionRepo))
 
-	// Crear el handler usando el servicio mockeado
+	// Crear el handler usando el servicio
 	handler := http_conection.NewAccountHandler(service)
 
 	// Crear una solicitud de prueba para un retiro que excede el balance
 	body := map[string]interface{}{
 		"account_id": 100,
 		"amount":     200.0,
+		"currency":   "USD",
 	}
 	bodyBytes, _ := json.Marshal(body)
 	req, err := http.NewRequest("POST", "/withdraw", bytes.NewBuffer(bodyBytes))
@@ -169,9 +150,9 @@ func TestWithdrawHandler_InsufficientFunds(t *testing.T) {
 	// Llamar al handler
 	handler.WithdrawHandler(rr, req)
 
-	// Verificar que la respuesta sea HTTP 500 Internal Server Error (por fondos insuficientes)
-	if status := rr.Code; status != http.StatusInternalServerError {
-		t.Errorf("Código de estado incorrecto: obtenido %v, esperado %v", status, http.StatusInternalServerError)
+	// Verificar que la respuesta sea HTTP 422 Unprocessable Entity (por fondos insuficientes)
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("Código de estado incorrecto: obtenido %v, esperado %v", status, http.StatusUnprocessableEntity)
 	}
 
 	// Verificar que el cuerpo de la respuesta contenga un mensaje de error
@@ -179,4 +160,117 @@ func TestWithdrawHandler_InsufficientFunds(t *testing.T) {
 	if !bytes.Contains(rr.Body.Bytes(), []byte(expected)) {
 		t.Errorf("Respuesta incorrecta: obtenida %v, esperada %v", rr.Body.String(), expected)
 	}
+
+	// Verificar que el error tenga el formato RFC 7807 con el código estable
+	assertProblem(t, rr, http.StatusUnprocessableEntity, http_conection.CodeInsufficientFunds)
+}
+
+// Prueba que un depósito en una cuenta inexistente devuelve 404 sin exponer errores internos
+func TestDepositHandler_AccountNotFound(t *testing.T) {
+	accountRepo := memory.NewAccountRepository()
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+	handler := http_conection.NewAccountHandler(service)
+
+	req := httptest.NewRequest("POST", "/deposit", bytes.NewBufferString(`{"account_id": 999, "amount": 10.00, "currency": "USD"}`))
+	rr := httptest.NewRecorder()
+	handler.DepositHandler(rr, req)
+
+	assertProblem(t, rr, http.StatusNotFound, http_conection.CodeAccountNotFound)
+}
+
+// Prueba que un depósito en una moneda distinta a la de la cuenta se rechaza con 422 y no la modifica
+func TestDepositHandler_CurrencyMismatch(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("500", "JPY")},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+	handler := http_conection.NewAccountHandler(service)
+
+	req := httptest.NewRequest("POST", "/deposit", bytes.NewBufferString(`{"account_id": 100, "amount": 10.00, "currency": "USD"}`))
+	rr := httptest.NewRecorder()
+	handler.DepositHandler(rr, req)
+
+	assertProblem(t, rr, http.StatusUnprocessableEntity, http_conection.CodeCurrencyMismatch)
+	acc, _ := accountRepo.FindByID(context.Background(), 100)
+	if acc.Balance != money.MustParse("500", "JPY") {
+		t.Errorf("El balance no debería cambiar, obtenido %v", acc.Balance)
+	}
+}
+
+// Prueba que un cuerpo mayor al máximo se rechaza con 413 también sin la cabecera Idempotency-Key
+func TestDepositHandler_BodyTooLarge(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+	handler := http_conection.NewAccountHandler(service)
+
+	body := `{"account_id": 100, "amount": 10.00, "currency": "USD", "padding": "` + strings.Repeat("x", 1<<20) + `"}`
+	rr := httptest.NewRecorder()
+	handler.DepositHandler(rr, httptest.NewRequest("POST", "/deposit", bytes.NewBufferString(body)))
+
+	assertProblem(t, rr, http.StatusRequestEntityTooLarge, http_conection.CodeRequestTooLarge)
+	acc, _ := accountRepo.FindByID(context.Background(), 100)
+	if acc.Balance != money.MustParse("100.00", money.DefaultCurrency) {
+		t.Errorf("El balance no debería cambiar, obtenido %v", acc.Balance)
+	}
+}
+
+// assertProblem verifica que la respuesta sea un problema RFC 7807 con el estado y el código indicados
+func assertProblem(t *testing.T, rr *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	if contentType := rr.Header().Get("Content-Type"); contentType != http_conection.ProblemContentType {
+		t.Errorf("Content-Type incorrecto: obtenido %q, esperado %q", contentType, http_conection.ProblemContentType)
+	}
+	var problem http_conection.Problem
+	if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("El cuerpo no es un problema JSON válido: %v", err)
+	}
+	if problem.Status != status || problem.Code != code || problem.Title == "" {
+		t.Errorf("Problema incorrecto: %+v, esperado estado %d y código %q", problem, status, code)
+	}
+}
+
+// Prueba que las solicitudes con campos desconocidos o montos inválidos se rechazan con 422 y el detalle por campo
+func TestDepositHandler_ValidationErrors(t *testing.T) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+	handler := http_conection.NewAccountHandler(service)
+
+	tests := []struct {
+		name  string
+		body  string
+		field string // Campo con error esperado
+		code  string // Código de error esperado
+	}{
+		{"campo desconocido", `{"account_id": 100, "amount": 10, "currency": "USD", "ammount": 10}`, "ammount", application.FieldUnknown},
+		{"tipo incorrecto", `{"account_id": "100", "amount": 10, "currency": "USD"}`, "account_id", application.FieldInvalidType},
+		{"monto negativo", `{"account_id": 100, "amount": -10, "currency": "USD"}`, "amount", application.FieldNotPositive},
+		{"demasiados decimales", `{"account_id": 100, "amount": "10.001", "currency": "USD"}`, "amount", application.FieldPrecision},
+		{"sin monto", `{"account_id": 100, "currency": "USD"}`, "amount", application.FieldRequired},
+		{"sin moneda", `{"account_id": 100, "amount": 10}`, "currency", application.FieldRequired},
+		{"moneda desconocida", `{"account_id": 100, "amount": 10, "currency": "ABC"}`, "currency", application.FieldInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.DepositHandler(rr, httptest.NewRequest("POST", "/deposit", bytes.NewBufferString(tt.body)))
+
+			assertProblem(t, rr, http.StatusUnprocessableEntity, http_conection.CodeValidationFailed)
+			var problem http_conection.Problem
+			json.Unmarshal(rr.Body.Bytes(), &problem)
+			if len(problem.Errors) != 1 || problem.Errors[0].Field != tt.field || problem.Errors[0].Code != tt.code {
+				t.Errorf("Errores incorrectos: %+v, esperado %s/%s", problem.Errors, tt.field, tt.code)
+			}
+		})
+	}
+
+	// El balance no cambia con ninguna de las solicitudes rechazadas
+	acc, _ := accountRepo.FindByID(context.Background(), 100)
+	if balance := acc.Balance; balance != money.MustParse("100.00", money.DefaultCurrency) {
+		t.Errorf("El balance no debería cambiar, obtenido %v", balance)
+	}
 }
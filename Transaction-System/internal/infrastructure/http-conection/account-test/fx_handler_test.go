@@ -0,0 +1,181 @@
+package account_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/memory"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFXServer crea un enrutador con los endpoints de cambio, de transferencia y de historial sobre
+// repositorios en memoria, con una cuenta en USD (1), otra en EUR (2) y la tabla estática USD/EUR = 0.92.
+func newFXServer(t *testing.T) http.Handler {
+	t.Helper()
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC0001", Balance: money.MustParse("500.00", "USD")},
+		&account.Account{ID: 2, AccountNumber: "ACC0002", Balance: money.Zero("EUR")},
+	)
+	quotes := memory.NewQuoteRepository()
+	static, err := fx.NewStaticProvider(map[string]string{"USD/EUR": "0.92"}, time.Now())
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	service := application.NewTransactionService(
+		memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()), application.WithQuotes(quotes))
+	fxService := application.NewFXService(static, memory.NewRateRepository(), quotes, application.FXPolicy{SpreadBPS: 25, QuoteTTL: time.Minute})
+
+	accountHandler := http_conection.NewAccountHandler(service)
+	fxHandler := http_conection.NewFXHandler(fxService)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /transfers", accountHandler.TransferHandler)
+	mux.HandleFunc("GET /accounts/{id}/transactions", accountHandler.HistoryHandler)
+	mux.HandleFunc("POST /fx/quotes", fxHandler.QuoteHandler)
+	mux.HandleFunc("GET /fx/quotes/{id}", fxHandler.GetQuoteHandler)
+	mux.HandleFunc("GET /fx/rates", fxHandler.ListRatesHandler)
+	mux.HandleFunc("PUT /admin/fx/rates/{base}/{quote}", fxHandler.SetRateHandler)
+	mux.HandleFunc("DELETE /admin/fx/rates/{base}/{quote}", fxHandler.DeleteRateHandler)
+	return mux
+}
+
+// serve envía una solicitud al enrutador y devuelve la respuesta grabada.
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+// Prueba el flujo completo: cotizar, transferir con la cotización y ver el cambio en el historial de ambas cuentas
+func TestFXHandler_QuoteAndTransfer(t *testing.T) {
+	server := newFXServer(t)
+
+	rr := serve(server, "POST", "/fx/quotes", `{"amount": "100.00", "currency": "USD", "target_currency": "EUR"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Código de estado incorrecto: obtenido %v, esperado %v (%s)", rr.Code, http.StatusCreated, rr.Body)
+	}
+	var quote struct {
+		ID             string      `json:"id"`
+		TargetAmount   json.Number `json:"target_amount"`
+		TargetCurrency string      `json:"target_currency"`
+		MidRate        string      `json:"mid_rate"`
+		SpreadBPS      string      `json:"spread_bps"`
+		Rate           string      `json:"rate"`
+		RateSource     string      `json:"rate_source"`
+		Expired        bool        `json:"expired"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &quote); err != nil {
+		t.Fatalf("Respuesta inválida: %v", err)
+	}
+	if quote.ID == "" || quote.TargetAmount != "91.77" || quote.TargetCurrency != "EUR" || quote.MidRate != "0.92" ||
+		quote.SpreadBPS != "25" || quote.Rate != "0.9177" || quote.RateSource != fx.SourceStatic || quote.Expired {
+		t.Fatalf("Cotización incorrecta: %+v", quote)
+	}
+
+	if rr := serve(server, "GET", "/fx/quotes/"+quote.ID, ""); rr.Code != http.StatusOK {
+		t.Errorf("La cotización emitida debería poder consultarse, obtenido %v", rr.Code)
+	}
+
+	// Sin cotización, la transferencia entre monedas sigue rechazándose
+	assertProblem(t, serve(server, "POST", "/transfers", `{"from_account_id": 1, "to_account_id": 2, "amount": "100.00", "currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeCurrencyMismatch)
+
+	rr = serve(server, "POST", "/transfers",
+		`{"from_account_id": 1, "to_account_id": 2, "amount": "100.00", "currency": "USD", "quote_id": "`+quote.ID+`"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Código de estado incorrecto: obtenido %v, esperado %v (%s)", rr.Code, http.StatusCreated, rr.Body)
+	}
+
+	// La cotización ya liquidó la transferencia y no puede reutilizarse
+	assertProblem(t, serve(server, "POST", "/transfers",
+		`{"from_account_id": 1, "to_account_id": 2, "amount": "100.00", "currency": "USD", "quote_id": "`+quote.ID+`"}`),
+		http.StatusConflict, http_conection.CodeQuoteUsed)
+
+	// La pata de destino se acredita en EUR e informa el monto debitado como contrapartida
+	rr = serve(server, "GET", "/accounts/2/transactions", "")
+	var history struct {
+		Transactions []struct {
+			Amount   json.Number `json:"amount"`
+			Currency string      `json:"currency"`
+			Exchange *struct {
+				QuoteID         string      `json:"quote_id"`
+				Rate            string      `json:"rate"`
+				CounterAmount   json.Number `json:"counter_amount"`
+				CounterCurrency string      `json:"counter_currency"`
+			} `json:"exchange"`
+		} `json:"transactions"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &history); err != nil || len(history.Transactions) != 1 {
+		t.Fatalf("Historial inválido: %s (err: %v)", rr.Body, err)
+	}
+	leg := history.Transactions[0]
+	if leg.Amount != "91.77" || leg.Currency != "EUR" || leg.Exchange == nil {
+		t.Fatalf("Pata de destino incorrecta: %+v", leg)
+	}
+	if leg.Exchange.QuoteID != quote.ID || leg.Exchange.Rate != "0.9177" ||
+		leg.Exchange.CounterAmount != "100.00" || leg.Exchange.CounterCurrency != "USD" {
+		t.Errorf("Cambio incorrecto: %+v", *leg.Exchange)
+	}
+}
+
+// Prueba los errores de cotización: par sin tipo, cotización inexistente y monto que no coincide
+func TestFXHandler_Errors(t *testing.T) {
+	server := newFXServer(t)
+
+	assertProblem(t, serve(server, "POST", "/fx/quotes", `{"amount": "1.00", "currency": "GBP", "target_currency": "BRL"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeRateNotFound)
+	assertProblem(t, serve(server, "POST", "/fx/quotes", `{"amount": "1.00", "currency": "USD", "target_currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeValidationFailed)
+	assertProblem(t, serve(server, "GET", "/fx/quotes/no-existe", ""), http.StatusNotFound, http_conection.CodeQuoteNotFound)
+
+	rr := serve(server, "POST", "/fx/quotes", `{"amount": "100.00", "currency": "USD", "target_currency": "EUR"}`)
+	var quote struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &quote)
+	assertProblem(t, serve(server, "POST", "/transfers",
+		`{"from_account_id": 1, "to_account_id": 2, "amount": "99.00", "currency": "USD", "quote_id": "`+quote.ID+`"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeQuoteMismatch)
+}
+
+// Prueba la administración de tipos: un tipo fijado aparece como manual en el listado hasta que se elimina
+func TestFXHandler_AdminRates(t *testing.T) {
+	server := newFXServer(t)
+
+	rr := serve(server, "PUT", "/admin/fx/rates/usd/eur", `{"rate": "0.95"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Código de estado incorrecto: obtenido %v, esperado %v (%s)", rr.Code, http.StatusOK, rr.Body)
+	}
+	assertProblem(t, serve(server, "PUT", "/admin/fx/rates/USD/EUR", `{"rate": "0"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeValidationFailed)
+
+	var list struct {
+		Rates []struct {
+			Base   string `json:"base"`
+			Quote  string `json:"quote"`
+			Rate   string `json:"rate"`
+			Source string `json:"source"`
+		} `json:"rates"`
+	}
+	rr = serve(server, "GET", "/fx/rates", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil || len(list.Rates) != 1 {
+		t.Fatalf("Listado inválido: %s (err: %v)", rr.Body, err)
+	}
+	if r := list.Rates[0]; r.Base != "USD" || r.Quote != "EUR" || r.Rate != "0.95" || r.Source != fx.SourceManual {
+		t.Errorf("Tipo incorrecto: %+v", r)
+	}
+
+	if rr := serve(server, "DELETE", "/admin/fx/rates/USD/EUR", ""); rr.Code != http.StatusNoContent {
+		t.Errorf("Código de estado incorrecto: obtenido %v, esperado %v", rr.Code, http.StatusNoContent)
+	}
+	assertProblem(t, serve(server, "DELETE", "/admin/fx/rates/USD/EUR", ""), http.StatusUnprocessableEntity, http_conection.CodeRateNotFound)
+	assertProblem(t, serve(server, "DELETE", "/admin/fx/rates/USD/USD", ""), http.StatusBadRequest, http_conection.CodeInvalidRequest)
+}
@@ -0,0 +1,121 @@
+package account_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/memory"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// newHoldServer crea un enrutador con los endpoints de retenciones y de consulta de cuentas
+// sobre repositorios en memoria, con una cuenta en USD (1) y 100.00 de balance.
+func newHoldServer(t *testing.T) http.Handler {
+	t.Helper()
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC0001", Balance: money.MustParse("100.00", "USD")},
+	)
+	uow := memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository())
+	holdHandler := http_conection.NewHoldHandler(application.NewHoldService(uow, application.HoldPolicy{}))
+	lifecycleHandler := http_conection.NewAccountLifecycleHandler(application.NewAccountService(uow))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /holds", holdHandler.AuthorizeHandler)
+	mux.HandleFunc("GET /holds/{id}", holdHandler.GetHandler)
+	mux.HandleFunc("POST /holds/{id}/capture", holdHandler.CaptureHandler)
+	mux.HandleFunc("POST /holds/{id}/release", holdHandler.ReleaseHandler)
+	mux.HandleFunc("GET /accounts/{id}", lifecycleHandler.GetHandler)
+	return mux
+}
+
+// holdBody es la parte de la respuesta de una retención que verifican las pruebas.
+type holdBody struct {
+	ID            string      `json:"id"`
+	Amount        json.Number `json:"amount"`
+	Captured      json.Number `json:"captured"`
+	Status        string      `json:"status"`
+	TransactionID int         `json:"transaction_id"`
+	ClosedAt      string      `json:"closed_at"`
+}
+
+// Prueba autorizar, consultar, capturar parcialmente y liberar retenciones a través de la API
+func TestHoldHandlers(t *testing.T) {
+	server := newHoldServer(t)
+
+	rr := serve(server, http.MethodPost, "/holds", `{"account_id": 1, "amount": "80.00", "currency": "USD", "reference": "pedido-981", "ttl": "2h"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Código de estado incorrecto al autorizar, esperado 201, obtenido %d: %s", rr.Code, rr.Body)
+	}
+	var authorized holdBody
+	if err := json.Unmarshal(rr.Body.Bytes(), &authorized); err != nil {
+		t.Fatalf("Error al decodificar la retención: %v", err)
+	}
+	if authorized.Status != "active" || authorized.Amount != "80.00" || authorized.ClosedAt != "" {
+		t.Errorf("Retención inesperada: %+v", authorized)
+	}
+
+	// La cuenta muestra el balance contable, los fondos retenidos y el disponible
+	var acc struct {
+		Balance          json.Number `json:"balance"`
+		Held             json.Number `json:"held"`
+		AvailableBalance json.Number `json:"available_balance"`
+	}
+	rr = serve(server, http.MethodGet, "/accounts/1", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &acc); err != nil {
+		t.Fatalf("Error al decodificar la cuenta: %v", err)
+	}
+	if acc.Balance != "100.00" || acc.Held != "80.00" || acc.AvailableBalance != "20.00" {
+		t.Errorf("Cuenta inesperada con la retención activa: %+v", acc)
+	}
+
+	// El disponible no alcanza para otra retención; la vigencia y los campos se validan
+	assertProblem(t, serve(server, http.MethodPost, "/holds", `{"account_id": 1, "amount": "20.01", "currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeInsufficientFunds)
+	assertProblem(t, serve(server, http.MethodPost, "/holds", `{"account_id": 1, "amount": "1.00", "currency": "USD", "ttl": "9999h"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeValidationFailed)
+	assertProblem(t, serve(server, http.MethodPost, "/holds", `{"account_id": 1, "amount": "1.00", "currency": "USD", "ttl": "mañana"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeValidationFailed)
+
+	// Capturar más de lo retenido se rechaza; la captura parcial libera el resto
+	path := "/holds/" + authorized.ID
+	assertProblem(t, serve(server, http.MethodPost, path+"/capture", `{"amount": "80.01", "currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeCaptureExceedsHold)
+	rr = serve(server, http.MethodPost, path+"/capture", `{"amount": "50.00", "currency": "USD"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Código de estado incorrecto al capturar, esperado 200, obtenido %d: %s", rr.Code, rr.Body)
+	}
+	var captured holdBody
+	if err := json.Unmarshal(rr.Body.Bytes(), &captured); err != nil {
+		t.Fatalf("Error al decodificar la captura: %v", err)
+	}
+	if captured.Status != "captured" || captured.Captured != "50.00" || captured.TransactionID == 0 || captured.ClosedAt == "" {
+		t.Errorf("Captura inesperada: %+v", captured)
+	}
+	rr = serve(server, http.MethodGet, "/accounts/1", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &acc); err != nil {
+		t.Fatalf("Error al decodificar la cuenta: %v", err)
+	}
+	if acc.Balance != "50.00" || acc.Held != "0.00" || acc.AvailableBalance != "50.00" {
+		t.Errorf("Cuenta inesperada tras la captura: %+v", acc)
+	}
+
+	// Una retención cerrada no vuelve a capturarse ni liberarse
+	assertProblem(t, serve(server, http.MethodPost, path+"/capture", ""), http.StatusConflict, http_conection.CodeHoldNotActive)
+	assertProblem(t, serve(server, http.MethodPost, path+"/release", ""), http.StatusConflict, http_conection.CodeHoldNotActive)
+	assertProblem(t, serve(server, http.MethodGet, "/holds/no-existe", ""), http.StatusNotFound, http_conection.CodeHoldNotFound)
+
+	// Una retención nueva puede liberarse sin capturarse
+	rr = serve(server, http.MethodPost, "/holds", `{"account_id": 1, "amount": "10.00", "currency": "USD"}`)
+	var second holdBody
+	if err := json.Unmarshal(rr.Body.Bytes(), &second); err != nil {
+		t.Fatalf("Error al decodificar la retención: %v", err)
+	}
+	rr = serve(server, http.MethodPost, "/holds/"+second.ID+"/release", "")
+	var released holdBody
+	if err := json.Unmarshal(rr.Body.Bytes(), &released); err != nil || rr.Code != http.StatusOK || released.Status != "released" {
+		t.Errorf("Liberación inesperada (%d): %s", rr.Code, rr.Body)
+	}
+}
@@ -0,0 +1,97 @@
+package account_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newIdempotentDeposit crea el handler de depósitos envuelto con idempotencia sobre una cuenta con 100.00
+func newIdempotentDeposit(t testing.TB) (http.HandlerFunc, *memory.AccountRepository) {
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 100, AccountNumber: "ACC0100", Balance: money.MustParse("100.00", money.DefaultCurrency)},
+	)
+	service := application.NewTransactionService(memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository()))
+	handler := http_conection.NewAccountHandler(service)
+	idempotency := http_conection.NewIdempotency(memory.NewIdempotencyRepository(), time.Hour)
+	return idempotency.Wrap(handler.DepositHandler), accountRepo
+}
+
+// postDeposit envía una solicitud de depósito con la Idempotency-Key indicada
+func postDeposit(h http.HandlerFunc, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/deposit", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(http_conection.IdempotencyKeyHeader, key)
+	rr := httptest.NewRecorder()
+	h(rr, req)
+	return rr
+}
+
+// Prueba que un reintento con la misma clave devuelve la respuesta original sin volver a depositar
+func TestIdempotency_Replay(t *testing.T) {
+	handler, accountRepo := newIdempotentDeposit(t)
+	body := `{"account_id": 100, "amount": 50.00, "currency": "USD"}`
+
+	first := postDeposit(handler, "clave-1", body)
+	second := postDeposit(handler, "clave-1", body)
+
+	// Ambas respuestas deben ser idénticas
+	if first.Code != http.StatusOK || second.Code != http.StatusOK {
+		t.Fatalf("Códigos incorrectos: %d y %d", first.Code, second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("La respuesta repetida no coincide: %q vs %q", second.Body.String(), first.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Se esperaba la cabecera Idempotent-Replayed en la respuesta repetida")
+	}
+
+	// El depósito debe haberse aplicado una sola vez
+	acc, _ := accountRepo.FindByID(context.Background(), 100)
+	if acc.Balance != money.MustParse("150.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado 150.00, obtenido %v", acc.Balance)
+	}
+}
+
+// Prueba que reutilizar la clave con otro contenido devuelve 422
+func TestIdempotency_KeyReuseWithDifferentPayload(t *testing.T) {
+	handler, accountRepo := newIdempotentDeposit(t)
+
+	postDeposit(handler, "clave-1", `{"account_id": 100, "amount": 50.00, "currency": "USD"}`)
+	rr := postDeposit(handler, "clave-1", `{"account_id": 100, "amount": 75.00, "currency": "USD"}`)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Código de estado incorrecto: obtenido %v, esperado %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+
+	// Sólo el primer depósito debe haberse aplicado
+	acc, _ := accountRepo.FindByID(context.Background(), 100)
+	if acc.Balance != money.MustParse("150.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado 150.00, obtenido %v", acc.Balance)
+	}
+}
+
+// Prueba que un cuerpo mayor al máximo se rechaza con 413 antes de reservar la clave
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	handler, accountRepo := newIdempotentDeposit(t)
+
+	body := `{"account_id": 100, "amount": 50.00, "currency": "USD", "padding": "` + strings.Repeat("x", 1<<20) + `"}`
+	assertProblem(t, postDeposit(handler, "clave-1", body), http.StatusRequestEntityTooLarge, http_conection.CodeRequestTooLarge)
+
+	// La clave no quedó reservada: un reintento válido con la misma clave se procesa
+	if rr := postDeposit(handler, "clave-1", `{"account_id": 100, "amount": 50.00, "currency": "USD"}`); rr.Code != http.StatusOK {
+		t.Errorf("El reintento válido debería procesarse, obtenido %d: %s", rr.Code, rr.Body)
+	}
+	acc, _ := accountRepo.FindByID(context.Background(), 100)
+	if acc.Balance != money.MustParse("150.00", money.DefaultCurrency) {
+		t.Errorf("Balance incorrecto, esperado 150.00, obtenido %v", acc.Balance)
+	}
+}
@@ -0,0 +1,137 @@
+package account_test
+
+import (
+	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/logging"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureLogs reemplaza el logger por defecto por uno JSON que escribe en un buffer durante la prueba
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := logging.NewLogger(&buf, logging.FormatJSON, slog.LevelDebug)
+	if err != nil {
+		t.Fatalf("No se pudo crear el logger: %v", err)
+	}
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+// Prueba que se conserva un X-Request-ID válido del cliente y que llega al contexto y a la respuesta
+func TestRequestIDMiddleware_Inbound(t *testing.T) {
+	var seen string
+	handler := http_conection.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logging.RequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	req.Header.Set(http_conection.RequestIDHeader, "locust-42")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if seen != "locust-42" {
+		t.Errorf("Se esperaba el request ID del cliente en el contexto, obtenido %q", seen)
+	}
+	if got := rr.Header().Get(http_conection.RequestIDHeader); got != "locust-42" {
+		t.Errorf("Se esperaba el request ID en la respuesta, obtenido %q", got)
+	}
+}
+
+// Prueba que se genera un identificador si el cliente no envía uno o envía uno inválido
+func TestRequestIDMiddleware_Generated(t *testing.T) {
+	handler := http_conection.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, inbound := range []string{"", "con espacios\n", strings.Repeat("x", 200)} {
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		if inbound != "" {
+			req.Header.Set(http_conection.RequestIDHeader, inbound)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		got := rr.Header().Get(http_conection.RequestIDHeader)
+		if len(got) != 32 || got == inbound {
+			t.Errorf("Se esperaba un request ID generado para %q, obtenido %q", inbound, got)
+		}
+	}
+}
+
+// Prueba que la línea de acceso incluye el request ID, el estado y los bytes escritos
+func TestAccessLogMiddleware(t *testing.T) {
+	logs := captureLogs(t)
+	handler := http_conection.RequestIDMiddleware(http_conection.AccessLogMiddleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			slog.InfoContext(r.Context(), "procesando")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("hola"))
+		})))
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+	req.Header.Set(http_conection.RequestIDHeader, "req-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Se esperaban dos líneas de log, obtenido %q", logs.String())
+	}
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Línea de log no es JSON: %q", line)
+		}
+		if entry[logging.RequestIDKey] != "req-1" {
+			t.Errorf("Se esperaba request_id en la línea %q", line)
+		}
+	}
+
+	var access map[string]any
+	json.Unmarshal([]byte(lines[1]), &access)
+	if access["status"] != float64(http.StatusCreated) || access["bytes"] != float64(4) || access["path"] != "/transfers" {
+		t.Errorf("Línea de acceso inesperada: %v", access)
+	}
+}
+
+// Prueba que las rutas de administración sólo atienden las solicitudes con el token correcto
+func TestRequireAdminToken(t *testing.T) {
+	const token = "0123456789abcdef0123456789abcdef"
+	called := 0
+	handler := http_conection.RequireAdminToken(token, func(w http.ResponseWriter, r *http.Request) {
+		called++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, authorization := range []string{"", token, "Bearer ", "Bearer otro-token", "Basic " + token, "Bearer " + token + "x"} {
+		req := httptest.NewRequest(http.MethodPut, "/admin/fx/rates/USD/EUR", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+
+		var problem http_conection.Problem
+		_ = json.Unmarshal(rr.Body.Bytes(), &problem)
+		if rr.Code != http.StatusUnauthorized || problem.Code != http_conection.CodeUnauthorized || rr.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("Authorization %q: se esperaba 401 unauthorized, obtenido %d %+v", authorization, rr.Code, problem)
+		}
+	}
+	if called != 0 {
+		t.Fatalf("El manejador no debería ejecutarse sin el token, se ejecutó %d veces", called)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/fx/rates/USD/EUR", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	if rr.Code != http.StatusNoContent || called != 1 {
+		t.Errorf("Con el token correcto se esperaba 204, obtenido %d (llamadas: %d)", rr.Code, called)
+	}
+}
@@ -0,0 +1,89 @@
+package account_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/memory"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+)
+
+// newOverdraftServer crea un enrutador con los endpoints de cuentas, retiros e intereses de sobregiro
+// sobre repositorios en memoria, con una cuenta en USD (1) y 100.00 de balance.
+func newOverdraftServer(t *testing.T) http.Handler {
+	t.Helper()
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC0001", Balance: money.MustParse("100.00", "USD")},
+	)
+	uow := memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository())
+	accountHandler := http_conection.NewAccountHandler(application.NewTransactionService(uow))
+	lifecycleHandler := http_conection.NewAccountLifecycleHandler(application.NewAccountService(uow))
+	interestHandler := http_conection.NewInterestHandler(application.NewInterestService(uow,
+		application.InterestPolicy{AnnualRate: big.NewRat(365, 1000), DaysPerYear: 365}))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/withdraw", accountHandler.WithdrawHandler)
+	mux.HandleFunc("GET /accounts/{id}", lifecycleHandler.GetHandler)
+	mux.HandleFunc("PUT /accounts/{id}/overdraft", lifecycleHandler.SetOverdraftHandler)
+	mux.HandleFunc("POST /admin/overdraft/interest", interestHandler.AccrueHandler)
+	return mux
+}
+
+// Prueba fijar el sobregiro, usarlo, excederlo y cobrar sus intereses a través de la API
+func TestOverdraftHandlers(t *testing.T) {
+	server := newOverdraftServer(t)
+
+	rr := serve(server, http.MethodPut, "/accounts/1/overdraft", `{"limit": "50.00", "currency": "USD"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Código de estado incorrecto al fijar el sobregiro, esperado 200, obtenido %d: %s", rr.Code, rr.Body)
+	}
+
+	// El retiro puede usar el sobregiro
+	if rr := serve(server, http.MethodPost, "/withdraw", `{"account_id": 1, "amount": "120.00", "currency": "USD"}`); rr.Code != http.StatusOK {
+		t.Fatalf("El retiro dentro del sobregiro debería aceptarse, obtenido %d: %s", rr.Code, rr.Body)
+	}
+	var acc struct {
+		Balance          json.Number `json:"balance"`
+		OverdraftLimit   json.Number `json:"overdraft_limit"`
+		OverdraftUsed    json.Number `json:"overdraft_used"`
+		AvailableBalance json.Number `json:"available_balance"`
+	}
+	rr = serve(server, http.MethodGet, "/accounts/1", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &acc); err != nil {
+		t.Fatalf("Error al decodificar la cuenta: %v", err)
+	}
+	if acc.Balance != "-20.00" || acc.OverdraftLimit != "50.00" || acc.OverdraftUsed != "20.00" || acc.AvailableBalance != "30.00" {
+		t.Errorf("Sobregiro incorrecto en la cuenta: %+v", acc)
+	}
+
+	// Excederlo devuelve un código propio, distinto de insufficient_funds
+	assertProblem(t, serve(server, http.MethodPost, "/withdraw", `{"account_id": 1, "amount": "30.01", "currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeOverdraftExceeded)
+	// Tampoco puede reducirse el límite por debajo del sobregiro en uso
+	assertProblem(t, serve(server, http.MethodPut, "/accounts/1/overdraft", `{"limit": "10.00", "currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeOverdraftExceeded)
+	assertProblem(t, serve(server, http.MethodPut, "/accounts/1/overdraft", `{"limit": "-1", "currency": "USD"}`),
+		http.StatusUnprocessableEntity, http_conection.CodeValidationFailed)
+
+	// 20.00 × 0.365 / 365 = 0.02
+	var report struct {
+		Day      string `json:"day"`
+		Accruals []struct {
+			AccountID int         `json:"account_id"`
+			Interest  json.Number `json:"interest"`
+		} `json:"accruals"`
+	}
+	rr = serve(server, http.MethodPost, "/admin/overdraft/interest?day=2024-09-10", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Error al decodificar el informe: %v: %s", err, rr.Body)
+	}
+	if report.Day != "2024-09-10" || len(report.Accruals) != 1 || report.Accruals[0].Interest != "0.02" {
+		t.Errorf("Informe de intereses inesperado: %+v", report)
+	}
+	assertProblem(t, serve(server, http.MethodPost, "/admin/overdraft/interest?day=10/09/2024", ""),
+		http.StatusBadRequest, http_conection.CodeInvalidRequest)
+}
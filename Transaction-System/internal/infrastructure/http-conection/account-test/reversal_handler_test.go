@@ -0,0 +1,93 @@
+package account_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/http-conection"
+	"Transaction-System/internal/infrastructure/memory"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// newReversalServer crea un enrutador con los endpoints de movimientos, historial y reversión
+// sobre repositorios en memoria, con una cuenta en USD (1) y 100.00 de balance.
+func newReversalServer(t *testing.T) http.Handler {
+	t.Helper()
+	accountRepo := newAccountRepository(t,
+		&account.Account{ID: 1, AccountNumber: "ACC0001", Balance: money.MustParse("100.00", "USD")},
+	)
+	accountHandler := http_conection.NewAccountHandler(application.NewTransactionService(
+		memory.NewUnitOfWork(accountRepo, memory.NewTransactionRepository())))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/deposit", accountHandler.DepositHandler)
+	mux.HandleFunc("/withdraw", accountHandler.WithdrawHandler)
+	mux.HandleFunc("GET /accounts/{id}/transactions", accountHandler.HistoryHandler)
+	mux.HandleFunc("POST /transactions/{id}/reverse", accountHandler.ReverseHandler)
+	return mux
+}
+
+// reversalBody es la parte de la respuesta de una transacción que verifican las pruebas.
+type reversalBody struct {
+	ID              int         `json:"id"`
+	Amount          json.Number `json:"amount"`
+	TransactionType string      `json:"transaction_type"`
+	ReversalOf      int         `json:"reversal_of"`
+}
+
+// Prueba revertir un depósito a través de la API, la reversión repetida y las reglas de fondos
+func TestReverseHandler(t *testing.T) {
+	server := newReversalServer(t)
+
+	if rr := serve(server, http.MethodPost, "/deposit", `{"account_id": 1, "amount": "40.00", "currency": "USD"}`); rr.Code != http.StatusOK {
+		t.Fatalf("Error en el depósito (%d): %s", rr.Code, rr.Body)
+	}
+	var history struct {
+		Transactions []reversalBody `json:"transactions"`
+	}
+	rr := serve(server, http.MethodGet, "/accounts/1/transactions", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &history); err != nil || len(history.Transactions) != 1 {
+		t.Fatalf("Historial inesperado (err: %v): %s", err, rr.Body)
+	}
+	deposit := history.Transactions[0]
+
+	rr = serve(server, http.MethodPost, "/transactions/"+strconv.Itoa(deposit.ID)+"/reverse", "")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Código de estado incorrecto al revertir, esperado 201, obtenido %d: %s", rr.Code, rr.Body)
+	}
+	var reversal reversalBody
+	if err := json.Unmarshal(rr.Body.Bytes(), &reversal); err != nil {
+		t.Fatalf("Error al decodificar la reversión: %v", err)
+	}
+	if reversal.TransactionType != "reversal" || reversal.ReversalOf != deposit.ID || reversal.Amount != "40.00" {
+		t.Errorf("Reversión inesperada: %+v", reversal)
+	}
+
+	// La reversión aparece en el historial filtrado por tipo
+	rr = serve(server, http.MethodGet, "/accounts/1/transactions?type=reversal", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &history); err != nil || len(history.Transactions) != 1 || history.Transactions[0].ID != reversal.ID {
+		t.Errorf("Historial de reversiones inesperado (err: %v): %s", err, rr.Body)
+	}
+
+	assertProblem(t, serve(server, http.MethodPost, "/transactions/"+strconv.Itoa(deposit.ID)+"/reverse", ""),
+		http.StatusConflict, http_conection.CodeAlreadyReversed)
+	assertProblem(t, serve(server, http.MethodPost, "/transactions/"+strconv.Itoa(reversal.ID)+"/reverse", ""),
+		http.StatusUnprocessableEntity, http_conection.CodeNotReversible)
+	assertProblem(t, serve(server, http.MethodPost, "/transactions/9999/reverse", ""),
+		http.StatusNotFound, http_conection.CodeTransactionNotFound)
+	assertProblem(t, serve(server, http.MethodPost, "/transactions/abc/reverse", ""),
+		http.StatusBadRequest, http_conection.CodeInvalidRequest)
+
+	// Revertir un depósito ya retirado se rechaza por fondos insuficientes
+	serve(server, http.MethodPost, "/deposit", `{"account_id": 1, "amount": "10.00", "currency": "USD"}`)
+	serve(server, http.MethodPost, "/withdraw", `{"account_id": 1, "amount": "105.00", "currency": "USD"}`)
+	rr = serve(server, http.MethodGet, "/accounts/1/transactions?type=deposit", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &history); err != nil || len(history.Transactions) != 2 {
+		t.Fatalf("Historial de depósitos inesperado (err: %v): %s", err, rr.Body)
+	}
+	assertProblem(t, serve(server, http.MethodPost, "/transactions/"+strconv.Itoa(history.Transactions[0].ID)+"/reverse", ""),
+		http.StatusUnprocessableEntity, http_conection.CodeInsufficientFunds)
+}
@@ -28,23 +28,34 @@ func NewAccountHandler(service *application.TransactionService) *AccountHandler
 // - r: la solicitud HTTP entrante (http.Request), que contiene los datos del depósito.
 func (h *AccountHandler) DepositHandler(w http.ResponseWriter, r *http.Request) {
 	var request struct {
-		AccountID int     `json:"account_id"` // ID de la cuenta en la que se realizará el depósito
-		Amount    float64 `json:"amount"`     // Monto del depósito
+		AccountID int          `json:"account_id"` // ID de la cuenta en la que se realizará el depósito
+		Amount    decimalField `json:"amount"`     // Monto del depósito (decimal exacto, sin pasar por float64)
+		Currency  string       `json:"currency"`   // Moneda del monto; debe ser la de la cuenta
 	}
 
-	// Decodificar la solicitud JSON en la estructura request
-	err := json.NewDecoder(r.Body).Decode(&request)
+	// Decodificar la solicitud JSON en la estructura request, rechazando campos desconocidos
+	if err := decodeJSON(w, r, &request); err != nil {
+		// JSON mal formado: 400; campos desconocidos o de otro tipo: 422 con el detalle por campo
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// Validar los campos de la solicitud; los errores se informan por campo con un 422
+	amount, err := h.service.Validator().ValidateTransaction(application.TransactionCommand{
+		AccountID: request.AccountID,
+		Amount:    string(request.Amount),
+		Currency:  request.Currency,
+	})
 	if err != nil {
-		// Si la solicitud no puede ser decodificada (por ejemplo, está mal formateada), devolver un error 400
-		http.Error(w, "Solicitud inválida", http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
 	// Procesar la transacción de depósito utilizando el servicio
-	err = h.service.ProcessTransaction(request.AccountID, request.Amount, "deposit")
+	err = h.service.ProcessTransaction(r.Context(), request.AccountID, amount, "deposit")
 	if err != nil {
-		// Si ocurre un error al procesar la transacción, devolver un error 500
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		// Si ocurre un error al procesar la transacción, devolver el código correspondiente al error
+		writeError(w, r, err)
 		return
 	}
 
@@ -60,23 +71,33 @@ func (h *AccountHandler) DepositHandler(w http.ResponseWriter, r *http.Request)
 // - r: la solicitud HTTP entrante (http.Request), que contiene los datos del retiro.
 func (h *AccountHandler) WithdrawHandler(w http.ResponseWriter, r *http.Request) {
 	var request struct {
-		AccountID int     `json:"account_id"` // ID de la cuenta de la que se retirarán los fondos
-		Amount    float64 `json:"amount"`     // Monto del retiro
+		AccountID int          `json:"account_id"` // ID de la cuenta de la que se retirarán los fondos
+		Amount    decimalField `json:"amount"`     // Monto del retiro (decimal exacto, sin pasar por float64)
+		Currency  string       `json:"currency"`   // Moneda del monto; debe ser la de la cuenta
+	}
+
+	// Decodificar la solicitud JSON en la estructura request, rechazando campos desconocidos
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
 	}
 
-	// Decodificar la solicitud JSON en la estructura request
-	err := json.NewDecoder(r.Body).Decode(&request)
+	// Validar los campos de la solicitud; los errores se informan por campo con un 422
+	amount, err := h.service.Validator().ValidateTransaction(application.TransactionCommand{
+		AccountID: request.AccountID,
+		Amount:    string(request.Amount),
+		Currency:  request.Currency,
+	})
 	if err != nil {
-		// Si la solicitud no puede ser decodificada, devolver un error 400
-		http.Error(w, "Solicitud inválida", http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
 	// Procesar la transacción de retiro utilizando el servicio
-	err = h.service.ProcessTransaction(request.AccountID, request.Amount, "withdrawal")
+	err = h.service.ProcessTransaction(r.Context(), request.AccountID, amount, "withdrawal")
 	if err != nil {
-		// Si ocurre un error al procesar la transacción, devolver un error 500
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		// Si ocurre un error al procesar la transacción, devolver el código correspondiente al error
+		writeError(w, r, err)
 		return
 	}
 
@@ -84,3 +105,53 @@ func (h *AccountHandler) WithdrawHandler(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Retiro exitoso"))
 }
+
+// TransferHandler maneja las solicitudes de transferencia entre cuentas realizadas a través de HTTP.
+// Debita la cuenta de origen y acredita la cuenta de destino de forma atómica.
+// Parámetros:
+// - w: el escritor de respuesta HTTP (http.ResponseWriter) que se utiliza para enviar la respuesta al cliente.
+// - r: la solicitud HTTP entrante (http.Request), que contiene los datos de la transferencia.
+func (h *AccountHandler) TransferHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		FromAccountID int          `json:"from_account_id"` // ID de la cuenta de origen
+		ToAccountID   int          `json:"to_account_id"`   // ID de la cuenta de destino
+		Amount        decimalField `json:"amount"`          // Monto de la transferencia
+		Currency      string       `json:"currency"`        // Moneda del monto; debe ser la de la cuenta de origen
+		QuoteID       string       `json:"quote_id"`        // Cotización de cambio; obligatoria entre cuentas de distinta moneda
+	}
+
+	// Decodificar la solicitud JSON en la estructura request, rechazando campos desconocidos
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// Validar los campos de la solicitud; los errores se informan por campo con un 422
+	amount, err := h.service.Validator().ValidateTransfer(application.TransferCommand{
+		FromAccountID: request.FromAccountID,
+		ToAccountID:   request.ToAccountID,
+		Amount:        string(request.Amount),
+		Currency:      request.Currency,
+		QuoteID:       request.QuoteID,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// Procesar la transferencia utilizando el servicio; con una cotización se convierte el monto acreditado
+	transferID, err := h.service.TransferWithQuote(r.Context(), request.FromAccountID, request.ToAccountID, amount, request.QuoteID)
+	if err != nil {
+		// Si ocurre un error al procesar la transferencia, devolver el código correspondiente al error
+		writeError(w, r, err)
+		return
+	}
+
+	// Si la transferencia es exitosa, devolver un código 201 con el identificador de la transferencia
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"transfer_id": transferID,
+		"message":     "Transferencia exitosa",
+	})
+}
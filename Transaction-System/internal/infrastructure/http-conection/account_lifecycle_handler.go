@@ -0,0 +1,245 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AccountLifecycleHandler maneja las solicitudes HTTP del ciclo de vida de las cuentas:
+// apertura, consulta, listado y cambios de estado.
+type AccountLifecycleHandler struct {
+	service *application.AccountService // Servicio que gestiona el ciclo de vida de las cuentas
+}
+
+// NewAccountLifecycleHandler crea un nuevo controlador del ciclo de vida de las cuentas.
+// Parámetros:
+// - service: una instancia de AccountService que gestiona las cuentas.
+// Retorna:
+// - Un puntero a AccountLifecycleHandler, que se utiliza para manejar las solicitudes HTTP de cuentas.
+func NewAccountLifecycleHandler(service *application.AccountService) *AccountLifecycleHandler {
+	return &AccountLifecycleHandler{service: service}
+}
+
+// accountResponse es la representación JSON de una cuenta.
+type accountResponse struct {
+	ID               int         `json:"id"`                // Identificador único de la cuenta
+	AccountNumber    string      `json:"account_number"`    // Número de cuenta
+	Balance          money.Money `json:"balance"`           // Balance contable; negativo si usa el sobregiro
+	Currency         string      `json:"currency"`          // Moneda del balance
+	OverdraftLimit   money.Money `json:"overdraft_limit"`   // Sobregiro autorizado
+	OverdraftUsed    money.Money `json:"overdraft_used"`    // Sobregiro en uso
+	Held             money.Money `json:"held"`              // Fondos reservados por retenciones activas
+	AvailableBalance money.Money `json:"available_balance"` // Monto disponible: balance más sobregiro autorizado menos retenciones
+	Status           string      `json:"status"`            // Estado de la cuenta
+	CreatedAt        time.Time   `json:"created_at"`        // Fecha de creación
+}
+
+// newAccountResponse convierte una cuenta del dominio en su representación JSON.
+func newAccountResponse(a *account.Account) accountResponse {
+	status := a.Status
+	if status == "" {
+		status = account.StatusActive
+	}
+	return accountResponse{
+		ID:               a.ID,
+		AccountNumber:    a.AccountNumber,
+		Balance:          a.Balance,
+		Currency:         a.Currency(),
+		OverdraftLimit:   a.OverdraftLimit(),
+		OverdraftUsed:    a.OverdraftUsed(),
+		Held:             a.HeldAmount(),
+		AvailableBalance: a.Available(),
+		Status:           string(status),
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+// OpenHandler abre una cuenta nueva con un número de cuenta generado, en la moneda indicada.
+// Ruta: POST /accounts
+func (h *AccountLifecycleHandler) OpenHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		InitialDeposit decimalField `json:"initial_deposit"` // Depósito inicial opcional
+		Currency       string       `json:"currency"`        // Moneda de la cuenta; por defecto USD
+	}
+
+	// Decodificar la solicitud JSON; un cuerpo vacío equivale a abrir la cuenta sin depósito
+	if r.ContentLength != 0 {
+		if err := decodeJSON(w, r, &request); err != nil {
+			writeDecodeError(w, r, err)
+			return
+		}
+	}
+
+	// Validar la moneda y el depósito inicial: puede omitirse o ser cero, pero no negativo ni superior al máximo.
+	// La cuenta se abre en la moneda del depósito
+	initialDeposit, err := h.service.Validator().ValidateInitialDeposit(string(request.InitialDeposit), request.Currency)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	acc, err := h.service.Open(r.Context(), initialDeposit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newAccountResponse(acc))
+}
+
+// GetHandler devuelve una cuenta por su ID.
+// Ruta: GET /accounts/{id}
+func (h *AccountLifecycleHandler) GetHandler(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "ID de cuenta inválido")
+		return
+	}
+
+	acc, err := h.service.Get(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountResponse(acc))
+}
+
+// ListHandler lista las cuentas con paginación y filtro opcional por estado.
+// Ruta: GET /accounts?status=active&limit=50&offset=0
+func (h *AccountLifecycleHandler) ListHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var filter account.ListFilter
+
+	// Filtro por estado
+	if s := query.Get("status"); s != "" {
+		status, err := account.ParseStatus(s)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		filter.Status = status
+	}
+
+	// Parámetros de paginación
+	var err error
+	if filter.Limit, err = queryInt(query.Get("limit")); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "Parámetro limit inválido")
+		return
+	}
+	if filter.Offset, err = queryInt(query.Get("offset")); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "Parámetro offset inválido")
+		return
+	}
+
+	page, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	items := make([]accountResponse, 0, len(page.Accounts))
+	for _, a := range page.Accounts {
+		items = append(items, newAccountResponse(a))
+	}
+
+	// Se informa el límite efectivo, que puede diferir del solicitado (valor por defecto o máximo)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"accounts": items,
+		"total":    page.Total,
+		"limit":    page.Limit,
+		"offset":   page.Offset,
+	})
+}
+
+// ChangeStatusHandler cambia el estado de una cuenta (active, frozen o closed).
+// Ruta: PATCH /accounts/{id}/status
+func (h *AccountLifecycleHandler) ChangeStatusHandler(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "ID de cuenta inválido")
+		return
+	}
+
+	var request struct {
+		Status string `json:"status"` // Nuevo estado de la cuenta
+	}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// El estado es obligatorio y debe ser uno de los estados conocidos
+	status, err := account.ParseStatus(request.Status)
+	if err != nil {
+		verr := &application.ValidationError{}
+		if request.Status == "" {
+			verr.Add("status", application.FieldRequired, "el campo es obligatorio")
+		} else {
+			verr.Add("status", application.FieldInvalid, "el estado debe ser active, frozen o closed")
+		}
+		writeError(w, r, verr)
+		return
+	}
+
+	acc, err := h.service.ChangeStatus(r.Context(), accountID, status)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountResponse(acc))
+}
+
+// SetOverdraftHandler fija el sobregiro autorizado de una cuenta; un límite cero lo deshabilita.
+// Ruta: PUT /accounts/{id}/overdraft
+// Cuerpo: {"limit": "500.00", "currency": "USD"}
+func (h *AccountLifecycleHandler) SetOverdraftHandler(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "ID de cuenta inválido")
+		return
+	}
+
+	var request struct {
+		Limit    decimalField `json:"limit"`    // Sobregiro autorizado
+		Currency string       `json:"currency"` // Moneda del límite; debe ser la de la cuenta
+	}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	limit, err := h.service.Validator().ValidateOverdraft(application.OverdraftCommand{
+		Limit:    string(request.Limit),
+		Currency: request.Currency,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	acc, err := h.service.SetOverdraftLimit(r.Context(), accountID, limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAccountResponse(acc))
+}
+
+// writeJSON escribe una respuesta JSON con el código de estado indicado.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// queryInt convierte un parámetro de consulta en entero; un parámetro vacío vale cero.
+func queryInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
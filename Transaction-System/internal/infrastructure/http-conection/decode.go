@@ -0,0 +1,98 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxRequestBodyBytes limita el tamaño del cuerpo JSON de las solicitudes.
+const maxRequestBodyBytes = 1 << 20
+
+// errMalformedJSON indica que el cuerpo de la solicitud no es un JSON válido.
+var errMalformedJSON = errors.New("el cuerpo de la solicitud no es un JSON válido")
+
+// decodeJSON decodifica el cuerpo JSON de la solicitud en dst de forma estricta:
+// rechaza campos desconocidos, valores de un tipo inesperado y contenido adicional tras el objeto.
+// Retorna un *application.ValidationError si el JSON es válido pero no cumple con la estructura
+// esperada, un *http.MaxBytesError si el cuerpo supera maxRequestBodyBytes, o un error que envuelve
+// errMalformedJSON si el cuerpo no puede interpretarse.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(dst)
+	if err == nil {
+		// Sólo se admite un objeto JSON por solicitud
+		if decoder.Decode(&struct{}{}) != io.EOF {
+			return fmt.Errorf("%w: contenido adicional tras el objeto", errMalformedJSON)
+		}
+		return nil
+	}
+
+	// Errores de estructura: se informan por campo
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		verr := &application.ValidationError{}
+		verr.Add(typeErr.Field, application.FieldInvalidType, fmt.Sprintf("se esperaba un valor de tipo %s", typeErr.Type))
+		return verr
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		verr := &application.ValidationError{}
+		verr.Add(strings.Trim(field, `"`), application.FieldUnknown, "el campo no existe")
+		return verr
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return tooLarge
+	}
+	return fmt.Errorf("%w: %v", errMalformedJSON, err)
+}
+
+// writeDecodeError responde al error devuelto por decodeJSON:
+// 422 con el detalle por campo para los errores de estructura, 413 para un cuerpo demasiado grande
+// y 400 para el JSON mal formado.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeTooLarge(w, r, tooLarge)
+		return
+	}
+	if errors.Is(err, errMalformedJSON) {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
+	}
+	writeError(w, r, err)
+}
+
+// writeTooLarge responde 413 a una solicitud cuyo cuerpo supera el máximo admitido.
+func writeTooLarge(w http.ResponseWriter, r *http.Request, tooLarge *http.MaxBytesError) {
+	writeProblem(w, r, http.StatusRequestEntityTooLarge, CodeRequestTooLarge,
+		fmt.Sprintf("El cuerpo de la solicitud supera el máximo de %d bytes", tooLarge.Limit))
+}
+
+// decimalField recibe un monto JSON como texto sin pasar por float64.
+// Acepta tanto un número (150.25) como una cadena ("150.25"); la validación del valor
+// la realiza application.Validator.
+type decimalField string
+
+// UnmarshalJSON guarda el texto del número o el contenido de la cadena; null equivale a omitir el campo.
+func (d *decimalField) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*d = decimalField(s)
+		return nil
+	}
+	*d = decimalField(data)
+	return nil
+}
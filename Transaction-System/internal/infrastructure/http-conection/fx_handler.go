@@ -0,0 +1,181 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// FXHandler maneja las solicitudes HTTP de cambio de divisas: cotizaciones y tipos de cambio.
+type FXHandler struct {
+	service *application.FXService // Servicio que emite cotizaciones y administra los tipos de cambio
+}
+
+// NewFXHandler crea un nuevo controlador de cambio de divisas (FXHandler).
+// Parámetros:
+// - service: una instancia de FXService que emite las cotizaciones.
+// Retorna:
+// - Un puntero a FXHandler, que se utiliza para manejar las solicitudes HTTP de cambio de divisas.
+func NewFXHandler(service *application.FXService) *FXHandler {
+	return &FXHandler{service: service}
+}
+
+// quoteResponse es la representación JSON de una cotización de cambio.
+type quoteResponse struct {
+	ID             string      `json:"id"`              // Identificador de la cotización, para indicarlo en la transferencia
+	Amount         money.Money `json:"amount"`          // Monto que se debita
+	Currency       string      `json:"currency"`        // Moneda del monto que se debita
+	TargetAmount   money.Money `json:"target_amount"`   // Monto que se acredita
+	TargetCurrency string      `json:"target_currency"` // Moneda del monto que se acredita
+	MidRate        string      `json:"mid_rate"`        // Tipo de cambio de mercado
+	SpreadBPS      string      `json:"spread_bps"`      // Margen aplicado, en puntos básicos
+	Rate           string      `json:"rate"`            // Tipo de cambio aplicado
+	RateSource     string      `json:"rate_source"`     // Origen del tipo de mercado: "static" o "manual"
+	CreatedAt      time.Time   `json:"created_at"`      // Fecha de emisión
+	ExpiresAt      time.Time   `json:"expires_at"`      // Fecha de vencimiento
+	Expired        bool        `json:"expired"`         // Indica si la cotización ya venció
+}
+
+// newQuoteResponse convierte una cotización del dominio en su representación JSON.
+func newQuoteResponse(q *fx.Quote) quoteResponse {
+	bps := new(big.Rat).Mul(q.Spread, big.NewRat(10000, 1))
+	return quoteResponse{
+		ID:             q.ID,
+		Amount:         q.Source,
+		Currency:       q.Source.Currency(),
+		TargetAmount:   q.Target,
+		TargetCurrency: q.Target.Currency(),
+		MidRate:        fx.FormatRate(q.MidRate),
+		SpreadBPS:      fx.FormatRate(bps),
+		Rate:           fx.FormatRate(q.Rate),
+		RateSource:     q.RateSource,
+		CreatedAt:      q.CreatedAt,
+		ExpiresAt:      q.ExpiresAt,
+		Expired:        q.Expired(time.Now()),
+	}
+}
+
+// rateResponse es la representación JSON de un tipo de cambio.
+type rateResponse struct {
+	Base      string    `json:"base"`       // Moneda base del par
+	Quote     string    `json:"quote"`      // Moneda cotizada del par
+	Rate      string    `json:"rate"`       // Unidades de la moneda cotizada por unidad de la base
+	Source    string    `json:"source"`     // Origen: "static" o "manual"
+	UpdatedAt time.Time `json:"updated_at"` // Fecha en que se fijó el tipo
+}
+
+// newRateResponse convierte un tipo de cambio del dominio en su representación JSON.
+func newRateResponse(rate fx.Rate) rateResponse {
+	return rateResponse{
+		Base:      rate.Pair.Base,
+		Quote:     rate.Pair.Quote,
+		Rate:      rate.Decimal(),
+		Source:    rate.Source,
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+// QuoteHandler emite una cotización para convertir un monto a otra moneda.
+// Ruta: POST /fx/quotes
+// Cuerpo: {"amount": "100.00", "currency": "USD", "target_currency": "EUR"}
+func (h *FXHandler) QuoteHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Amount         decimalField `json:"amount"`          // Monto a convertir
+		Currency       string       `json:"currency"`        // Moneda del monto
+		TargetCurrency string       `json:"target_currency"` // Moneda a la que se convierte
+	}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	amount, pair, err := h.service.Validator().ValidateQuote(application.QuoteCommand{
+		Amount:         string(request.Amount),
+		Currency:       request.Currency,
+		TargetCurrency: request.TargetCurrency,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	quote, err := h.service.Quote(r.Context(), amount, pair)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newQuoteResponse(quote))
+}
+
+// GetQuoteHandler devuelve una cotización emitida, vigente o vencida.
+// Ruta: GET /fx/quotes/{id}
+func (h *FXHandler) GetQuoteHandler(w http.ResponseWriter, r *http.Request) {
+	quote, err := h.service.FindQuote(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newQuoteResponse(quote))
+}
+
+// ListRatesHandler devuelve los tipos de cambio publicados con su origen.
+// Ruta: GET /fx/rates
+func (h *FXHandler) ListRatesHandler(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.service.Rates(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]rateResponse, 0, len(rates))
+	for _, rate := range rates {
+		items = append(items, newRateResponse(rate))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"rates": items})
+}
+
+// SetRateHandler fija manualmente el tipo de cambio de un par.
+// Ruta: PUT /admin/fx/rates/{base}/{quote}
+// Cuerpo: {"rate": "0.9215"}
+func (h *FXHandler) SetRateHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Rate decimalField `json:"rate"` // Tipo de cambio decimal, como número o como cadena
+	}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	rate, err := h.service.Validator().ValidateRate(application.RateCommand{
+		Base:  r.PathValue("base"),
+		Quote: r.PathValue("quote"),
+		Rate:  string(request.Rate),
+	}, time.Now())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.SetRate(r.Context(), rate); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newRateResponse(rate))
+}
+
+// DeleteRateHandler elimina el tipo de cambio fijado manualmente para un par.
+// Ruta: DELETE /admin/fx/rates/{base}/{quote}
+func (h *FXHandler) DeleteRateHandler(w http.ResponseWriter, r *http.Request) {
+	pair, err := fx.NewPair(r.PathValue("base"), r.PathValue("quote"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
+	}
+	if err := h.service.DeleteRate(r.Context(), pair); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
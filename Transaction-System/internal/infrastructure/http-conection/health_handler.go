@@ -0,0 +1,42 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/infrastructure/health"
+	"net/http"
+)
+
+// HealthHandler maneja las solicitudes de estado del servicio que usan los orquestadores.
+type HealthHandler struct {
+	readiness *health.Registry // Comprobaciones que deben cumplirse para recibir tráfico
+}
+
+// NewHealthHandler crea un nuevo controlador de estado (HealthHandler).
+// Parámetros:
+// - readiness: el registro con las comprobaciones de disponibilidad de las dependencias.
+// Retorna:
+// - Un puntero a HealthHandler, que se utiliza para manejar /healthz y /readyz.
+func NewHealthHandler(readiness *health.Registry) *HealthHandler {
+	return &HealthHandler{readiness: readiness}
+}
+
+// LivenessHandler indica que el proceso está vivo y atiende solicitudes.
+// No consulta dependencias: una base de datos caída no se soluciona reiniciando el proceso.
+// Ruta: GET /healthz
+func (h *HealthHandler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": health.StatusUp})
+}
+
+// ReadinessHandler ejecuta las comprobaciones registradas e indica si el servicio puede recibir tráfico.
+// Responde 200 si todos los componentes están disponibles y 503 en caso contrario,
+// con el resultado de cada componente en ambos casos.
+// Ruta: GET /readyz
+func (h *HealthHandler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.readiness.Check(r.Context())
+
+	status := http.StatusOK
+	if !report.Up() {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, report)
+}
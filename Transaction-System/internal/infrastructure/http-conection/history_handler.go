@@ -0,0 +1,192 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout es el formato aceptado para las fechas sin hora en los filtros del historial.
+const dateLayout = "2006-01-02"
+
+// transactionResponse es la representación JSON de una transacción del historial.
+type transactionResponse struct {
+	ID              int               `json:"id"`                    // Identificador único de la transacción
+	AccountID       int               `json:"account_id"`            // Cuenta a la que se aplicó
+	Amount          money.Money       `json:"amount"`                // Monto de la transacción
+	Currency        string            `json:"currency"`              // Moneda del monto
+	TransactionType string            `json:"transaction_type"`      // Tipo de transacción
+	TransferID      string            `json:"transfer_id,omitempty"` // Transferencia a la que pertenece, si aplica
+	Exchange        *exchangeResponse `json:"exchange,omitempty"`    // Cambio de divisas aplicado, si la transferencia convirtió monedas
+	ReversalOf      int               `json:"reversal_of,omitempty"` // Transacción revertida, si es una reversión
+	CreatedAt       time.Time         `json:"created_at"`            // Fecha de creación
+}
+
+// newTransactionResponse convierte una transacción del dominio en su representación JSON.
+func newTransactionResponse(t *transaction.Transaction) transactionResponse {
+	return transactionResponse{
+		ID:              t.ID,
+		AccountID:       t.AccountID,
+		Amount:          t.Amount,
+		Currency:        t.Amount.Currency(),
+		TransactionType: t.TransactionType,
+		TransferID:      t.TransferID,
+		Exchange:        newExchangeResponse(t.Conversion),
+		ReversalOf:      t.ReversalOf,
+		CreatedAt:       t.CreatedAt,
+	}
+}
+
+// exchangeResponse es la representación JSON del cambio de divisas de una pata de transferencia.
+type exchangeResponse struct {
+	QuoteID         string      `json:"quote_id"`         // Cotización con la que se liquidó la transferencia
+	Rate            string      `json:"rate"`             // Tipo aplicado: unidades de la moneda de destino por unidad de la de origen
+	CounterAmount   money.Money `json:"counter_amount"`   // Monto de la otra pata
+	CounterCurrency string      `json:"counter_currency"` // Moneda de la otra pata
+}
+
+// newExchangeResponse convierte el cambio de divisas de una transacción; devuelve nil si no lo hubo.
+func newExchangeResponse(c *transaction.Conversion) *exchangeResponse {
+	if c == nil {
+		return nil
+	}
+	return &exchangeResponse{
+		QuoteID:         c.QuoteID,
+		Rate:            fx.FormatRate(c.Rate),
+		CounterAmount:   c.CounterAmount,
+		CounterCurrency: c.CounterAmount.Currency(),
+	}
+}
+
+// HistoryHandler devuelve el historial de transacciones de una cuenta con paginación por cursor.
+// Ruta: GET /accounts/{id}/transactions
+// Parámetros de consulta (todos opcionales):
+// - type: tipos de transacción separados por comas (deposit, withdrawal, transfer_out, transfer_in,
+// overdraft_interest, overdraft_fee, capture, reversal).
+// - min_amount, max_amount: rango de montos, ambos inclusive.
+// - currency: moneda del rango de montos (por defecto USD); debe ser la de la cuenta.
+// - from, to: rango de fechas en RFC 3339 o AAAA-MM-DD; from es inclusive y to exclusivo
+// (una fecha sin hora en to incluye el día completo).
+// - limit: cantidad de transacciones por página.
+// - cursor: valor next_cursor de la página anterior.
+func (h *AccountHandler) HistoryHandler(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "ID de cuenta inválido")
+		return
+	}
+
+	// Traducir los parámetros de consulta al filtro del dominio
+	filter, err := parseHistoryFilter(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
+	}
+
+	page, err := h.service.History(r.Context(), accountID, filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	items := make([]transactionResponse, 0, len(page.Transactions))
+	for _, t := range page.Transactions {
+		items = append(items, newTransactionResponse(t))
+	}
+
+	// next_cursor es null en la última página
+	var nextCursor *string
+	if page.NextCursor != nil {
+		encoded := page.NextCursor.Encode()
+		nextCursor = &encoded
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"transactions": items,
+		"next_cursor":  nextCursor,
+		"limit":        page.Limit,
+	})
+}
+
+// parseHistoryFilter construye el filtro del historial a partir de los parámetros de consulta.
+// Retorna un error descriptivo si algún parámetro no es válido.
+func parseHistoryFilter(query url.Values) (transaction.Filter, error) {
+	var filter transaction.Filter
+
+	// Tipos de transacción
+	if types := query.Get("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			t = strings.TrimSpace(t)
+			switch t {
+			case transaction.TypeDeposit, transaction.TypeWithdrawal, transaction.TypeTransferOut, transaction.TypeTransferIn,
+				transaction.TypeOverdraftInterest, transaction.TypeOverdraftFee, transaction.TypeCapture, transaction.TypeReversal:
+				filter.Types = append(filter.Types, t)
+			default:
+				return filter, fmt.Errorf("tipo de transacción inválido: %q", t)
+			}
+		}
+	}
+
+	// Rango de montos, en la moneda indicada
+	currency := query.Get("currency")
+	for _, param := range []struct {
+		name   string
+		target **money.Money
+	}{{"min_amount", &filter.MinAmount}, {"max_amount", &filter.MaxAmount}} {
+		if value := query.Get(param.name); value != "" {
+			amount, err := money.Parse(value, currency)
+			if err != nil {
+				return filter, fmt.Errorf("parámetro %s inválido: %w", param.name, err)
+			}
+			*param.target = &amount
+		}
+	}
+
+	// Rango de fechas
+	var err error
+	if filter.From, err = parseDateParam(query.Get("from"), false); err != nil {
+		return filter, fmt.Errorf("parámetro from inválido: %w", err)
+	}
+	if filter.To, err = parseDateParam(query.Get("to"), true); err != nil {
+		return filter, fmt.Errorf("parámetro to inválido: %w", err)
+	}
+
+	// Paginación
+	if filter.Limit, err = queryInt(query.Get("limit")); err != nil {
+		return filter, errors.New("parámetro limit inválido")
+	}
+	if c := query.Get("cursor"); c != "" {
+		cursor, err := transaction.DecodeCursor(c)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &cursor
+	}
+	return filter, nil
+}
+
+// parseDateParam interpreta una fecha en RFC 3339 o AAAA-MM-DD (UTC); un valor vacío devuelve la fecha cero.
+// Si endOfDay es verdadero, una fecha sin hora se desplaza al inicio del día siguiente para
+// que el límite exclusivo incluya el día completo.
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("se esperaba RFC 3339 o AAAA-MM-DD")
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
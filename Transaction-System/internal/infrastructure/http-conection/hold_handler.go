@@ -0,0 +1,150 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/hold"
+	"Transaction-System/internal/domain/money"
+	"net/http"
+	"time"
+)
+
+// HoldHandler maneja las solicitudes HTTP de retenciones de fondos: autorización, captura y liberación.
+type HoldHandler struct {
+	service *application.HoldService // Servicio que autoriza, captura y libera las retenciones
+}
+
+// NewHoldHandler crea un nuevo controlador de retenciones de fondos (HoldHandler).
+// Parámetros:
+// - service: una instancia de HoldService que gestiona las retenciones.
+// Retorna:
+// - Un puntero a HoldHandler, que se utiliza para manejar las solicitudes HTTP de retenciones.
+func NewHoldHandler(service *application.HoldService) *HoldHandler {
+	return &HoldHandler{service: service}
+}
+
+// holdResponse es la representación JSON de una retención de fondos.
+type holdResponse struct {
+	ID            string      `json:"id"`                       // Identificador de la retención
+	AccountID     int         `json:"account_id"`               // Cuenta cuyos fondos se retienen
+	Amount        money.Money `json:"amount"`                   // Monto autorizado y retenido
+	Captured      money.Money `json:"captured"`                 // Monto capturado; cero si no se capturó
+	Currency      string      `json:"currency"`                 // Moneda de la cuenta
+	Reference     string      `json:"reference,omitempty"`      // Referencia del comercio u origen
+	Status        string      `json:"status"`                   // active, captured, released o expired
+	TransactionID int         `json:"transaction_id,omitempty"` // Transacción "capture" del monto capturado
+	CreatedAt     time.Time   `json:"created_at"`               // Fecha de la autorización
+	ExpiresAt     time.Time   `json:"expires_at"`               // Fecha de vencimiento
+	ClosedAt      *time.Time  `json:"closed_at,omitempty"`      // Fecha de captura, liberación o vencimiento
+}
+
+// newHoldResponse convierte una retención del dominio en su representación JSON.
+func newHoldResponse(h *hold.Hold) holdResponse {
+	resp := holdResponse{
+		ID:            h.ID,
+		AccountID:     h.AccountID,
+		Amount:        h.Amount,
+		Captured:      h.Captured,
+		Currency:      h.Amount.Currency(),
+		Reference:     h.Reference,
+		Status:        string(h.Status),
+		TransactionID: h.TransactionID,
+		CreatedAt:     h.CreatedAt,
+		ExpiresAt:     h.ExpiresAt,
+	}
+	if !h.ClosedAt.IsZero() {
+		closedAt := h.ClosedAt
+		resp.ClosedAt = &closedAt
+	}
+	return resp
+}
+
+// AuthorizeHandler autoriza una retención que reduce el disponible de la cuenta sin registrar un movimiento.
+// Ruta: POST /holds
+// Cuerpo: {"account_id": 1, "amount": "25.00", "currency": "USD", "reference": "pedido-981", "ttl": "72h"}
+func (h *HoldHandler) AuthorizeHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		AccountID int          `json:"account_id"` // ID de la cuenta cuyos fondos se retienen
+		Amount    decimalField `json:"amount"`     // Monto a retener
+		Currency  string       `json:"currency"`   // Moneda del monto; debe ser la de la cuenta
+		Reference string       `json:"reference"`  // Referencia opcional del comercio u origen
+		TTL       string       `json:"ttl"`        // Vigencia opcional, como duración ("30m", "72h")
+	}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	amount, ttl, err := h.service.Validator().ValidateHold(application.HoldCommand{
+		AccountID: request.AccountID,
+		Amount:    string(request.Amount),
+		Currency:  request.Currency,
+		Reference: request.Reference,
+		TTL:       request.TTL,
+	}, h.service.MaxTTL())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	authorized, err := h.service.Authorize(r.Context(), request.AccountID, amount, request.Reference, ttl)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newHoldResponse(authorized))
+}
+
+// GetHandler devuelve una retención, activa o cerrada.
+// Ruta: GET /holds/{id}
+func (h *HoldHandler) GetHandler(w http.ResponseWriter, r *http.Request) {
+	found, err := h.service.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newHoldResponse(found))
+}
+
+// CaptureHandler captura una retención activa, total o parcialmente, y libera el resto.
+// Sin cuerpo (o sin monto) se captura todo el monto retenido.
+// Ruta: POST /holds/{id}/capture
+// Cuerpo: {"amount": "20.00", "currency": "USD"}
+func (h *HoldHandler) CaptureHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Amount   decimalField `json:"amount"`   // Monto a capturar; opcional
+		Currency string       `json:"currency"` // Moneda del monto; obligatoria si se indica el monto
+	}
+	if r.ContentLength != 0 {
+		if err := decodeJSON(w, r, &request); err != nil {
+			writeDecodeError(w, r, err)
+			return
+		}
+	}
+
+	amount, err := h.service.Validator().ValidateCapture(application.CaptureCommand{
+		Amount:   string(request.Amount),
+		Currency: request.Currency,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	captured, err := h.service.Capture(r.Context(), r.PathValue("id"), amount)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newHoldResponse(captured))
+}
+
+// ReleaseHandler libera una retención activa y devuelve sus fondos al disponible de la cuenta.
+// Ruta: POST /holds/{id}/release
+func (h *HoldHandler) ReleaseHandler(w http.ResponseWriter, r *http.Request) {
+	released, err := h.service.Release(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newHoldResponse(released))
+}
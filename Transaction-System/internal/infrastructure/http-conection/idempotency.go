@@ -0,0 +1,207 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/domain/idempotency"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// IdempotencyKeyHeader es la cabecera con la que el cliente identifica una solicitud que puede reintentar.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength es la longitud máxima aceptada para la cabecera Idempotency-Key.
+const maxIdempotencyKeyLength = 255
+
+// Idempotency evita que los reintentos de una misma solicitud (por ejemplo, por timeouts del cliente
+// o reintentos de Locust) procesen el dinero dos veces.
+// Cuando la solicitud incluye la cabecera Idempotency-Key:
+//   - La primera solicitud se procesa y su respuesta se guarda junto con la huella de la solicitud.
+//   - Un reintento con la misma clave y el mismo contenido recibe la respuesta original.
+//   - Un reintento mientras la original sigue en curso recibe 409 Conflict.
+//   - Reutilizar la clave con un contenido distinto devuelve 422 Unprocessable Entity.
+//   - Las respuestas 5xx y 409 no se guardan, de modo que un reintento vuelve a procesar la solicitud.
+type Idempotency struct {
+	repo      idempotency.Repository // Repositorio donde se guardan las claves y las respuestas
+	retention time.Duration          // Tiempo durante el cual una clave tiene efecto
+}
+
+// NewIdempotency crea el componente de idempotencia.
+// Parámetros:
+// - repo: repositorio donde se guardan las claves y las respuestas.
+// - retention: tiempo durante el cual se conserva cada clave; pasado ese tiempo la clave puede reutilizarse.
+func NewIdempotency(repo idempotency.Repository, retention time.Duration) *Idempotency {
+	return &Idempotency{repo: repo, retention: retention}
+}
+
+// Wrap envuelve un manejador HTTP aplicando la deduplicación por Idempotency-Key.
+// Las solicitudes sin la cabecera se procesan normalmente.
+func (i *Idempotency) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "Idempotency-Key demasiado larga")
+			return
+		}
+
+		// Leer el cuerpo para calcular la huella y restaurarlo para el manejador; se aplica el mismo límite
+		// que en decodeJSON para no cargar en memoria cuerpos arbitrariamente grandes
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeTooLarge(w, r, tooLarge)
+			return
+		}
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "No se pudo leer el cuerpo de la solicitud")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(r, body)
+
+		// Reservar la clave; si ya existe, resolver el reintento con el registro guardado
+		record := idempotency.NewRecord(key, fingerprint, i.retention)
+		if err := i.reserve(r.Context(), record); err != nil {
+			if errors.Is(err, idempotency.ErrKeyExists) {
+				i.replay(w, r, key, fingerprint)
+				return
+			}
+			writeError(w, r, err)
+			return
+		}
+
+		// Procesar la solicitud capturando la respuesta para guardarla
+		recorder := newBufferedResponse()
+		next(recorder, r)
+
+		// El resultado se registra aunque el cliente ya se haya desconectado: la operación pudo haberse
+		// confirmado, y una clave sin completar bloquearía los reintentos hasta que expire
+		ctx := context.WithoutCancel(r.Context())
+		if recorder.status >= http.StatusInternalServerError || recorder.status == http.StatusConflict {
+			// Los errores del servidor y los conflictos de concurrencia no se guardan:
+			// se libera la clave para permitir el reintento
+			if err := i.repo.Delete(ctx, key); err != nil {
+				slog.ErrorContext(r.Context(), "no se pudo liberar la clave de idempotencia", "key", key, "error", err)
+			}
+		} else {
+			record.StatusCode = recorder.status
+			record.ContentType = recorder.Header().Get("Content-Type")
+			record.ResponseBody = recorder.body.Bytes()
+			if err := i.repo.Complete(ctx, record); err != nil {
+				slog.ErrorContext(r.Context(), "no se pudo guardar la respuesta de la clave de idempotencia", "key", key, "error", err)
+			}
+		}
+
+		recorder.writeTo(w)
+	}
+}
+
+// PurgeExpired elimina las claves cuyo periodo de retención terminó.
+// Retorna la cantidad de claves eliminadas.
+func (i *Idempotency) PurgeExpired(ctx context.Context) (int64, error) {
+	return i.repo.DeleteExpired(ctx, time.Now())
+}
+
+// reserve intenta registrar la clave; si la clave existente ya expiró, la elimina y reintenta.
+func (i *Idempotency) reserve(ctx context.Context, record *idempotency.Record) error {
+	err := i.repo.Reserve(ctx, record)
+	if !errors.Is(err, idempotency.ErrKeyExists) {
+		return err
+	}
+
+	existing, findErr := i.repo.Find(ctx, record.Key)
+	if findErr != nil || !existing.Expired(time.Now()) {
+		return err
+	}
+	if err := i.repo.Delete(ctx, record.Key); err != nil {
+		return err
+	}
+	return i.repo.Reserve(ctx, record)
+}
+
+// replay responde a un reintento usando el registro guardado bajo la clave.
+func (i *Idempotency) replay(w http.ResponseWriter, r *http.Request, key, fingerprint string) {
+	existing, err := i.repo.Find(r.Context(), key)
+	if errors.Is(err, idempotency.ErrNotFound) {
+		// La solicitud original falló y liberó la clave mientras se procesaba este reintento
+		writeProblem(w, r, http.StatusConflict, CodeRequestInProgress, "La solicitud original con esta Idempotency-Key no se completó, reintente")
+		return
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	switch {
+	case existing.Fingerprint != fingerprint:
+		writeProblem(w, r, http.StatusUnprocessableEntity, CodeIdempotencyKeyReused, "La Idempotency-Key ya fue usada con una solicitud distinta")
+	case !existing.Completed():
+		writeProblem(w, r, http.StatusConflict, CodeRequestInProgress, "Una solicitud con esta Idempotency-Key sigue en curso")
+	default:
+		// Devolver exactamente la respuesta original
+		if existing.ContentType != "" {
+			w.Header().Set("Content-Type", existing.ContentType)
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(existing.StatusCode)
+		w.Write(existing.ResponseBody)
+	}
+}
+
+// requestFingerprint calcula la huella SHA-256 del método, la ruta y el cuerpo de la solicitud.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bufferedResponse es un http.ResponseWriter que guarda la respuesta en memoria
+// para poder almacenarla antes de enviarla al cliente.
+type bufferedResponse struct {
+	header http.Header  // Cabeceras escritas por el manejador
+	status int          // Código de estado escrito por el manejador
+	body   bytes.Buffer // Cuerpo escrito por el manejador
+}
+
+// newBufferedResponse crea una respuesta en memoria con estado 200 por defecto.
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+// Header devuelve las cabeceras de la respuesta.
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+// WriteHeader guarda el código de estado de la respuesta.
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+// Write guarda los bytes del cuerpo de la respuesta.
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// writeTo envía la respuesta guardada al cliente.
+func (b *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for name, values := range b.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
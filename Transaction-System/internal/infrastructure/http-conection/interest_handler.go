@@ -0,0 +1,71 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/money"
+	"net/http"
+	"time"
+)
+
+// InterestHandler maneja las solicitudes HTTP de administración de los intereses de sobregiro.
+type InterestHandler struct {
+	service *application.InterestService // Servicio que calcula y cobra los intereses
+}
+
+// NewInterestHandler crea un nuevo controlador de intereses de sobregiro (InterestHandler).
+// Parámetros:
+// - service: una instancia de InterestService que cobra los intereses.
+// Retorna:
+// - Un puntero a InterestHandler, que se utiliza para manejar las solicitudes HTTP de intereses.
+func NewInterestHandler(service *application.InterestService) *InterestHandler {
+	return &InterestHandler{service: service}
+}
+
+// accrualResponse es la representación JSON del cargo de intereses y comisión de una cuenta.
+type accrualResponse struct {
+	AccountID        int         `json:"account_id"`                   // Cuenta a la que se cobraron los intereses
+	OverdraftUsed    money.Money `json:"overdraft_used"`               // Sobregiro en uso sobre el que se calcularon
+	Interest         money.Money `json:"interest"`                     // Intereses cobrados
+	Fee              money.Money `json:"fee"`                          // Comisión de sobregiro cobrada
+	Currency         string      `json:"currency"`                     // Moneda de la cuenta
+	TransactionID    int         `json:"transaction_id,omitempty"`     // Transacción "overdraft_interest" del cargo
+	FeeTransactionID int         `json:"fee_transaction_id,omitempty"` // Transacción "overdraft_fee" de la comisión
+}
+
+// AccrueHandler cobra los intereses y la comisión de sobregiro de un día. Sin el parámetro day se cobra el día
+// anterior (UTC), igual que la tarea periódica; volver a cobrar un día ya cobrado no tiene efecto.
+// Ruta: POST /admin/overdraft/interest?day=2024-09-10
+func (h *InterestHandler) AccrueHandler(w http.ResponseWriter, r *http.Request) {
+	day := time.Now().UTC().AddDate(0, 0, -1)
+	if raw := r.URL.Query().Get("day"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "day debe tener el formato AAAA-MM-DD")
+			return
+		}
+		day = parsed
+	}
+
+	report, err := h.service.AccrueOverdraftInterest(r.Context(), day)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]accrualResponse, 0, len(report.Accruals))
+	for _, a := range report.Accruals {
+		items = append(items, accrualResponse{
+			AccountID:        a.AccountID,
+			OverdraftUsed:    a.Used,
+			Interest:         a.Interest,
+			Fee:              a.Fee,
+			Currency:         a.Interest.Currency(),
+			TransactionID:    a.TransactionID,
+			FeeTransactionID: a.FeeTransactionID,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"day":      report.Day.Format(time.DateOnly),
+		"accruals": items,
+		"skipped":  report.Skipped,
+	})
+}
@@ -0,0 +1,59 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// LedgerHandler maneja las solicitudes HTTP de consulta y verificación del libro mayor.
+type LedgerHandler struct {
+	service *application.LedgerService // Servicio que deriva y verifica balances desde el libro mayor
+}
+
+// NewLedgerHandler crea un nuevo controlador del libro mayor (LedgerHandler).
+// Parámetros:
+// - service: una instancia de LedgerService que consulta el libro mayor.
+// Retorna:
+// - Un puntero a LedgerHandler, que se utiliza para manejar las solicitudes HTTP del libro mayor.
+func NewLedgerHandler(service *application.LedgerService) *LedgerHandler {
+	return &LedgerHandler{service: service}
+}
+
+// VerifyAccountHandler compara el balance guardado de una cuenta con el derivado del libro mayor.
+// Ruta: GET /ledger/accounts/{id}
+func (h *LedgerHandler) VerifyAccountHandler(w http.ResponseWriter, r *http.Request) {
+	// Obtener el ID de la cuenta desde la ruta
+	accountID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "ID de cuenta inválido")
+		return
+	}
+
+	// Conciliar la cuenta con el libro mayor
+	verification, err := h.service.VerifyAccount(r.Context(), accountID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(verification)
+}
+
+// TrialBalanceHandler devuelve el balance de comprobación del libro mayor por moneda.
+// Ruta: GET /ledger/trial-balance
+func (h *LedgerHandler) TrialBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	totals, balanced, err := h.service.VerifyJournal(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"totals":   totals,
+		"balanced": balanced,
+	})
+}
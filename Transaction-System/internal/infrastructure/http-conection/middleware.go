@@ -0,0 +1,127 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/infrastructure/logging"
+	"crypto/sha256"
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader es la cabecera con la que se recibe y se devuelve el identificador de la solicitud.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength es la longitud máxima aceptada para un X-Request-ID recibido del cliente.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware asigna un identificador a cada solicitud y lo guarda en su contexto,
+// de modo que todos los logs emitidos durante la solicitud puedan correlacionarse.
+// Si el cliente (o un proxy) envía un X-Request-ID válido se conserva; en caso contrario se genera uno.
+// El identificador se devuelve en la cabecera X-Request-ID de la respuesta.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = logging.NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID indica si un identificador recibido puede usarse tal cual: no vacío, de longitud
+// acotada y sólo con caracteres ASCII imprimibles, para que no pueda alterar el formato de los logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLogMiddleware registra una línea estructurada por solicitud al terminar de atenderla,
+// con el método, la ruta, el código de estado, los bytes escritos y la duración.
+// Debe ubicarse después de RequestIDMiddleware para que la línea incluya el request_id.
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		// Los errores del servidor se registran con nivel de advertencia para distinguirlos
+		level := slog.LevelInfo
+		if rec.Status() >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+		slog.LogAttrs(r.Context(), level, "solicitud completada",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.Status()),
+			slog.Int64("bytes", rec.bytes),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("user_agent", r.UserAgent()),
+		)
+	})
+}
+
+// responseRecorder envuelve un http.ResponseWriter para capturar el código de estado y los bytes escritos.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int   // Código de estado escrito; cero si el manejador no llamó a WriteHeader
+	bytes  int64 // Bytes del cuerpo escritos
+}
+
+// WriteHeader registra el código de estado antes de escribirlo.
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write cuenta los bytes escritos; si aún no se escribió el estado, net/http usa 200.
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Status devuelve el código de estado de la respuesta (200 si el manejador no escribió nada).
+func (r *responseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Unwrap permite a http.ResponseController acceder al ResponseWriter original.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// RequireAdminToken protege una ruta de administración: sólo atiende las solicitudes que envían el token
+// en la cabecera "Authorization: Bearer <token>" y responde 401 (unauthorized) a las demás.
+// Los tokens se comparan por su resumen SHA-256 en tiempo constante, para no revelar su contenido ni su longitud.
+func RequireAdminToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	want := sha256.Sum256([]byte(token))
+	return func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		sum := sha256.Sum256([]byte(got))
+		if !ok || subtle.ConstantTimeCompare(sum[:], want[:]) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			writeProblem(w, r, http.StatusUnauthorized, CodeUnauthorized, "Se requiere el token de administración")
+			return
+		}
+		next(w, r)
+	}
+}
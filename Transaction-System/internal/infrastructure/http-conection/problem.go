@@ -0,0 +1,195 @@
+package http_conection
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/hold"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// ProblemContentType es el tipo de contenido de las respuestas de error (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Códigos de error estables que los clientes pueden usar para distinguir cada caso.
+// Forman parte del contrato de la API: no deben cambiarse una vez publicados.
+const (
+	CodeInvalidRequest       = "invalid_request"              // El cuerpo o los parámetros de la solicitud no son válidos
+	CodeValidationFailed     = "validation_failed"            // Uno o más campos de la solicitud no son válidos
+	CodeRequestTooLarge      = "request_too_large"            // El cuerpo de la solicitud supera el tamaño máximo
+	CodeInvalidAmount        = "invalid_amount"               // El monto no es positivo
+	CodeCurrencyMismatch     = "currency_mismatch"            // El monto está en una moneda distinta a la de la cuenta
+	CodeInsufficientFunds    = "insufficient_funds"           // El balance no alcanza para la operación
+	CodeOverdraftExceeded    = "overdraft_limit_exceeded"     // La operación excede el sobregiro autorizado
+	CodeAccountNotFound      = "account_not_found"            // La cuenta no existe
+	CodeAccountFrozen        = "account_frozen"               // La cuenta está congelada
+	CodeAccountClosed        = "account_closed"               // La cuenta está cerrada
+	CodeBalanceNotZero       = "balance_not_zero"             // La cuenta no puede cerrarse con saldo
+	CodeConcurrencyConflict  = "concurrency_conflict"         // La cuenta fue modificada por otras operaciones
+	CodeIdempotencyKeyReused = "idempotency_key_reused"       // La Idempotency-Key ya se usó con otra solicitud
+	CodeRequestInProgress    = "request_in_progress"          // La solicitud original con la misma Idempotency-Key sigue en curso
+	CodeRateNotFound         = "rate_not_found"               // No hay tipo de cambio para el par de monedas
+	CodeQuoteNotFound        = "quote_not_found"              // La cotización de cambio no existe
+	CodeQuoteExpired         = "quote_expired"                // La cotización de cambio venció
+	CodeQuoteMismatch        = "quote_mismatch"               // La transferencia no coincide con la cotización
+	CodeQuoteUsed            = "quote_already_used"           // La cotización ya liquidó otra transferencia
+	CodeAmountTooSmall       = "amount_too_small"             // El monto convertido es cero
+	CodeHoldNotFound         = "hold_not_found"               // La retención de fondos no existe
+	CodeHoldNotActive        = "hold_not_active"              // La retención ya fue capturada, liberada o venció
+	CodeHoldExpired          = "hold_expired"                 // La retención venció y ya no puede capturarse
+	CodeCaptureExceedsHold   = "capture_exceeds_hold"         // El monto a capturar supera el monto retenido
+	CodeTransactionNotFound  = "transaction_not_found"        // La transacción no existe
+	CodeAlreadyReversed      = "transaction_already_reversed" // La transacción ya fue revertida
+	CodeNotReversible        = "transaction_not_reversible"   // El tipo de la transacción no admite reversión
+	CodeUnauthorized         = "unauthorized"                 // Falta el token de administración o no es válido
+	CodeInternal             = "internal_error"               // Error inesperado del servidor
+)
+
+// Problem es el cuerpo de una respuesta de error según RFC 7807 (Problem Details for HTTP APIs),
+// extendido con un código de error estable.
+type Problem struct {
+	Type     string `json:"type"`               // URI que identifica el tipo de problema
+	Title    string `json:"title"`              // Resumen legible del tipo de problema
+	Status   int    `json:"status"`             // Código de estado HTTP
+	Detail   string `json:"detail,omitempty"`   // Explicación de esta ocurrencia concreta
+	Instance string `json:"instance,omitempty"` // Ruta de la solicitud que produjo el problema
+	Code     string `json:"code"`               // Código de error estable para los clientes
+
+	// Errors detalla los campos inválidos cuando Code es "validation_failed"
+	Errors []application.FieldError `json:"errors,omitempty"`
+}
+
+// errorMapping asocia un error del dominio o de la aplicación con su respuesta HTTP.
+type errorMapping struct {
+	target error  // Error buscado con errors.Is
+	status int    // Código de estado HTTP
+	code   string // Código de error estable
+}
+
+// errorMappings se recorre en orden; el primer error que coincida determina la respuesta.
+var errorMappings = []errorMapping{
+	{account.ErrNotFound, http.StatusNotFound, CodeAccountNotFound},
+	{account.ErrInsufficientFunds, http.StatusUnprocessableEntity, CodeInsufficientFunds},
+	{account.ErrOverdraftLimitExceeded, http.StatusUnprocessableEntity, CodeOverdraftExceeded},
+	{account.ErrInvalidAmount, http.StatusUnprocessableEntity, CodeInvalidAmount},
+	{money.ErrCurrencyMismatch, http.StatusUnprocessableEntity, CodeCurrencyMismatch},
+	{account.ErrAccountFrozen, http.StatusConflict, CodeAccountFrozen},
+	{account.ErrAccountClosed, http.StatusConflict, CodeAccountClosed},
+	{account.ErrBalanceNotZero, http.StatusConflict, CodeBalanceNotZero},
+	{account.ErrInvalidStatus, http.StatusBadRequest, CodeInvalidRequest},
+	{application.ErrInvalidTransactionType, http.StatusBadRequest, CodeInvalidRequest},
+	{application.ErrSameAccount, http.StatusUnprocessableEntity, CodeInvalidRequest},
+	{transaction.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidRequest},
+	{fx.ErrRateNotFound, http.StatusUnprocessableEntity, CodeRateNotFound},
+	{fx.ErrQuoteNotFound, http.StatusNotFound, CodeQuoteNotFound},
+	{fx.ErrQuoteExpired, http.StatusUnprocessableEntity, CodeQuoteExpired},
+	{fx.ErrQuoteMismatch, http.StatusUnprocessableEntity, CodeQuoteMismatch},
+	{fx.ErrQuoteUsed, http.StatusConflict, CodeQuoteUsed},
+	{fx.ErrAmountTooSmall, http.StatusUnprocessableEntity, CodeAmountTooSmall},
+	{hold.ErrNotFound, http.StatusNotFound, CodeHoldNotFound},
+	{hold.ErrExpired, http.StatusUnprocessableEntity, CodeHoldExpired},
+	{hold.ErrNotActive, http.StatusConflict, CodeHoldNotActive},
+	{hold.ErrCaptureExceedsHold, http.StatusUnprocessableEntity, CodeCaptureExceedsHold},
+	{transaction.ErrNotFound, http.StatusNotFound, CodeTransactionNotFound},
+	{transaction.ErrAlreadyReversed, http.StatusConflict, CodeAlreadyReversed},
+	{transaction.ErrNotReversible, http.StatusUnprocessableEntity, CodeNotReversible},
+}
+
+// problemTitles contiene el título de cada código de error.
+var problemTitles = map[string]string{
+	CodeInvalidRequest:       "Solicitud inválida",
+	CodeValidationFailed:     "Error de validación",
+	CodeRequestTooLarge:      "Solicitud demasiado grande",
+	CodeInvalidAmount:        "Monto inválido",
+	CodeCurrencyMismatch:     "Moneda distinta a la de la cuenta",
+	CodeInsufficientFunds:    "Fondos insuficientes",
+	CodeOverdraftExceeded:    "Sobregiro autorizado excedido",
+	CodeAccountNotFound:      "Cuenta no encontrada",
+	CodeAccountFrozen:        "Cuenta congelada",
+	CodeAccountClosed:        "Cuenta cerrada",
+	CodeBalanceNotZero:       "La cuenta tiene saldo",
+	CodeConcurrencyConflict:  "Conflicto de concurrencia",
+	CodeIdempotencyKeyReused: "Idempotency-Key reutilizada",
+	CodeRequestInProgress:    "Solicitud en curso",
+	CodeRateNotFound:         "Tipo de cambio no disponible",
+	CodeQuoteNotFound:        "Cotización no encontrada",
+	CodeQuoteExpired:         "Cotización vencida",
+	CodeQuoteMismatch:        "La operación no coincide con la cotización",
+	CodeQuoteUsed:            "Cotización ya utilizada",
+	CodeAmountTooSmall:       "Monto demasiado pequeño para convertirlo",
+	CodeHoldNotFound:         "Retención no encontrada",
+	CodeHoldNotActive:        "La retención ya no está activa",
+	CodeHoldExpired:          "Retención vencida",
+	CodeCaptureExceedsHold:   "Captura mayor al monto retenido",
+	CodeTransactionNotFound:  "Transacción no encontrada",
+	CodeAlreadyReversed:      "Transacción ya revertida",
+	CodeNotReversible:        "La transacción no admite reversión",
+	CodeUnauthorized:         "No autorizado",
+	CodeInternal:             "Error interno del servidor",
+}
+
+// writeProblem escribe una respuesta de error en formato application/problem+json.
+// Parámetros:
+// - w, r: la respuesta y la solicitud HTTP en curso.
+// - status: código de estado HTTP.
+// - code: código de error estable (una de las constantes Code*).
+// - detail: explicación de esta ocurrencia concreta; puede estar vacía.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	writeProblemBody(w, newProblem(r, status, code, detail))
+}
+
+// newProblem construye el cuerpo de un problema con el tipo y el título que corresponden al código.
+func newProblem(r *http.Request, status int, code, detail string) Problem {
+	return Problem{
+		Type:     "/problems/" + code,
+		Title:    problemTitles[code],
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Code:     code,
+	}
+}
+
+// writeProblemBody envía el problema al cliente con el tipo de contenido de RFC 7807.
+func writeProblemBody(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// writeError traduce un error devuelto por los servicios a una respuesta RFC 7807.
+// Los errores de validación se responden con 422 y el detalle de cada campo, y los errores conocidos
+// del dominio con su código 4xx; un conflicto de concurrencia que persistió tras los reintentos
+// responde 409. Cualquier otro error se registra en el log y se responde con un 500 genérico,
+// sin exponer detalles internos (por ejemplo, errores del driver SQL).
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var validation *application.ValidationError
+	if errors.As(err, &validation) {
+		p := newProblem(r, http.StatusUnprocessableEntity, CodeValidationFailed, "La solicitud contiene campos inválidos")
+		p.Errors = validation.Errors
+		writeProblemBody(w, p)
+		return
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.target) {
+			writeProblem(w, r, m.status, m.code, err.Error())
+			return
+		}
+	}
+
+	var conflict *application.ConflictError
+	if errors.As(err, &conflict) {
+		writeProblem(w, r, http.StatusConflict, CodeConcurrencyConflict, err.Error())
+		return
+	}
+
+	slog.ErrorContext(r.Context(), "error interno", "method", r.Method, "path", r.URL.Path, "error", err)
+	writeProblem(w, r, http.StatusInternalServerError, CodeInternal, "Ocurrió un error inesperado al procesar la solicitud")
+}
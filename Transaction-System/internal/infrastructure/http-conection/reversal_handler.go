@@ -0,0 +1,25 @@
+package http_conection
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// ReverseHandler revierte una transacción con una transacción "reversal" enlazada a la original, que
+// mueve el mismo monto en sentido contrario. Una transacción sólo puede revertirse una vez; revertir un
+// depósito aplica las reglas de fondos de un retiro.
+// Ruta: POST /transactions/{id}/reverse
+func (h *AccountHandler) ReverseHandler(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || transactionID <= 0 {
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "ID de transacción inválido")
+		return
+	}
+
+	reversal, err := h.service.Reverse(r.Context(), transactionID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newTransactionResponse(reversal))
+}
@@ -0,0 +1,93 @@
+// Package logging configura el logging estructurado del servicio con log/slog.
+// El identificador de cada solicitud viaja en el context.Context; el manejador de este paquete
+// lo agrega como atributo request_id a todo registro emitido con las variantes *Context de slog
+// (slog.InfoContext, slog.ErrorContext, ...), en cualquier capa que reciba el contexto.
+package logging
+
+import (
+	"context"      // Transporte del identificador de solicitud
+	"crypto/rand"  // Generación de identificadores aleatorios
+	"encoding/hex" // Codificación de los identificadores generados
+	"fmt"          // Paquete para formatear errores
+	"io"           // Destino de los registros
+	"log/slog"     // Logging estructurado
+	"strings"      // Normalización del formato
+)
+
+// Formatos de salida admitidos.
+const (
+	FormatJSON = "json" // Una línea JSON por registro
+	FormatText = "text" // Pares clave=valor, más cómodo para desarrollo local
+)
+
+// RequestIDKey es el nombre del atributo con el identificador de la solicitud.
+const RequestIDKey = "request_id"
+
+// requestIDContextKey es la clave privada del identificador de solicitud en el contexto.
+type requestIDContextKey struct{}
+
+// WithRequestID devuelve un contexto que transporta el identificador de la solicitud.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestID devuelve el identificador de la solicitud del contexto, o una cadena vacía si no tiene.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// NewRequestID genera un identificador de solicitud aleatorio de 128 bits en hexadecimal.
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// ParseLevel interpreta un nivel de log ("debug", "info", "warn" o "error").
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return level, fmt.Errorf("nivel de log inválido: %q", s)
+	}
+	return level, nil
+}
+
+// NewLogger crea un logger que escribe en w con el formato y el nivel indicados
+// y agrega el identificador de la solicitud tomado del contexto.
+func NewLogger(w io.Writer, format string, level slog.Level) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case FormatJSON, "":
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("formato de log inválido: %q", format)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// contextHandler envuelve un slog.Handler para agregar a cada registro los atributos del contexto.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle agrega el identificador de la solicitud, si el contexto lo tiene, y delega el registro.
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs conserva el envoltorio al derivar un logger con atributos fijos.
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup conserva el envoltorio al derivar un logger con un grupo.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
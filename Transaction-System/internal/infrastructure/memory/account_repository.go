@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"Transaction-System/internal/domain/account"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// AccountRepository es una implementación en memoria de account.Repository.
+// Es segura para uso concurrente y se comporta como la tabla 'accounts' de MySQL:
+// IDs autoincrementales, números de cuenta únicos y actualizaciones condicionadas a la versión.
+// Guarda y devuelve copias, de modo que modificar una cuenta leída no altera la almacenada.
+type AccountRepository struct {
+	mu       sync.RWMutex            // Protege el acceso a las cuentas
+	accounts map[int]account.Account // Cuentas indexadas por ID
+	numbers  map[string]int          // ID de cada número de cuenta, para garantizar su unicidad
+	ids      sequence                // Generador de IDs
+}
+
+// Asegurar que AccountRepository implementa la interfaz account.Repository.
+var _ account.Repository = &AccountRepository{}
+
+// NewAccountRepository crea un repositorio de cuentas vacío.
+func NewAccountRepository() *AccountRepository {
+	return &AccountRepository{accounts: make(map[int]account.Account), numbers: make(map[string]int)}
+}
+
+// Save guarda una cuenta nueva. Si la cuenta no tiene ID se le asigna el siguiente;
+// si lo tiene (por ejemplo, al precargar datos) se respeta, como un valor explícito en una columna AUTO_INCREMENT.
+// Retorna un error si el ID o el número de cuenta ya existen.
+func (r *AccountRepository) Save(_ context.Context, a *account.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if a.ID != 0 {
+		if _, exists := r.accounts[a.ID]; exists {
+			return fmt.Errorf("la cuenta %d ya existe", a.ID)
+		}
+	}
+	if id, exists := r.numbers[a.AccountNumber]; exists {
+		return fmt.Errorf("el número de cuenta %s ya pertenece a la cuenta %d", a.AccountNumber, id)
+	}
+
+	// Las cuentas sin estado explícito se guardan como activas
+	if a.Status == "" {
+		a.Status = account.StatusActive
+	}
+	if a.ID == 0 {
+		a.ID = r.ids.next()
+	} else {
+		r.ids.observe(a.ID)
+	}
+	r.accounts[a.ID] = *a
+	r.numbers[a.AccountNumber] = a.ID
+	return nil
+}
+
+// Update actualiza el balance, los fondos retenidos, el sobregiro y el estado de una cuenta si su versión guardada coincide con a.Version,
+// e incrementa la versión. Igual que en MySQL, una cuenta inexistente también se informa como conflicto.
+func (r *AccountRepository) Update(_ context.Context, a *account.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.accounts[a.ID]
+	if !exists || stored.Version != a.Version {
+		return fmt.Errorf("cuenta %d (versión %d): %w", a.ID, a.Version, account.ErrVersionConflict)
+	}
+	stored.Balance = a.Balance
+	stored.Held = a.Held
+	stored.Overdraft = a.Overdraft
+	stored.InterestAccruedOn = a.InterestAccruedOn
+	stored.Status = a.Status
+	stored.Version++
+	r.accounts[a.ID] = stored
+	a.Version++
+	return nil
+}
+
+// FindByID devuelve una copia de la cuenta con el ID indicado.
+func (r *AccountRepository) FindByID(_ context.Context, id int) (*account.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, exists := r.accounts[id]
+	if !exists {
+		return nil, fmt.Errorf("cuenta %d: %w", id, account.ErrNotFound)
+	}
+	return &a, nil
+}
+
+// FindByIDForUpdate equivale a FindByID: la memoria no bloquea cuentas y las escrituras concurrentes
+// se detectan en Update mediante la versión.
+func (r *AccountRepository) FindByIDForUpdate(ctx context.Context, id int) (*account.Account, error) {
+	return r.FindByID(ctx, id)
+}
+
+// List devuelve copias de las cuentas que cumplen el filtro, ordenadas por ID, y el total sin paginar.
+func (r *AccountRepository) List(_ context.Context, filter account.ListFilter) ([]*account.Account, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*account.Account
+	for _, a := range r.accounts {
+		if (filter.Status == "" || a.Status == filter.Status) && (!filter.Overdrawn || a.Balance.IsNegative()) {
+			cp := a
+			matched = append(matched, &cp)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *account.Account) int { return a.ID - b.ID })
+
+	total := len(matched)
+	if filter.Offset >= total {
+		return nil, total, nil
+	}
+	return matched[filter.Offset:min(filter.Offset+filter.Limit, total)], total, nil
+}
+
+// allocateID reserva el próximo ID sin guardar la cuenta; la unidad de trabajo lo usa para
+// asignar el ID al crear la cuenta y guardarla recién al confirmar.
+func (r *AccountRepository) allocateID() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ids.next()
+}
+
+// remove elimina una cuenta; la unidad de trabajo lo usa para deshacer una cuenta nueva
+// si no pudo confirmar el resto de los cambios.
+func (r *AccountRepository) remove(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, exists := r.accounts[id]; exists {
+		delete(r.numbers, a.AccountNumber)
+		delete(r.accounts, id)
+	}
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"Transaction-System/internal/domain/fx"
+	"context"
+	"sync"
+)
+
+// RateRepository es una implementación en memoria de fx.RateStore.
+// Es segura para uso concurrente.
+type RateRepository struct {
+	mu    sync.RWMutex        // Protege el acceso a los tipos de cambio
+	rates map[fx.Pair]fx.Rate // Tipos de cambio fijados, por par
+}
+
+// Asegurar que RateRepository implementa la interfaz fx.RateStore.
+var _ fx.RateStore = &RateRepository{}
+
+// NewRateRepository crea un repositorio de tipos de cambio vacío.
+func NewRateRepository() *RateRepository {
+	return &RateRepository{rates: make(map[fx.Pair]fx.Rate)}
+}
+
+// Rate devuelve el tipo de cambio fijado para el par.
+func (r *RateRepository) Rate(_ context.Context, pair fx.Pair) (fx.Rate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rate, exists := r.rates[pair]
+	if !exists {
+		return fx.Rate{}, fx.ErrRateNotFound
+	}
+	return rate, nil
+}
+
+// Rates devuelve todos los tipos de cambio fijados, ordenados por par.
+func (r *RateRepository) Rates(context.Context) ([]fx.Rate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rates := make([]fx.Rate, 0, len(r.rates))
+	for _, rate := range r.rates {
+		rates = append(rates, rate)
+	}
+	fx.SortRates(rates)
+	return rates, nil
+}
+
+// Set guarda el tipo de cambio del par, reemplazando el anterior si existía.
+func (r *RateRepository) Set(_ context.Context, rate fx.Rate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rate.Source = fx.SourceManual
+	r.rates[rate.Pair] = rate
+	return nil
+}
+
+// Delete elimina el tipo de cambio fijado para el par.
+func (r *RateRepository) Delete(_ context.Context, pair fx.Pair) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.rates[pair]; !exists {
+		return fx.ErrRateNotFound
+	}
+	delete(r.rates, pair)
+	return nil
+}
+
+// QuoteRepository es una implementación en memoria de fx.QuoteRepository.
+// Es segura para uso concurrente.
+type QuoteRepository struct {
+	mu     sync.RWMutex         // Protege el acceso a las cotizaciones
+	quotes map[string]*fx.Quote // Cotizaciones indexadas por ID
+}
+
+// Asegurar que QuoteRepository implementa la interfaz fx.QuoteRepository.
+var _ fx.QuoteRepository = &QuoteRepository{}
+
+// NewQuoteRepository crea un repositorio de cotizaciones vacío.
+func NewQuoteRepository() *QuoteRepository {
+	return &QuoteRepository{quotes: make(map[string]*fx.Quote)}
+}
+
+// Save guarda una copia de la cotización.
+func (r *QuoteRepository) Save(_ context.Context, q *fx.Quote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *q
+	r.quotes[q.ID] = &cp
+	return nil
+}
+
+// FindByID devuelve una copia de la cotización.
+func (r *QuoteRepository) FindByID(_ context.Context, id string) (*fx.Quote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	q, exists := r.quotes[id]
+	if !exists {
+		return nil, fx.ErrQuoteNotFound
+	}
+	cp := *q
+	return &cp, nil
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"Transaction-System/internal/domain/hold"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HoldRepository es una implementación en memoria de hold.Repository.
+// Es segura para uso concurrente.
+type HoldRepository struct {
+	mu    sync.RWMutex          // Protege el acceso a las retenciones
+	holds map[string]*hold.Hold // Retenciones indexadas por ID
+}
+
+// Asegurar que HoldRepository implementa la interfaz hold.Repository.
+var _ hold.Repository = &HoldRepository{}
+
+// NewHoldRepository crea un repositorio de retenciones vacío.
+func NewHoldRepository() *HoldRepository {
+	return &HoldRepository{holds: make(map[string]*hold.Hold)}
+}
+
+// Save guarda una copia de la retención.
+func (r *HoldRepository) Save(_ context.Context, h *hold.Hold) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *h
+	r.holds[h.ID] = &cp
+	return nil
+}
+
+// Update reemplaza la retención guardada por una copia de la indicada.
+func (r *HoldRepository) Update(_ context.Context, h *hold.Hold) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.holds[h.ID]; !exists {
+		return hold.ErrNotFound
+	}
+	cp := *h
+	r.holds[h.ID] = &cp
+	return nil
+}
+
+// remove elimina una retención; la unidad de trabajo lo usa para deshacer una retención nueva
+// si no pudo confirmar el resto de los cambios.
+func (r *HoldRepository) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.holds, id)
+}
+
+// FindByID devuelve una copia de la retención.
+func (r *HoldRepository) FindByID(_ context.Context, id string) (*hold.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, exists := r.holds[id]
+	if !exists {
+		return nil, hold.ErrNotFound
+	}
+	cp := *h
+	return &cp, nil
+}
+
+// FindByIDForUpdate equivale a FindByID: la memoria no bloquea retenciones, la unidad de trabajo
+// serializa las confirmaciones.
+func (r *HoldRepository) FindByIDForUpdate(ctx context.Context, id string) (*hold.Hold, error) {
+	return r.FindByID(ctx, id)
+}
+
+// FindExpired devuelve copias de las retenciones activas vencidas en now, de la que venció primero a la última.
+func (r *HoldRepository) FindExpired(_ context.Context, now time.Time, limit int) ([]*hold.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var expired []*hold.Hold
+	for _, h := range r.holds {
+		if h.Expired(now) {
+			cp := *h
+			expired = append(expired, &cp)
+		}
+	}
+	sortByExpiry(expired)
+	return expired[:min(limit, len(expired))], nil
+}
+
+// sortByExpiry ordena las retenciones por fecha de vencimiento y, a igual fecha, por ID.
+func sortByExpiry(holds []*hold.Hold) {
+	slices.SortFunc(holds, func(a, b *hold.Hold) int {
+		if c := a.ExpiresAt.Compare(b.ExpiresAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
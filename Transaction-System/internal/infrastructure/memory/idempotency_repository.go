@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"Transaction-System/internal/domain/idempotency"
+	"context"
+	"sync"
+	"time"
+)
+
+// IdempotencyRepository es una implementación en memoria de idempotency.Repository.
+// Es segura para uso concurrente.
+type IdempotencyRepository struct {
+	mu      sync.Mutex                     // Protege el acceso a los registros
+	records map[string]*idempotency.Record // Registros indexados por clave
+}
+
+// Asegurar que IdempotencyRepository implementa la interfaz idempotency.Repository.
+var _ idempotency.Repository = &IdempotencyRepository{}
+
+// NewIdempotencyRepository crea un repositorio de claves de idempotencia vacío.
+func NewIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{records: make(map[string]*idempotency.Record)}
+}
+
+// Reserve registra la clave si aún no existe.
+func (r *IdempotencyRepository) Reserve(_ context.Context, rec *idempotency.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.records[rec.Key]; exists {
+		return idempotency.ErrKeyExists
+	}
+	cp := *rec
+	r.records[rec.Key] = &cp
+	return nil
+}
+
+// Find devuelve una copia del registro asociado a la clave.
+func (r *IdempotencyRepository) Find(_ context.Context, key string) (*idempotency.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, exists := r.records[key]
+	if !exists {
+		return nil, idempotency.ErrNotFound
+	}
+	cp := *rec
+	return &cp, nil
+}
+
+// Complete guarda la respuesta asociada a la clave.
+func (r *IdempotencyRepository) Complete(_ context.Context, rec *idempotency.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.records[rec.Key]; !exists {
+		return idempotency.ErrNotFound
+	}
+	cp := *rec
+	r.records[rec.Key] = &cp
+	return nil
+}
+
+// Delete elimina la clave.
+func (r *IdempotencyRepository) Delete(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, key)
+	return nil
+}
+
+// DeleteExpired elimina las claves expiradas en el instante indicado.
+func (r *IdempotencyRepository) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for key, rec := range r.records {
+		if rec.Expired(now) {
+			delete(r.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
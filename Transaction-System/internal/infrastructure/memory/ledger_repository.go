@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"Transaction-System/internal/domain/ledger"
+	"Transaction-System/internal/domain/money"
+	"context"
+	"sync"
+)
+
+// LedgerRepository es una implementación en memoria de ledger.Repository.
+// Es segura para uso concurrente.
+type LedgerRepository struct {
+	mu      sync.RWMutex           // Protege el acceso a los asientos
+	entries []*ledger.JournalEntry // Asientos guardados, en orden de inserción
+}
+
+// Asegurar que LedgerRepository implementa la interfaz ledger.Repository.
+var _ ledger.Repository = &LedgerRepository{}
+
+// NewLedgerRepository crea un libro mayor vacío en memoria.
+func NewLedgerRepository() *LedgerRepository {
+	return &LedgerRepository{}
+}
+
+// Append valida y guarda un asiento contable, asignándole un ID incremental.
+func (r *LedgerRepository) Append(_ context.Context, e *ledger.JournalEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.ID = len(r.entries) + 1
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+// truncate descarta los asientos posteriores a los n primeros; la unidad de trabajo lo usa para deshacer
+// los asientos guardados si no pudo confirmar el resto de los cambios.
+func (r *LedgerRepository) truncate(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n < len(r.entries) {
+		r.entries = r.entries[:n]
+	}
+}
+
+// Sum devuelve la suma y la cantidad de movimientos de una cuenta contable en la moneda indicada.
+func (r *LedgerRepository) Sum(_ context.Context, accountCode string, currency string) (money.Money, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sumPostings(r.entries, accountCode, currency)
+}
+
+// TrialBalance devuelve la suma de todos los movimientos agrupada por moneda.
+func (r *LedgerRepository) TrialBalance(_ context.Context) (map[string]money.Money, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totals := make(map[string]money.Money)
+	for _, e := range r.entries {
+		for _, p := range e.Postings {
+			total, ok := totals[p.Amount.Currency()]
+			if !ok {
+				total = money.Zero(p.Amount.Currency())
+			}
+			total, err := total.Add(p.Amount)
+			if err != nil {
+				return nil, err
+			}
+			totals[p.Amount.Currency()] = total
+		}
+	}
+	return totals, nil
+}
+
+// Entries devuelve una copia de los asientos guardados, en orden de inserción.
+func (r *LedgerRepository) Entries() []*ledger.JournalEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*ledger.JournalEntry(nil), r.entries...)
+}
+
+// sumPostings suma los movimientos de una cuenta contable en la moneda indicada.
+func sumPostings(entries []*ledger.JournalEntry, accountCode string, currency string) (money.Money, int, error) {
+	sum := money.Zero(currency)
+	count := 0
+	for _, e := range entries {
+		for _, p := range e.Postings {
+			if p.Account.Code != accountCode || p.Amount.Currency() != sum.Currency() {
+				continue
+			}
+			var err error
+			if sum, err = sum.Add(p.Amount); err != nil {
+				return money.Money{}, 0, err
+			}
+			count++
+		}
+	}
+	return sum, count, nil
+}
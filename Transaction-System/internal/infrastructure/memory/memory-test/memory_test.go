@@ -0,0 +1,297 @@
+package memory_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"Transaction-System/internal/infrastructure/memory"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Prueba que el repositorio de cuentas guarda copias, rechaza duplicados y aplica la versión en Update
+func TestAccountRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAccountRepository()
+
+	a := &account.Account{AccountNumber: "ACC0001", Balance: money.MustParse("10.00", money.DefaultCurrency)}
+	if err := repo.Save(ctx, a); err != nil {
+		t.Fatalf("Error al guardar la cuenta: %v", err)
+	}
+	if a.ID != 1 || a.Status != account.StatusActive {
+		t.Fatalf("Se esperaba el ID 1 y el estado activo, obtenido %d/%s", a.ID, a.Status)
+	}
+	if err := repo.Save(ctx, &account.Account{AccountNumber: "ACC0001"}); err == nil {
+		t.Error("Se esperaba un error al repetir el número de cuenta")
+	}
+
+	// Modificar la copia leída no altera la cuenta guardada
+	read, _ := repo.FindByID(ctx, 1)
+	read.Balance = money.MustParse("99.00", money.DefaultCurrency)
+	if again, _ := repo.FindByID(ctx, 1); again.Balance != a.Balance {
+		t.Errorf("La cuenta guardada cambió sin Update: %v", again.Balance)
+	}
+
+	// La primera actualización avanza la versión; repetirla con la versión vieja es un conflicto
+	stale := *read
+	if err := repo.Update(ctx, read); err != nil || read.Version != 1 {
+		t.Fatalf("Error al actualizar: %v (versión %d)", err, read.Version)
+	}
+	if err := repo.Update(ctx, &stale); !errors.Is(err, account.ErrVersionConflict) {
+		t.Errorf("Se esperaba ErrVersionConflict, obtenido %v", err)
+	}
+	if _, err := repo.FindByID(ctx, 2); !errors.Is(err, account.ErrNotFound) {
+		t.Errorf("Se esperaba ErrNotFound, obtenido %v", err)
+	}
+}
+
+// Prueba que el listado filtra por estado, ordena por ID y pagina
+func TestAccountRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAccountRepository()
+	for i, status := range []account.Status{account.StatusActive, account.StatusFrozen, account.StatusActive, account.StatusActive} {
+		repo.Save(ctx, &account.Account{ID: 4 - i, AccountNumber: string(rune('A' + i)), Status: status})
+	}
+
+	accounts, total, err := repo.List(ctx, account.ListFilter{Status: account.StatusActive, Offset: 1, Limit: 5})
+	if err != nil {
+		t.Fatalf("Error al listar: %v", err)
+	}
+	if total != 3 || len(accounts) != 2 || accounts[0].ID != 2 || accounts[1].ID != 4 {
+		t.Errorf("Listado incorrecto: total %d, %d cuentas", total, len(accounts))
+	}
+}
+
+// Prueba que el historial aplica el filtro, el orden y el límite
+func TestTransactionRepository_FindByAccount(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewTransactionRepository()
+	for _, typ := range []string{"deposit", "withdrawal", "deposit", "deposit"} {
+		repo.Save(ctx, &transaction.Transaction{AccountID: 1, TransactionType: typ, Amount: money.MustParse("1.00", money.DefaultCurrency)})
+	}
+	repo.Save(ctx, &transaction.Transaction{AccountID: 2, TransactionType: "deposit"})
+
+	found, err := repo.FindByAccount(ctx, 1, transaction.Filter{Types: []string{"deposit"}, Limit: 2})
+	if err != nil {
+		t.Fatalf("Error al consultar el historial: %v", err)
+	}
+	if len(found) != 2 || found[0].ID != 4 || found[1].ID != 3 {
+		t.Errorf("Historial incorrecto: %+v", found)
+	}
+}
+
+// Prueba que, como el índice único de SQL, el repositorio admite una sola pata de cada tipo por cotización
+func TestTransactionRepository_QuoteUsedOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewTransactionRepository()
+	leg := func(accountID int, typ string) *transaction.Transaction {
+		tr := transaction.New(accountID, money.MustParse("10.00", money.DefaultCurrency), typ)
+		tr.Conversion = &transaction.Conversion{QuoteID: "cotizacion-1", Rate: big.NewRat(1, 1), CounterAmount: money.MustParse("9.00", "EUR")}
+		return tr
+	}
+
+	if err := repo.Save(ctx, leg(1, transaction.TypeTransferOut)); err != nil {
+		t.Fatalf("Error al guardar la pata de débito: %v", err)
+	}
+	if err := repo.Save(ctx, leg(2, transaction.TypeTransferIn)); err != nil {
+		t.Fatalf("Error al guardar la pata de crédito: %v", err)
+	}
+	if err := repo.Save(ctx, leg(3, transaction.TypeTransferOut)); !errors.Is(err, fx.ErrQuoteUsed) {
+		t.Errorf("Se esperaba ErrQuoteUsed al repetir la pata de débito, obtenido %v", err)
+	}
+	if found, _ := repo.FindByAccount(ctx, 3, transaction.Filter{Limit: 10}); len(found) != 0 {
+		t.Errorf("La pata rechazada no debería guardarse, obtenidas %d", len(found))
+	}
+}
+
+// Prueba que dos unidades de trabajo sobre los mismos repositorios no liquidan la misma cotización
+// en transferencias concurrentes entre cuentas distintas
+func TestUnitOfWork_ConcurrentQuoteTransfers(t *testing.T) {
+	ctx := context.Background()
+	accounts := memory.NewAccountRepository()
+	transactions := memory.NewTransactionRepository()
+	for i, balance := range []money.Money{
+		money.MustParse("100.00", "USD"), money.Zero("EUR"), money.MustParse("100.00", "USD"), money.Zero("EUR"),
+	} {
+		if err := accounts.Save(ctx, &account.Account{AccountNumber: fmt.Sprintf("ACC%04d", i+1), Balance: balance}); err != nil {
+			t.Fatalf("No se pudo precargar la cuenta: %v", err)
+		}
+	}
+	rate, _ := fx.NewRate(fx.Pair{Base: "USD", Quote: "EUR"}, "0.9", fx.SourceManual, time.Now())
+	quote, err := fx.NewQuote(rate, money.MustParse("100.00", "USD"), new(big.Rat), time.Minute, time.Now())
+	if err != nil {
+		t.Fatalf("Error al cotizar: %v", err)
+	}
+	quotes := memory.NewQuoteRepository()
+	quotes.Save(ctx, quote)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, pair := range [][2]int{{1, 2}, {3, 4}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			service := application.NewTransactionService(memory.NewUnitOfWork(accounts, transactions), application.WithQuotes(quotes))
+			_, errs[i] = service.TransferWithQuote(ctx, pair[0], pair[1], money.MustParse("100.00", "USD"), quote.ID)
+		}()
+	}
+	wg.Wait()
+
+	if (errs[0] == nil) == (errs[1] == nil) {
+		t.Fatalf("Sólo una transferencia debería liquidar la cotización: %v / %v", errs[0], errs[1])
+	}
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, fx.ErrQuoteUsed) {
+			t.Errorf("Se esperaba ErrQuoteUsed, obtenido %v", err)
+		}
+	}
+	debited := 0
+	for _, id := range []int{1, 3} {
+		if acc, _ := accounts.FindByID(ctx, id); acc.Balance.IsZero() {
+			debited++
+		}
+	}
+	if debited != 1 {
+		t.Errorf("Se esperaba una sola cuenta debitada, obtenidas %d", debited)
+	}
+}
+
+// Prueba que una unidad de trabajo que falla no deja la cuenta creada ni consume su número
+func TestUnitOfWork_RollbackDiscardsNewAccount(t *testing.T) {
+	ctx := context.Background()
+	accounts := memory.NewAccountRepository()
+	uow := memory.NewUnitOfWork(accounts, memory.NewTransactionRepository())
+
+	failure := errors.New("falla simulada")
+	err := uow.Execute(ctx, func(repos application.Repositories) error {
+		if err := repos.Accounts.Save(ctx, &account.Account{AccountNumber: "ACC0001"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Se esperaba la falla simulada, obtenido %v", err)
+	}
+	if _, total, _ := accounts.List(ctx, account.ListFilter{Limit: 10}); total != 0 {
+		t.Errorf("No se esperaban cuentas guardadas, obtenidas %d", total)
+	}
+	if err := accounts.Save(ctx, &account.Account{AccountNumber: "ACC0001"}); err != nil {
+		t.Errorf("El número de cuenta debería seguir disponible: %v", err)
+	}
+}
+
+// failingTransactions es un repositorio de transacciones en memoria que guarda las primeras ok transacciones
+// y falla en las siguientes, para simular una escritura que falla a mitad de la confirmación.
+type failingTransactions struct {
+	*memory.TransactionRepository
+	ok int // Transacciones que se guardan antes de fallar
+}
+
+// Save guarda la transacción mientras queden escrituras permitidas; después devuelve un error.
+func (r *failingTransactions) Save(ctx context.Context, t *transaction.Transaction) error {
+	if r.ok == 0 {
+		return errors.New("falla simulada al guardar la transacción")
+	}
+	r.ok--
+	return r.TransactionRepository.Save(ctx, t)
+}
+
+// Prueba que si una escritura falla a mitad de la confirmación se deshacen también las anteriores:
+// ni los balances, ni la pata ya guardada, ni los asientos quedan en los repositorios
+func TestUnitOfWork_CommitFailureUndoesEveryStore(t *testing.T) {
+	ctx := context.Background()
+	accounts := memory.NewAccountRepository()
+	for _, number := range []string{"ACC0001", "ACC0002"} {
+		if err := accounts.Save(ctx, &account.Account{AccountNumber: number, Balance: money.MustParse("100.00", money.DefaultCurrency)}); err != nil {
+			t.Fatalf("No se pudo precargar la cuenta: %v", err)
+		}
+	}
+	transactions := &failingTransactions{TransactionRepository: memory.NewTransactionRepository(), ok: 1}
+	uow := memory.NewUnitOfWork(accounts, transactions)
+	service := application.NewTransactionService(uow)
+
+	// La pata de débito se guarda y la de crédito falla
+	if _, err := service.Transfer(ctx, 1, 2, money.MustParse("30.00", money.DefaultCurrency)); err == nil {
+		t.Fatal("Se esperaba un error al guardar la segunda pata")
+	}
+	for _, id := range []int{1, 2} {
+		if acc, _ := accounts.FindByID(ctx, id); acc.Balance != money.MustParse("100.00", money.DefaultCurrency) {
+			t.Errorf("El balance de la cuenta %d no debería cambiar, obtenido %v", id, acc.Balance)
+		}
+		if found, _ := transactions.FindByAccount(ctx, id, transaction.Filter{Limit: 10}); len(found) != 0 {
+			t.Errorf("No deberían quedar transacciones de la cuenta %d, obtenidas %d", id, len(found))
+		}
+	}
+	if entries := uow.Ledger().Entries(); len(entries) != 0 {
+		t.Errorf("No deberían quedar asientos contables, obtenidos %d", len(entries))
+	}
+
+	// Con el repositorio sano, la misma transferencia se confirma y cuadra con el libro mayor
+	transactions.ok = 2
+	if _, err := service.Transfer(ctx, 1, 2, money.MustParse("30.00", money.DefaultCurrency)); err != nil {
+		t.Fatalf("Error en la transferencia: %v", err)
+	}
+	ledgerService := application.NewLedgerService(uow)
+	for _, id := range []int{1, 2} {
+		if v, err := ledgerService.VerifyAccount(ctx, id); err != nil || !v.Balanced {
+			t.Errorf("La cuenta %d debería cuadrar con el libro mayor: %v %+v", id, err, v)
+		}
+	}
+}
+
+// Prueba que el backend en memoria soporta solicitudes concurrentes sin perder actualizaciones
+// y manteniendo el libro mayor balanceado. Ejecutar con -race para detectar accesos sin sincronizar.
+func TestBackend_ConcurrentTransfers(t *testing.T) {
+	ctx := context.Background()
+	accounts := memory.NewAccountRepository()
+	transactions := memory.NewTransactionRepository()
+	uow := memory.NewUnitOfWork(accounts, transactions)
+	accountService := application.NewAccountService(uow)
+	transactionService := application.NewTransactionService(uow)
+
+	from, err := accountService.Open(ctx, money.MustParse("100.00", money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta de origen: %v", err)
+	}
+	to, err := accountService.Open(ctx, money.Zero(money.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Error al abrir la cuenta de destino: %v", err)
+	}
+
+	// 50 transferencias de 1.00 y 50 lecturas del historial en paralelo
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := transactionService.Transfer(ctx, from.ID, to.ID, money.MustParse("1.00", money.DefaultCurrency)); err != nil {
+				t.Errorf("Error en la transferencia: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			transactions.FindByAccount(ctx, to.ID, transaction.Filter{Limit: 10})
+		}()
+	}
+	wg.Wait()
+
+	for id, want := range map[int]string{from.ID: "50.00", to.ID: "50.00"} {
+		acc, _ := accounts.FindByID(ctx, id)
+		if acc.Balance != money.MustParse(want, money.DefaultCurrency) {
+			t.Errorf("Balance de la cuenta %d incorrecto, esperado %s, obtenido %v", id, want, acc.Balance)
+		}
+	}
+	if found, _ := transactions.FindByAccount(ctx, to.ID, transaction.Filter{Limit: 100}); len(found) != 50 {
+		t.Errorf("Se esperaban 50 transacciones en la cuenta de destino, obtenidas %d", len(found))
+	}
+	if _, balanced, err := application.NewLedgerService(uow).VerifyJournal(ctx); err != nil || !balanced {
+		t.Errorf("El libro mayor debería estar balanceado: %v", err)
+	}
+}
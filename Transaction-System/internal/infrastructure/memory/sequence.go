@@ -0,0 +1,25 @@
+package memory
+
+// sequence genera IDs incrementales como una columna AUTO_INCREMENT.
+// No es segura para uso concurrente: la protege el mutex del repositorio que la contiene.
+type sequence struct {
+	last int // Último ID asignado u observado
+}
+
+// next devuelve el siguiente ID.
+func (s *sequence) next() int {
+	s.last++
+	return s.last
+}
+
+// observe registra un ID asignado explícitamente, para que next no lo repita.
+func (s *sequence) observe(id int) {
+	s.last = max(s.last, id)
+}
+
+// idAllocator lo implementan los repositorios de este paquete: permite a la unidad de trabajo
+// reservar el ID de una fila nueva antes de guardarla, como ocurre con un INSERT dentro de una
+// transacción de base de datos. Un ID reservado en una ejecución que se revierte no se reutiliza.
+type idAllocator interface {
+	allocateID() int
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"Transaction-System/internal/domain/fx"
+	"Transaction-System/internal/domain/transaction"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TransactionRepository es una implementación en memoria de transaction.Repository.
+// Es segura para uso concurrente; las transacciones se indexan por cuenta para consultar el historial.
+type TransactionRepository struct {
+	mu        sync.RWMutex                      // Protege el acceso a las transacciones
+	byAccount map[int][]transaction.Transaction // Transacciones de cada cuenta, en orden de inserción
+	quoteLegs map[quoteLeg]struct{}             // Patas ya liquidadas con cada cotización, como el índice único de SQL
+	ids       sequence                          // Generador de IDs
+}
+
+// quoteLeg identifica una pata de transferencia liquidada con una cotización.
+type quoteLeg struct {
+	quoteID         string
+	transactionType string
+}
+
+// Asegurar que TransactionRepository implementa la interfaz transaction.Repository.
+var _ transaction.Repository = &TransactionRepository{}
+
+// NewTransactionRepository crea un repositorio de transacciones vacío.
+func NewTransactionRepository() *TransactionRepository {
+	return &TransactionRepository{byAccount: make(map[int][]transaction.Transaction), quoteLegs: make(map[quoteLeg]struct{})}
+}
+
+// Save guarda una transacción. Si no tiene ID se le asigna el siguiente; si lo tiene se respeta.
+// Igual que el índice único de SQL sobre (fx_quote_id, transaction_type), cada cotización liquida una
+// sola pata de cada tipo: una segunda se rechaza con fx.ErrQuoteUsed.
+func (r *TransactionRepository) Save(_ context.Context, t *transaction.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t.Conversion != nil {
+		leg := quoteLeg{quoteID: t.Conversion.QuoteID, transactionType: t.TransactionType}
+		if _, ok := r.quoteLegs[leg]; ok {
+			return fmt.Errorf("%w: %s", fx.ErrQuoteUsed, leg.quoteID)
+		}
+		r.quoteLegs[leg] = struct{}{}
+	}
+	if t.ID == 0 {
+		t.ID = r.ids.next()
+	} else {
+		r.ids.observe(t.ID)
+	}
+	r.byAccount[t.AccountID] = append(r.byAccount[t.AccountID], *t)
+	return nil
+}
+
+// FindByID devuelve una copia de la transacción.
+func (r *TransactionRepository) FindByID(_ context.Context, id int) (*transaction.Transaction, error) {
+	return r.find(func(t *transaction.Transaction) bool { return t.ID == id })
+}
+
+// FindReversal devuelve una copia de la transacción que revierte originalID.
+func (r *TransactionRepository) FindReversal(_ context.Context, originalID int) (*transaction.Transaction, error) {
+	return r.find(func(t *transaction.Transaction) bool { return t.ReversalOf == originalID })
+}
+
+// FindByQuote devuelve una copia de la pata de débito de la transferencia liquidada con quoteID.
+func (r *TransactionRepository) FindByQuote(_ context.Context, quoteID string) (*transaction.Transaction, error) {
+	return r.find(func(t *transaction.Transaction) bool { return settlesQuote(t, quoteID) })
+}
+
+// settlesQuote indica si t es la pata de débito de una transferencia liquidada con la cotización quoteID.
+func settlesQuote(t *transaction.Transaction, quoteID string) bool {
+	return t.TransactionType == transaction.TypeTransferOut && t.Conversion != nil && t.Conversion.QuoteID == quoteID
+}
+
+// find devuelve una copia de la primera transacción que cumple match, o transaction.ErrNotFound.
+func (r *TransactionRepository) find(match func(t *transaction.Transaction) bool) (*transaction.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, txs := range r.byAccount {
+		for i := range txs {
+			if match(&txs[i]) {
+				cp := txs[i]
+				return &cp, nil
+			}
+		}
+	}
+	return nil, transaction.ErrNotFound
+}
+
+// FindByAccount devuelve copias de las transacciones de la cuenta que cumplen el filtro,
+// en el orden del historial y limitadas a filter.Limit.
+func (r *TransactionRepository) FindByAccount(_ context.Context, accountID int, filter transaction.Filter) ([]*transaction.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found []*transaction.Transaction
+	for _, t := range r.byAccount[accountID] {
+		if filter.Matches(&t) {
+			cp := t
+			found = append(found, &cp)
+		}
+	}
+	transaction.SortHistory(found)
+	return found[:min(filter.Limit, len(found))], nil
+}
+
+// remove elimina una transacción; la unidad de trabajo lo usa para deshacer una transacción guardada
+// si no pudo confirmar el resto de los cambios.
+func (r *TransactionRepository) remove(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for accountID, txs := range r.byAccount {
+		for i := range txs {
+			if txs[i].ID != id {
+				continue
+			}
+			if c := txs[i].Conversion; c != nil {
+				delete(r.quoteLegs, quoteLeg{quoteID: c.QuoteID, transactionType: txs[i].TransactionType})
+			}
+			r.byAccount[accountID] = append(txs[:i:i], txs[i+1:]...)
+			return
+		}
+	}
+}
+
+// allocateID reserva el próximo ID sin guardar la transacción; la unidad de trabajo lo usa para
+// que el asiento contable pueda referenciar la transacción antes de confirmarla.
+func (r *TransactionRepository) allocateID() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ids.next()
+}
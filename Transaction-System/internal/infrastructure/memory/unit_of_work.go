@@ -0,0 +1,447 @@
+package memory
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/hold"
+	"Transaction-System/internal/domain/ledger"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/domain/transaction"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// UnitOfWork es una implementación en memoria de application.UnitOfWork.
+// Envuelve repositorios existentes y acumula los cambios realizados durante la ejecución;
+// sólo si la función termina sin error los cambios se aplican sobre los repositorios envueltos.
+// Las ejecuciones se serializan, por lo que cada una se comporta como una transacción aislada.
+// Con los repositorios de este paquete, los IDs de las filas nuevas se reservan al crearlas, como con un
+// INSERT dentro de una transacción de base de datos, y las filas sólo se guardan al confirmar.
+// Con otros repositorios, las cuentas nuevas se guardan de inmediato para obtener su ID.
+// El libro mayor y las retenciones de fondos se mantienen en memoria dentro de la propia unidad de trabajo.
+type UnitOfWork struct {
+	mu           sync.Mutex             // Serializa las ejecuciones; cada una ve el estado confirmado por la anterior
+	accounts     account.Repository     // Repositorio de cuentas subyacente
+	transactions transaction.Repository // Repositorio de transacciones subyacente
+	ledger       *LedgerRepository      // Libro mayor en memoria
+	holds        *HoldRepository        // Retenciones de fondos en memoria
+}
+
+// Asegurar que UnitOfWork implementa la interfaz application.UnitOfWork.
+var _ application.UnitOfWork = &UnitOfWork{}
+
+// NewUnitOfWork crea una unidad de trabajo en memoria sobre los repositorios indicados.
+// Parámetros:
+// - accounts: repositorio de cuentas donde se aplicarán los cambios confirmados.
+// - transactions: repositorio de transacciones donde se guardarán las transacciones confirmadas.
+func NewUnitOfWork(accounts account.Repository, transactions transaction.Repository) *UnitOfWork {
+	return &UnitOfWork{accounts: accounts, transactions: transactions, ledger: NewLedgerRepository(), holds: NewHoldRepository()}
+}
+
+// Ledger devuelve el libro mayor en memoria con los asientos confirmados.
+func (u *UnitOfWork) Ledger() *LedgerRepository {
+	return u.ledger
+}
+
+// Holds devuelve el repositorio en memoria con las retenciones de fondos confirmadas.
+func (u *UnitOfWork) Holds() *HoldRepository {
+	return u.holds
+}
+
+// Execute ejecuta fn con repositorios que registran los cambios de forma provisional.
+// Si fn devuelve un error los cambios se descartan; en caso contrario se aplican en orden.
+// Si ctx se cancela antes de confirmar, los cambios también se descartan.
+func (u *UnitOfWork) Execute(ctx context.Context, fn func(repos application.Repositories) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Estado provisional de la unidad de trabajo
+	s := &staging{base: u, accounts: make(map[int]*account.Account), originals: make(map[int]account.Account), holds: make(map[string]*hold.Hold)}
+
+	// Ejecutar la lógica de negocio; si falla, el estado provisional simplemente se descarta
+	if err := fn(application.Repositories{
+		Accounts:     &stagedAccounts{s},
+		Transactions: &stagedTransactions{s},
+		Ledger:       &stagedLedger{s},
+		Holds:        &stagedHolds{s},
+	}); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Confirmar los cambios sobre los repositorios subyacentes
+	return s.commit(ctx)
+}
+
+// staging guarda los cambios pendientes de una ejecución de la unidad de trabajo.
+type staging struct {
+	base         *UnitOfWork
+	accounts     map[int]*account.Account   // Cuentas leídas o modificadas, indexadas por ID
+	originals    map[int]account.Account    // Estado original de las cuentas leídas, para revertir
+	created      []int                      // IDs de cuentas nuevas pendientes de guardar, en orden
+	updated      []int                      // IDs de cuentas actualizadas, en orden
+	transactions []*transaction.Transaction // Transacciones pendientes de guardar
+	entries      []*ledger.JournalEntry     // Asientos contables pendientes de guardar
+	holds        map[string]*hold.Hold      // Retenciones nuevas o modificadas, indexadas por ID
+	holdOrder    []string                   // IDs de las retenciones pendientes de guardar, en orden
+}
+
+// commit aplica los cambios pendientes sobre los repositorios subyacentes.
+// Las actualizaciones se comparan con la versión leída al comienzo de la ejecución, de modo que
+// una modificación hecha fuera de la unidad de trabajo se detecta como conflicto.
+// Si alguna escritura falla se deshacen todas las anteriores: las cuentas actualizadas recuperan su estado
+// original, las cuentas y transacciones nuevas se eliminan (si el repositorio lo permite), los asientos
+// guardados se descartan y las retenciones recuperan su estado anterior.
+func (s *staging) commit(ctx context.Context) error {
+	var saved, applied, recorded []int        // Cuentas guardadas y actualizadas, y transacciones guardadas
+	var stored []*hold.Hold                   // Estado anterior de las retenciones guardadas; nil si eran nuevas
+	var storedIDs []string                    // IDs de las retenciones guardadas, en el mismo orden
+	journaled := len(s.base.ledger.Entries()) // Asientos confirmados antes de esta unidad de trabajo
+	rollback := func(err error) error {
+		for i, id := range storedIDs {
+			if stored[i] == nil {
+				s.base.holds.remove(id)
+			} else {
+				_ = s.base.holds.Save(ctx, stored[i])
+			}
+		}
+		s.base.ledger.truncate(journaled)
+		if r, ok := s.base.transactions.(interface{ remove(id int) }); ok {
+			for _, id := range recorded {
+				r.remove(id)
+			}
+		}
+		for _, id := range applied {
+			// La versión guardada ya avanzó; se restaura el contenido sobre esa versión
+			restore := s.originals[id]
+			restore.Version = s.accounts[id].Version
+			_ = s.base.accounts.Update(ctx, &restore)
+		}
+		if r, ok := s.base.accounts.(interface{ remove(id int) }); ok {
+			for _, id := range saved {
+				r.remove(id)
+			}
+		}
+		return err
+	}
+
+	for _, id := range s.created {
+		created := s.originals[id]
+		if err := s.base.accounts.Save(ctx, &created); err != nil {
+			return rollback(err)
+		}
+		saved = append(saved, id)
+	}
+	for _, id := range s.updated {
+		// Actualizar desde la versión original; la actualización la lleva a la versión provisional
+		updated := *s.accounts[id]
+		updated.Version = s.originals[id].Version
+		if err := s.base.accounts.Update(ctx, &updated); err != nil {
+			return rollback(err)
+		}
+		applied = append(applied, id)
+	}
+	for _, t := range s.transactions {
+		if err := s.base.transactions.Save(ctx, t); err != nil {
+			return rollback(err)
+		}
+		recorded = append(recorded, t.ID)
+	}
+	for _, e := range s.entries {
+		if err := s.base.ledger.Append(ctx, e); err != nil {
+			return rollback(err)
+		}
+	}
+	for _, id := range s.holdOrder {
+		previous, err := s.base.holds.FindByID(ctx, id)
+		if err != nil && !errors.Is(err, hold.ErrNotFound) {
+			return rollback(err)
+		}
+		// Save reemplaza la retención si ya existía, por lo que sirve tanto para las nuevas como para las modificadas
+		if err := s.base.holds.Save(ctx, s.holds[id]); err != nil {
+			return rollback(err)
+		}
+		stored = append(stored, previous)
+		storedIDs = append(storedIDs, id)
+	}
+	return nil
+}
+
+// stagedAccounts es el repositorio de cuentas que se entrega dentro de la unidad de trabajo.
+// Devuelve copias de las cuentas para que las modificaciones no sean visibles antes de confirmar.
+type stagedAccounts struct {
+	s *staging
+}
+
+// Save registra una cuenta nueva. Si el repositorio subyacente reserva IDs, la cuenta recibe su ID
+// y se guarda al confirmar; en caso contrario se guarda de inmediato para obtenerlo.
+func (r *stagedAccounts) Save(ctx context.Context, a *account.Account) error {
+	if alloc, ok := r.s.base.accounts.(idAllocator); ok {
+		if a.Status == "" {
+			a.Status = account.StatusActive
+		}
+		a.ID = alloc.allocateID()
+		r.s.created = append(r.s.created, a.ID)
+	} else if err := r.s.base.accounts.Save(ctx, a); err != nil {
+		return err
+	}
+	stored := *a
+	r.s.originals[a.ID] = stored
+	r.s.accounts[a.ID] = &stored
+	return nil
+}
+
+// Update registra los cambios de una cuenta para aplicarlos al confirmar.
+// Igual que el repositorio de MySQL, compara la versión con la última leída o actualizada
+// dentro de la unidad de trabajo y la incrementa.
+func (r *stagedAccounts) Update(ctx context.Context, a *account.Account) error {
+	if current, ok := r.s.accounts[a.ID]; ok && current.Version != a.Version {
+		return fmt.Errorf("cuenta %d (versión %d): %w", a.ID, a.Version, account.ErrVersionConflict)
+	}
+	if !slices.Contains(r.s.updated, a.ID) {
+		r.s.updated = append(r.s.updated, a.ID)
+	}
+	a.Version++
+	cp := *a
+	r.s.accounts[a.ID] = &cp
+	return nil
+}
+
+// FindByID busca la cuenta primero en el estado provisional y luego en el repositorio subyacente.
+func (r *stagedAccounts) FindByID(ctx context.Context, id int) (*account.Account, error) {
+	return r.find(ctx, id, r.s.base.accounts.FindByID)
+}
+
+// FindByIDForUpdate busca la cuenta como FindByID, leyéndola del repositorio subyacente con bloqueo.
+func (r *stagedAccounts) FindByIDForUpdate(ctx context.Context, id int) (*account.Account, error) {
+	return r.find(ctx, id, r.s.base.accounts.FindByIDForUpdate)
+}
+
+// find devuelve la versión provisional de la cuenta o, si no la hay, la lee con lookup y la registra.
+func (r *stagedAccounts) find(ctx context.Context, id int, lookup func(context.Context, int) (*account.Account, error)) (*account.Account, error) {
+	if a, ok := r.s.accounts[id]; ok {
+		cp := *a
+		return &cp, nil
+	}
+	a, err := lookup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stored := *a
+	r.s.originals[id] = stored
+	r.s.accounts[id] = &stored
+	cp := stored
+	return &cp, nil
+}
+
+// List consulta el repositorio subyacente y reemplaza las cuentas modificadas por su versión provisional.
+func (r *stagedAccounts) List(ctx context.Context, filter account.ListFilter) ([]*account.Account, int, error) {
+	accounts, total, err := r.s.base.accounts.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, a := range accounts {
+		if staged, ok := r.s.accounts[a.ID]; ok {
+			cp := *staged
+			accounts[i] = &cp
+		}
+	}
+	return accounts, total, nil
+}
+
+// stagedTransactions es el repositorio de transacciones que se entrega dentro de la unidad de trabajo.
+type stagedTransactions struct {
+	s *staging
+}
+
+// Save registra una transacción para guardarla al confirmar.
+// Si el repositorio subyacente reserva IDs, la transacción recibe su ID de inmediato,
+// para que el asiento contable pueda referenciarla.
+func (r *stagedTransactions) Save(ctx context.Context, t *transaction.Transaction) error {
+	if alloc, ok := r.s.base.transactions.(idAllocator); ok && t.ID == 0 {
+		t.ID = alloc.allocateID()
+	}
+	r.s.transactions = append(r.s.transactions, t)
+	return nil
+}
+
+// FindByID busca la transacción entre las pendientes y, si no está, entre las confirmadas.
+func (r *stagedTransactions) FindByID(ctx context.Context, id int) (*transaction.Transaction, error) {
+	for _, t := range r.s.transactions {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return r.s.base.transactions.FindByID(ctx, id)
+}
+
+// FindReversal busca la reversión de originalID entre las transacciones pendientes y las confirmadas.
+func (r *stagedTransactions) FindReversal(ctx context.Context, originalID int) (*transaction.Transaction, error) {
+	for _, t := range r.s.transactions {
+		if t.ReversalOf == originalID {
+			return t, nil
+		}
+	}
+	return r.s.base.transactions.FindReversal(ctx, originalID)
+}
+
+// FindByQuote busca la pata de débito liquidada con quoteID entre las transacciones pendientes y las confirmadas.
+func (r *stagedTransactions) FindByQuote(ctx context.Context, quoteID string) (*transaction.Transaction, error) {
+	for _, t := range r.s.transactions {
+		if settlesQuote(t, quoteID) {
+			return t, nil
+		}
+	}
+	return r.s.base.transactions.FindByQuote(ctx, quoteID)
+}
+
+// FindByAccount combina el historial confirmado de la cuenta con las transacciones pendientes que cumplen el filtro.
+func (r *stagedTransactions) FindByAccount(ctx context.Context, accountID int, filter transaction.Filter) ([]*transaction.Transaction, error) {
+	found, err := r.s.base.transactions.FindByAccount(ctx, accountID, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range r.s.transactions {
+		if t.AccountID == accountID && filter.Matches(t) {
+			found = append(found, t)
+		}
+	}
+	transaction.SortHistory(found)
+	if len(found) > filter.Limit {
+		found = found[:filter.Limit]
+	}
+	return found, nil
+}
+
+// stagedLedger es el libro mayor que se entrega dentro de la unidad de trabajo.
+// Las sumas incluyen tanto los asientos confirmados como los pendientes.
+type stagedLedger struct {
+	s *staging
+}
+
+// Append valida el asiento y lo registra para guardarlo al confirmar.
+func (r *stagedLedger) Append(ctx context.Context, e *ledger.JournalEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	r.s.entries = append(r.s.entries, e)
+	return nil
+}
+
+// Sum suma los movimientos confirmados y pendientes de una cuenta contable.
+func (r *stagedLedger) Sum(ctx context.Context, accountCode string, currency string) (money.Money, int, error) {
+	committed, count, err := r.s.base.ledger.Sum(ctx, accountCode, currency)
+	if err != nil {
+		return money.Money{}, 0, err
+	}
+	pending, pendingCount, err := sumPostings(r.s.entries, accountCode, currency)
+	if err != nil {
+		return money.Money{}, 0, err
+	}
+	total, err := committed.Add(pending)
+	if err != nil {
+		return money.Money{}, 0, err
+	}
+	return total, count + pendingCount, nil
+}
+
+// TrialBalance devuelve el balance de comprobación de los asientos confirmados y pendientes.
+func (r *stagedLedger) TrialBalance(ctx context.Context) (map[string]money.Money, error) {
+	totals, err := r.s.base.ledger.TrialBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range r.s.entries {
+		for _, p := range e.Postings {
+			total, ok := totals[p.Amount.Currency()]
+			if !ok {
+				total = money.Zero(p.Amount.Currency())
+			}
+			if totals[p.Amount.Currency()], err = total.Add(p.Amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return totals, nil
+}
+
+// stagedHolds es el repositorio de retenciones que se entrega dentro de la unidad de trabajo.
+// Devuelve copias de las retenciones para que las modificaciones no sean visibles antes de confirmar.
+type stagedHolds struct {
+	s *staging
+}
+
+// stage registra una copia de la retención para guardarla al confirmar.
+func (r *stagedHolds) stage(h *hold.Hold) {
+	if _, ok := r.s.holds[h.ID]; !ok {
+		r.s.holdOrder = append(r.s.holdOrder, h.ID)
+	}
+	cp := *h
+	r.s.holds[h.ID] = &cp
+}
+
+// Save registra una retención nueva para guardarla al confirmar.
+func (r *stagedHolds) Save(ctx context.Context, h *hold.Hold) error {
+	r.stage(h)
+	return nil
+}
+
+// Update registra los cambios de una retención para aplicarlos al confirmar.
+func (r *stagedHolds) Update(ctx context.Context, h *hold.Hold) error {
+	if _, err := r.FindByID(ctx, h.ID); err != nil {
+		return err
+	}
+	r.stage(h)
+	return nil
+}
+
+// FindByID busca la retención primero en el estado provisional y luego en el repositorio subyacente.
+func (r *stagedHolds) FindByID(ctx context.Context, id string) (*hold.Hold, error) {
+	if h, ok := r.s.holds[id]; ok {
+		cp := *h
+		return &cp, nil
+	}
+	return r.s.base.holds.FindByID(ctx, id)
+}
+
+// FindByIDForUpdate busca la retención como FindByID, leyéndola del repositorio subyacente con bloqueo.
+func (r *stagedHolds) FindByIDForUpdate(ctx context.Context, id string) (*hold.Hold, error) {
+	if h, ok := r.s.holds[id]; ok {
+		cp := *h
+		return &cp, nil
+	}
+	return r.s.base.holds.FindByIDForUpdate(ctx, id)
+}
+
+// FindExpired combina las retenciones vencidas confirmadas con su versión provisional y con las
+// retenciones pendientes que también vencieron.
+func (r *stagedHolds) FindExpired(ctx context.Context, now time.Time, limit int) ([]*hold.Hold, error) {
+	committed, err := r.s.base.holds.FindExpired(ctx, now, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	var expired []*hold.Hold
+	for _, h := range committed {
+		if _, ok := r.s.holds[h.ID]; !ok {
+			expired = append(expired, h)
+		}
+	}
+	for _, id := range r.s.holdOrder {
+		if h := r.s.holds[id]; h.Expired(now) {
+			cp := *h
+			expired = append(expired, &cp)
+		}
+	}
+	sortByExpiry(expired)
+	return expired[:min(limit, len(expired))], nil
+}
@@ -0,0 +1,83 @@
+package metrics_test
+
+import (
+	"Transaction-System/internal/application"
+	"Transaction-System/internal/domain/account"
+	"Transaction-System/internal/domain/money"
+	"Transaction-System/internal/infrastructure/metrics"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scrape devuelve el texto que expone /metrics
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rr.Body)
+	return string(body)
+}
+
+// Prueba la clasificación de los errores de un movimiento por resultado
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, metrics.OutcomeSuccess},
+		{fmt.Errorf("retiro: %w", account.ErrInsufficientFunds), metrics.OutcomeInsufficientFunds},
+		{account.ErrAccountFrozen, metrics.OutcomeRejected},
+		{&application.ValidationError{}, metrics.OutcomeRejected},
+		{&application.ConflictError{Attempts: 5, Err: account.ErrVersionConflict}, metrics.OutcomeConflict},
+		{errors.New("conexión perdida"), metrics.OutcomeError},
+	}
+	for _, tt := range tests {
+		if got := metrics.Outcome(tt.err); got != tt.want {
+			t.Errorf("Outcome(%v) = %q, se esperaba %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+// Prueba que los movimientos se cuentan y suman por tipo y resultado, y que se cuentan los fondos insuficientes
+func TestTransactionProcessed(t *testing.T) {
+	m := metrics.New()
+	m.TransactionProcessed("deposit", money.MustParse("100.50", "USD"), nil)
+	m.TransactionProcessed("deposit", money.MustParse("20.00", "USD"), nil)
+	m.TransactionProcessed("withdrawal", money.MustParse("500.00", "USD"), account.ErrInsufficientFunds)
+
+	body := scrape(t, m)
+	for _, line := range []string{
+		`bank_transactions_total{outcome="success",type="deposit"} 2`,
+		`bank_transaction_amount_total{currency="USD",outcome="success",type="deposit"} 120.5`,
+		`bank_transactions_total{outcome="insufficient_funds",type="withdrawal"} 1`,
+		`bank_insufficient_funds_total{type="withdrawal"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Se esperaba la línea %q en las métricas", line)
+		}
+	}
+}
+
+// Prueba que las solicitudes se etiquetan con el patrón de la ruta y el código de estado
+func TestInstrumentRoute(t *testing.T) {
+	m := metrics.New()
+	handler := m.InstrumentRoute("GET /accounts/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	for _, id := range []string{"1", "2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/"+id, nil))
+	}
+
+	body := scrape(t, m)
+	if want := `bank_http_requests_total{method="GET",route="GET /accounts/{id}",status="404"} 2`; !strings.Contains(body, want) {
+		t.Errorf("Se esperaba la línea %q en las métricas", want)
+	}
+	if !strings.Contains(body, "bank_http_request_duration_seconds_bucket") {
+		t.Error("Se esperaba el histograma de latencia en las métricas")
+	}
+}
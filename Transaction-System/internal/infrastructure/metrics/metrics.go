@@ -0,0 +1,167 @@
+// Package metrics publica las métricas del servicio en formato Prometheus.
+// Reúne las métricas HTTP por ruta, los movimientos de dinero por resultado y las estadísticas
+// del pool de conexiones a la base de datos en un registro propio, expuesto en /metrics.
+package metrics
+
+import (
+	"Transaction-System/internal/application"        // Observador de movimientos y errores de la aplicación
+	"Transaction-System/internal/domain/account"     // Errores del dominio de cuentas
+	"Transaction-System/internal/domain/money"       // Tipo Money para montos exactos
+	"Transaction-System/internal/domain/transaction" // Errores de la reversión de transacciones
+	"database/sql"                                   // Estadísticas del pool de conexiones
+	"errors"                                         // Paquete para inspeccionar errores
+	"net/http"                                       // Instrumentación de los manejadores HTTP
+	"strconv"                                        // Conversión del código de estado a etiqueta
+	"time"                                           // Paquete para medir duraciones
+
+	"github.com/prometheus/client_golang/prometheus"            // Tipos de métricas
+	"github.com/prometheus/client_golang/prometheus/collectors" // Colectores de Go, del proceso y de sql.DB
+	"github.com/prometheus/client_golang/prometheus/promhttp"   // Manejador de /metrics
+)
+
+// Resultados con los que se etiquetan los movimientos de dinero.
+const (
+	OutcomeSuccess           = "success"            // El movimiento se completó
+	OutcomeInsufficientFunds = "insufficient_funds" // El balance no alcanzaba
+	OutcomeRejected          = "rejected"           // La solicitud o la cuenta no permitían el movimiento
+	OutcomeConflict          = "conflict"           // Se agotaron los reintentos por concurrencia
+	OutcomeError             = "error"              // Error inesperado (por ejemplo, de la base de datos)
+)
+
+// Metrics agrupa las métricas del servicio y el registro donde se publican.
+// Implementa application.TransactionObserver para contar los movimientos de dinero.
+type Metrics struct {
+	registry *prometheus.Registry // Registro propio, independiente del registro global
+
+	httpRequests      *prometheus.CounterVec   // Solicitudes HTTP por ruta, método y estado
+	httpDuration      *prometheus.HistogramVec // Latencia de las solicitudes HTTP por ruta, método y estado
+	transactions      *prometheus.CounterVec   // Movimientos por tipo y resultado
+	transactionAmount *prometheus.CounterVec   // Suma de montos por tipo, resultado y moneda
+	insufficientFunds *prometheus.CounterVec   // Rechazos por fondos insuficientes por tipo
+}
+
+// Asegurar que Metrics implementa la interfaz application.TransactionObserver.
+var _ application.TransactionObserver = &Metrics{}
+
+// New crea las métricas del servicio junto con los colectores del runtime de Go y del proceso.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bank_http_requests_total",
+			Help: "Solicitudes HTTP atendidas, por ruta, método y código de estado.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bank_http_request_duration_seconds",
+			Help:    "Latencia de las solicitudes HTTP en segundos, por ruta, método y código de estado.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"route", "method", "status"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bank_transactions_total",
+			Help: "Depósitos, retiros y transferencias procesados, por tipo y resultado.",
+		}, []string{"type", "outcome"}),
+		transactionAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bank_transaction_amount_total",
+			Help: "Suma de los montos de los movimientos procesados, por tipo, resultado y moneda.",
+		}, []string{"type", "outcome", "currency"}),
+		insufficientFunds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bank_insufficient_funds_total",
+			Help: "Movimientos rechazados por fondos insuficientes, por tipo.",
+		}, []string{"type"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.transactions, m.transactionAmount, m.insufficientFunds,
+	)
+	return m
+}
+
+// Registry devuelve el registro donde se publican las métricas, para registrar colectores adicionales.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler devuelve el manejador HTTP que expone las métricas en el formato de Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB publica las estadísticas del pool de conexiones (sql.DBStats) de la base de datos
+// con la etiqueta db_name indicada: conexiones abiertas, en uso e inactivas, esperas y cierres.
+func (m *Metrics) RegisterDB(name string, db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// InstrumentRoute envuelve el manejador de una ruta para contar sus solicitudes y medir su latencia.
+// route es el patrón con el que se registró la ruta (por ejemplo "GET /accounts/{id}"), de modo que
+// todas las cuentas comparten la misma serie en lugar de crear una por ID.
+func (m *Metrics) InstrumentRoute(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.status)
+		m.httpRequests.WithLabelValues(route, r.Method, status).Inc()
+		m.httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// TransactionProcessed implementa la interfaz application.TransactionObserver.
+func (m *Metrics) TransactionProcessed(transactionType string, amount money.Money, err error) {
+	outcome := Outcome(err)
+	m.transactions.WithLabelValues(transactionType, outcome).Inc()
+	if amount.Currency() != "" {
+		value, _ := amount.Rat().Float64()
+		m.transactionAmount.WithLabelValues(transactionType, outcome, amount.Currency()).Add(value)
+	}
+	if outcome == OutcomeInsufficientFunds {
+		m.insufficientFunds.WithLabelValues(transactionType).Inc()
+	}
+}
+
+// Outcome clasifica el error con el que terminó un movimiento en uno de los resultados Outcome*.
+func Outcome(err error) string {
+	var validation *application.ValidationError
+	var conflict *application.ConflictError
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, account.ErrInsufficientFunds), errors.Is(err, account.ErrOverdraftLimitExceeded):
+		return OutcomeInsufficientFunds
+	case errors.As(err, &conflict):
+		return OutcomeConflict
+	case errors.As(err, &validation),
+		errors.Is(err, account.ErrNotFound),
+		errors.Is(err, account.ErrInvalidAmount),
+		errors.Is(err, account.ErrAccountFrozen),
+		errors.Is(err, account.ErrAccountClosed),
+		errors.Is(err, money.ErrCurrencyMismatch),
+		errors.Is(err, application.ErrInvalidTransactionType),
+		errors.Is(err, application.ErrSameAccount),
+		errors.Is(err, transaction.ErrNotFound),
+		errors.Is(err, transaction.ErrAlreadyReversed),
+		errors.Is(err, transaction.ErrNotReversible):
+		return OutcomeRejected
+	default:
+		return OutcomeError
+	}
+}
+
+// statusRecorder captura el código de estado escrito por el manejador.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int // Código de estado de la respuesta
+}
+
+// WriteHeader registra el código de estado antes de escribirlo.
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap permite a http.ResponseController acceder al ResponseWriter original.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
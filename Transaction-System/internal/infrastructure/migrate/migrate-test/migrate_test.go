@@ -0,0 +1,96 @@
+package migrate_test
+
+import (
+	"Transaction-System/internal/infrastructure/database"
+	"Transaction-System/internal/infrastructure/migrate"
+	"testing"
+	"testing/fstest"
+)
+
+// Prueba que las migraciones se ordenan por versión y se separan en sentencias sin comentarios
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_status.up.sql":   {Data: []byte("-- Estado de la cuenta\nALTER TABLE accounts\n    ADD COLUMN status VARCHAR(10);\nUPDATE accounts SET status = 'active';\n")},
+		"0002_add_status.down.sql": {Data: []byte("ALTER TABLE accounts DROP COLUMN status;\n")},
+		"0001_init.up.sql":         {Data: []byte("CREATE TABLE accounts (id INT);")},
+		"0001_init.down.sql":       {Data: []byte("DROP TABLE accounts")},
+		"README.md":                {Data: []byte("no es una migración")},
+	}
+
+	migrations, err := migrate.Load(fsys)
+	if err != nil {
+		t.Fatalf("Error al cargar las migraciones: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Fatalf("Se esperaban las versiones 1 y 2 en orden, obtenido %+v", migrations)
+	}
+	if got := migrations[1]; got.Name != "add_status" || len(got.Up) != 2 || len(got.Down) != 1 {
+		t.Fatalf("Migración inesperada: %+v", got)
+	}
+	if want := "ALTER TABLE accounts\n    ADD COLUMN status VARCHAR(10)"; migrations[1].Up[0] != want {
+		t.Errorf("Sentencia inesperada: %q", migrations[1].Up[0])
+	}
+	if want := "DROP TABLE accounts"; migrations[0].Down[0] != want {
+		t.Errorf("Se esperaba la sentencia final sin punto y coma, obtenido %q", migrations[0].Down[0])
+	}
+}
+
+// Prueba que se rechazan las migraciones incompletas o con versiones repetidas
+func TestLoad_Errors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"sin down": {
+			"0001_init.up.sql": {Data: []byte("CREATE TABLE accounts (id INT);")},
+		},
+		"versión repetida": {
+			"0001_init.up.sql":    {Data: []byte("CREATE TABLE a (id INT);")},
+			"0001_init.down.sql":  {Data: []byte("DROP TABLE a;")},
+			"0001_other.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+			"0001_other.down.sql": {Data: []byte("DROP TABLE b;")},
+		},
+		"archivo vacío": {
+			"0001_init.up.sql":   {Data: []byte("-- pendiente\n")},
+			"0001_init.down.sql": {Data: []byte("DROP TABLE a;")},
+		},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := migrate.Load(fsys); err == nil {
+				t.Error("Se esperaba un error")
+			}
+		})
+	}
+}
+
+// Prueba que las migraciones embebidas de cada motor son válidas, tienen versiones consecutivas
+// y coinciden en versiones y nombres entre motores
+func TestEmbeddedMigrations(t *testing.T) {
+	mysql, err := database.Migrations(database.MySQL)
+	if err != nil {
+		t.Fatalf("Migraciones embebidas de MySQL inválidas: %v", err)
+	}
+	if len(mysql) == 0 {
+		t.Fatal("Se esperaba al menos una migración embebida")
+	}
+	for i, m := range mysql {
+		if m.Version != int64(i+1) {
+			t.Errorf("Se esperaba la versión %d en la posición %d, obtenido %d_%s", i+1, i, m.Version, m.Name)
+		}
+	}
+
+	// Los demás motores tienen las mismas versiones y nombres que MySQL
+	for _, driver := range []database.Driver{database.SQLite, database.PostgreSQL} {
+		migrations, err := database.Migrations(driver)
+		if err != nil {
+			t.Fatalf("Migraciones embebidas de %s inválidas: %v", driver, err)
+		}
+		if len(migrations) != len(mysql) {
+			t.Fatalf("Se esperaban %d migraciones de %s, obtenidas %d", len(mysql), driver, len(migrations))
+		}
+		for i := range mysql {
+			if migrations[i].Version != mysql[i].Version || migrations[i].Name != mysql[i].Name {
+				t.Errorf("La migración %d_%s de %s no coincide con %d_%s de MySQL",
+					migrations[i].Version, migrations[i].Name, driver, mysql[i].Version, mysql[i].Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package migrate aplica migraciones de esquema versionadas sobre una base de datos SQL.
+// Cada migración es un par de archivos NNNN_nombre.up.sql y NNNN_nombre.down.sql, normalmente
+// embebidos en el binario con embed; las versiones aplicadas se registran en la tabla schema_migrations.
+// Un bloqueo a nivel de base de datos impide que dos instancias del servicio migren a la vez.
+package migrate
+
+import (
+	"cmp"          // Comparación de versiones
+	"context"      // Cancelación y plazos de las operaciones
+	"database/sql" // Conexión a la base de datos
+	"errors"       // Paquete para definir errores
+	"fmt"          // Paquete para formatear errores
+	"io/fs"        // Lectura de los archivos de migración
+	"path"         // Nombres de los archivos de migración
+	"regexp"       // Formato de los nombres de archivo
+	"slices"       // Ordenamiento de las migraciones
+	"strconv"      // Conversión de la versión
+	"strings"      // Separación de sentencias
+)
+
+// Errores que puede devolver el Migrator.
+var (
+	// ErrDirty indica que una migración falló a medias y el esquema debe revisarse manualmente.
+	ErrDirty = errors.New("el esquema quedó en un estado intermedio")
+	// ErrLocked indica que no se obtuvo el bloqueo de migraciones dentro del plazo.
+	ErrLocked = errors.New("otra instancia está aplicando migraciones")
+)
+
+// Migration es una migración de esquema con sus sentencias de avance y de reversión.
+type Migration struct {
+	Version int64    // Versión de la migración; define el orden de aplicación
+	Name    string   // Nombre descriptivo, tomado del nombre del archivo
+	Up      []string // Sentencias que aplican la migración, en orden
+	Down    []string // Sentencias que revierten la migración, en orden
+}
+
+// Dialect aísla las diferencias entre motores de base de datos que afectan al Migrator.
+type Dialect interface {
+	// CreateTable devuelve la sentencia que crea la tabla schema_migrations si no existe.
+	// La tabla debe tener las columnas version, name, dirty y applied_at.
+	CreateTable() string
+	// Rebind adapta una consulta escrita con marcadores '?' a los del motor.
+	Rebind(query string) string
+	// Lock adquiere el bloqueo exclusivo de migraciones sobre conn y devuelve la función que lo libera.
+	// Debe devolver ErrLocked (envuelto) si no lo obtiene dentro de su plazo.
+	Lock(ctx context.Context, conn *sql.Conn) (unlock func() error, err error)
+}
+
+// fileName es el formato de los archivos de migración: versión, nombre y dirección.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load lee las migraciones de la raíz de fsys y las devuelve ordenadas por versión.
+// Los archivos que no siguen el formato NNNN_nombre.(up|down).sql se ignoran.
+// Retorna un error si una versión está repetida o si le falta alguno de sus dos archivos.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("no se pueden leer las migraciones: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("versión inválida en %s: %w", entry.Name(), err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("la versión %d está repetida: %s y %s", version, m.Name, match[2])
+		}
+
+		data, err := fs.ReadFile(fsys, path.Clean(entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("no se puede leer %s: %w", entry.Name(), err)
+		}
+		statements := splitStatements(string(data))
+		if match[3] == "up" {
+			m.Up = statements
+		} else {
+			m.Down = statements
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil || m.Down == nil {
+			return nil, fmt.Errorf("la migración %d_%s debe tener archivos up y down con al menos una sentencia", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return migrations, nil
+}
+
+// splitStatements separa un script SQL en sentencias, de modo que no haga falta habilitar
+// las sentencias múltiples en el driver. Una sentencia termina en un ';' al final de una línea;
+// las líneas vacías y los comentarios de línea ('--') se descartan.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
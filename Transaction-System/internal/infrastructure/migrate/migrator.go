@@ -0,0 +1,211 @@
+package migrate
+
+import (
+	"context"      // Cancelación y plazos de las operaciones
+	"database/sql" // Conexión a la base de datos
+	"errors"       // Paquete para combinar errores
+	"fmt"          // Paquete para formatear errores
+)
+
+// Status es el estado de una migración conocida por el Migrator.
+type Status struct {
+	Migration
+	Applied   bool   // Indica si la migración está registrada en schema_migrations
+	Dirty     bool   // Indica si la migración falló a medias
+	AppliedAt string // Fecha de aplicación tal como la devuelve la base de datos; vacía si no se aplicó
+}
+
+// Migrator aplica y revierte migraciones sobre una base de datos.
+// Es seguro usarlo desde varias instancias a la vez: Up y Down se ejecutan bajo el bloqueo del dialecto.
+type Migrator struct {
+	db         *sql.DB     // Conexión a la base de datos
+	dialect    Dialect     // Particularidades del motor
+	migrations []Migration // Migraciones conocidas, ordenadas por versión
+}
+
+// New crea un Migrator con las migraciones indicadas, que deben estar ordenadas por versión (ver Load).
+func New(db *sql.DB, dialect Dialect, migrations []Migration) *Migrator {
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}
+}
+
+// appliedRow es una fila de la tabla schema_migrations.
+type appliedRow struct {
+	dirty     bool
+	appliedAt string
+}
+
+// Up aplica, en orden, todas las migraciones pendientes.
+// Retorna las migraciones aplicadas; si una falla, las anteriores quedan aplicadas y la que falló
+// queda marcada como incompleta (ver ErrDirty).
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedRow) error {
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas, de la más reciente a la más antigua.
+// Retorna las migraciones revertidas.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedRow) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status devuelve el estado de cada migración conocida, ordenado por versión.
+// Crea la tabla schema_migrations si no existe, para poder informar el estado de una base sin migrar.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if _, err := m.db.ExecContext(ctx, m.dialect.CreateTable()); err != nil {
+		return nil, fmt.Errorf("no se pudo crear la tabla schema_migrations: %w", err)
+	}
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		row, ok := applied[mig.Version]
+		statuses[i] = Status{Migration: mig, Applied: ok, Dirty: row.dirty, AppliedAt: row.appliedAt}
+	}
+	return statuses, nil
+}
+
+// Pending devuelve las migraciones que aún no se aplicaron.
+// Retorna ErrDirty (envuelto) si alguna migración quedó incompleta.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range statuses {
+		if s.Dirty {
+			return nil, fmt.Errorf("migración %d_%s: %w", s.Version, s.Name, ErrDirty)
+		}
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// withLock reserva una conexión, toma el bloqueo de migraciones, crea la tabla schema_migrations
+// si no existe y ejecuta fn con las versiones ya aplicadas.
+// Si alguna migración quedó incompleta no ejecuta fn: el esquema debe corregirse antes de continuar.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, applied map[int64]appliedRow) error) (err error) {
+	// El bloqueo pertenece a la sesión, por lo que todas las sentencias usan la misma conexión
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("no se pudo obtener una conexión: %w", err)
+	}
+	defer conn.Close()
+
+	unlock, err := m.dialect.Lock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("no se pudo liberar el bloqueo de migraciones: %w", unlockErr))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, m.dialect.CreateTable()); err != nil {
+		return fmt.Errorf("no se pudo crear la tabla schema_migrations: %w", err)
+	}
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	for version, row := range applied {
+		if row.dirty {
+			return fmt.Errorf("migración %d: %w; corrija el esquema y elimine su fila de schema_migrations", version, ErrDirty)
+		}
+	}
+	return fn(conn, applied)
+}
+
+// querier abstrae *sql.DB y *sql.Conn para leer las versiones aplicadas.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// applied lee las versiones registradas en la tabla schema_migrations, que ya debe existir.
+func (m *Migrator) applied(ctx context.Context, q querier) (map[int64]appliedRow, error) {
+	rows, err := q.QueryContext(ctx, "SELECT version, dirty, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]appliedRow{}
+	for rows.Next() {
+		var version int64
+		var row appliedRow
+		if err := rows.Scan(&version, &row.dirty, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = row
+	}
+	return applied, rows.Err()
+}
+
+// apply ejecuta las sentencias de avance de una migración.
+// La migración se registra como incompleta antes de ejecutarlas y como completa al terminar,
+// porque muchos motores (MySQL entre ellos) confirman implícitamente las sentencias DDL
+// y no permiten agruparlas en una transacción.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx, m.dialect.Rebind("INSERT INTO schema_migrations (version, name, dirty) VALUES (?, ?, ?)"),
+		mig.Version, mig.Name, true); err != nil {
+		return fmt.Errorf("no se pudo registrar la migración %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if err := m.exec(ctx, conn, mig, mig.Up); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, m.dialect.Rebind("UPDATE schema_migrations SET dirty = ? WHERE version = ?"), false, mig.Version)
+	return err
+}
+
+// revert ejecuta las sentencias de reversión de una migración y elimina su registro.
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx, m.dialect.Rebind("UPDATE schema_migrations SET dirty = ? WHERE version = ?"), true, mig.Version); err != nil {
+		return fmt.Errorf("no se pudo marcar la migración %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if err := m.exec(ctx, conn, mig, mig.Down); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, m.dialect.Rebind("DELETE FROM schema_migrations WHERE version = ?"), mig.Version)
+	return err
+}
+
+// exec ejecuta las sentencias de una migración en orden.
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, mig Migration, statements []string) error {
+	for i, stmt := range statements {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migración %d_%s, sentencia %d: %w (%w)", mig.Version, mig.Name, i+1, err, ErrDirty)
+		}
+	}
+	return nil
+}
@@ -1,9 +1,33 @@
 import random
+import uuid
 from locust import HttpUser, TaskSet, task, between
 
+# Número máximo de intentos de una misma operación ante errores transitorios
+MAX_ATTEMPTS = 3
+
+# Proporción de operaciones exitosas que el cliente simulado reenvía, como si no hubiera recibido la respuesta
+REPLAY_RATIO = 0.1
+
 # Define el comportamiento de las transacciones bancarias para los usuarios simulados
 class BankTransactionBehavior(TaskSet):
 
+    def post_idempotent(self, path, payload):
+        """
+        Envía una operación con una única clave Idempotency-Key, generada una vez por operación lógica
+        y reutilizada en cada reintento, de modo que el servidor no duplique el movimiento.
+        Se reintenta ante errores de conexión, 5xx y 409 (la solicitud original sigue en curso); una parte
+        de las operaciones exitosas se reenvía para ejercitar la respuesta repetida (Idempotent-Replayed).
+        """
+        headers = {"Idempotency-Key": str(uuid.uuid4())}
+        for _ in range(MAX_ATTEMPTS):
+            response = self.client.post(path, json=payload, headers=headers)
+            if 0 < response.status_code < 500 and response.status_code != 409:
+                break
+        if response.ok and random.random() < REPLAY_RATIO:
+            with self.client.post(path, json=payload, headers=headers, name=path + " (reintento)", catch_response=True) as replay:
+                if replay.headers.get("Idempotent-Replayed") != "true":
+                    replay.failure("el reintento con la misma clave no devolvió la respuesta original")
+
     @task(1)
     def deposit(self):
         """
@@ -13,7 +37,8 @@ class BankTransactionBehavior(TaskSet):
         """
         account_id = random.randint(1, 100)  # Simula una cuenta con un ID aleatorio entre 1 y 100
         amount = round(random.uniform(10.0, 1000.0), 2)  # Genera un monto de depósito aleatorio entre 10.0 y 1000.0
-        self.client.post("/deposit", json={"account_id": account_id, "amount": amount})  # Envía la solicitud POST
+        # La cabecera Idempotency-Key evita que un reintento del cliente duplique el depósito
+        self.post_idempotent("/deposit", {"account_id": account_id, "amount": amount, "currency": "USD"})
 
     @task(1)
     def withdraw(self):
@@ -24,7 +49,8 @@ class BankTransactionBehavior(TaskSet):
         """
         account_id = random.randint(1, 100)  # Simula una cuenta con un ID aleatorio entre 1 y 100
         amount = round(random.uniform(10.0, 500.0), 2)  # Genera un monto de retiro aleatorio entre 10.0 y 500.0
-        self.client.post("/withdraw", json={"account_id": account_id, "amount": amount})  # Envía la solicitud POST
+        # La cabecera Idempotency-Key evita que un reintento del cliente duplique el retiro
+        self.post_idempotent("/withdraw", {"account_id": account_id, "amount": amount, "currency": "USD"})
 
 
 # Define el usuario virtual que ejecuta las transacciones bancarias